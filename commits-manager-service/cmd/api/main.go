@@ -13,12 +13,27 @@ import (
 	"commits-manager-service/platforms/routers"
 	"fmt"
 
+	am "commits-manager-service/internal/module/actions"
+	bm "commits-manager-service/internal/module/backfills"
 	cm "commits-manager-service/internal/module/commits"
+	im "commits-manager-service/internal/module/issues"
+	pm "commits-manager-service/internal/module/pulls"
+	relm "commits-manager-service/internal/module/releases"
 	rm "commits-manager-service/internal/module/repos"
+	sm "commits-manager-service/internal/module/schedules"
+	tm "commits-manager-service/internal/module/tracking"
 
+	"commits-manager-service/internal/http/grpc/protos/actions"
+	"commits-manager-service/internal/http/grpc/protos/backfills"
 	"commits-manager-service/internal/http/grpc/protos/commits"
+	"commits-manager-service/internal/http/grpc/protos/issues"
+	"commits-manager-service/internal/http/grpc/protos/pulls"
 	"commits-manager-service/internal/http/grpc/protos/repos"
+	actionsMetaData "commits-manager-service/internal/http/grpc/server/actions"
+	backfillsMetaData "commits-manager-service/internal/http/grpc/server/backfills"
 	commitMetaData "commits-manager-service/internal/http/grpc/server/commits"
+	issuesMetaData "commits-manager-service/internal/http/grpc/server/issues"
+	pullsMetaData "commits-manager-service/internal/http/grpc/server/pulls"
 	reposMetaData "commits-manager-service/internal/http/grpc/server/repos"
 
 	_ "github.com/jackc/pgconn"
@@ -57,17 +72,60 @@ func main() {
 	repositoriesHandler := handlers.NewRepositoriesHandler(repositoryManagerService)
 	repositoriesRouting := routing.RepositoriesRouting(repositoriesHandler)
 
+	workflowRunPersistence := db.NewWorkflowRunPersistence(dbConn)
+	actionsManagerService := am.NewActionsManagerService(workflowRunPersistence)
+	actionsHandler := handlers.NewActionsHandler(actionsManagerService, repositoryManagerService)
+	actionsRouting := routing.ActionsRouting(actionsHandler)
+
 	commitPersistence := db.NewCommitPersistence(dbConn)
-	commitsManagerService := cm.NewCommitsManagerService(commitPersistence)
-	commitsHandler := handlers.NewCommitsHandler(commitsManagerService)
+	commitsManagerService := cm.NewCommitsManagerService(commitPersistence, workflowRunPersistence)
+	commitsHandler := handlers.NewCommitsHandler(commitsManagerService, repositoryManagerService)
 	commitsRouting := routing.CommitsRouting(commitsHandler)
 
+	pullRequestPersistence := db.NewPullRequestPersistence(dbConn)
+	pullsManagerService := pm.NewPullsManagerService(pullRequestPersistence)
+	pullsHandler := handlers.NewPullsHandler(pullsManagerService, repositoryManagerService)
+	pullsRouting := routing.PullsRouting(pullsHandler)
+
+	issuePersistence := db.NewIssuePersistence(dbConn)
+	issuesManagerService := im.NewIssuesManagerService(issuePersistence)
+	issuesHandler := handlers.NewIssuesHandler(issuesManagerService, repositoryManagerService)
+	issuesRouting := routing.IssuesRouting(issuesHandler)
+
+	releasePersistence := db.NewReleasePersistence(dbConn)
+	releasesManagerService := relm.NewReleasesManagerService(releasePersistence, commitPersistence)
+	releasesHandler := handlers.NewReleasesHandler(releasesManagerService, repositoryManagerService)
+	releasesRouting := routing.ReleasesRouting(releasesHandler)
+
+	pollSchedulePersistence := db.NewPollSchedulePersistence(dbConn)
+	schedulesManagerService := sm.NewSchedulesManagerService(pollSchedulePersistence)
+	schedulesHandler := handlers.NewSchedulesHandler(schedulesManagerService, repositoryManagerService)
+	schedulesRouting := routing.SchedulesRouting(schedulesHandler)
+
+	backfillJobPersistence := db.NewBackfillJobPersistence(dbConn)
+	backfillsManagerService := bm.NewBackfillsManagerService(backfillJobPersistence, repositoryPersistence)
+	backfillsHandler := handlers.NewBackfillsHandler(backfillsManagerService, repositoryManagerService)
+	backfillsRouting := routing.BackfillsRouting(backfillsHandler)
+
+	trackedRepositoryPersistence := db.NewTrackedRepositoryPersistence(dbConn)
+	trackingManagerService := tm.NewTrackingManagerService(trackedRepositoryPersistence)
+	trackingHandler := handlers.NewTrackingHandler(trackingManagerService, repositoryManagerService)
+	trackingRouting := routing.TrackingRouting(trackingHandler)
+
 	var routesList []routers.Route
 	routesList = append(routesList, repositoriesRouting...)
 	routesList = append(routesList, commitsRouting...)
+	routesList = append(routesList, pullsRouting...)
+	routesList = append(routesList, issuesRouting...)
+	routesList = append(routesList, releasesRouting...)
+	routesList = append(routesList, actionsRouting...)
+	routesList = append(routesList, schedulesRouting...)
+	routesList = append(routesList, backfillsRouting...)
+	routesList = append(routesList, trackingRouting...)
 
 	consumer, err := event.NewConsumer(rabbitConn, "githubApiQueue",
-		commitPersistence, repositoryPersistence)
+		commitPersistence, repositoryPersistence, pullRequestPersistence, issuePersistence, releasePersistence,
+		workflowRunPersistence, backfillJobPersistence)
 	if err != nil {
 		log.Println("Listening for and consuming RabbitMQ messages...")
 		panic(err)
@@ -75,7 +133,7 @@ func main() {
 
 	// watch the queue and consume events
 	go func(eventConsumer event.Consumer) {
-		err = eventConsumer.Listen([]string{"github.REPOS", "github.REPO","github.COMMITS"})
+		err = eventConsumer.Listen([]string{"github.REPOS", "github.REPO","github.COMMITS", "github.PULLS", "github.ISSUES", "github.RELEASES", "github.ACTIONS"})
 		if err != nil {
 			log.Println(err)
 		}
@@ -100,9 +158,31 @@ func main() {
 				CommitPersistence: commitPersistence,
 			})
 
+		pulls.RegisterPullsServiceServer(s,
+			&pullsMetaData.PullsMetaDataServer{
+				PullRequestPersistence: pullRequestPersistence,
+			})
+
+		issues.RegisterIssuesServiceServer(s,
+			&issuesMetaData.IssuesMetaDataServer{
+				IssuePersistence: issuePersistence,
+			})
+
+		actions.RegisterActionsServiceServer(s,
+			&actionsMetaData.ActionsMetaDataServer{
+				WorkflowRunPersistence: workflowRunPersistence,
+			})
+
 		repos.RegisterRepositoriesServiceServer(s,
 			&reposMetaData.ReposMetaDataServer{
-				RepositoryPersistence: repositoryPersistence,
+				RepositoryPersistence:        repositoryPersistence,
+				PollSchedulePersistence:      pollSchedulePersistence,
+				TrackedRepositoryPersistence: trackedRepositoryPersistence,
+			})
+
+		backfills.RegisterBackfillsServiceServer(s,
+			&backfillsMetaData.BackfillsMetaDataServer{
+				BackfillJobPersistence: backfillJobPersistence,
 			})
 
 		log.Printf("gRPC Server started on port %s", gRpcPort)
@@ -0,0 +1,118 @@
+// Command ghimport loads the repositories and commits of the tracked owners
+// from GH Archive hourly dumps on disk into the commits manager database:
+//
+//	ghimport -dir /data/gharchive -owners chromium,golang
+//	ghimport 2015-01-01-15.json.gz 2015-01-01-16.json.gz
+//
+// The database is given by DSN and the owners default to GITHUB_OWNERS or
+// GITHUB_USERNAME, like for the other services. Files imported before are
+// skipped, so an interrupted import is resumed by running it again.
+package main
+
+import (
+	"commits-manager-service/internal/module/archives"
+	"commits-manager-service/internal/storage/db"
+	"database/sql"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	_ "github.com/jackc/pgconn"
+	_ "github.com/jackc/pgx/v4"
+	_ "github.com/jackc/pgx/v4/stdlib"
+)
+
+func main() {
+	dir := flag.String("dir", "", "directory of the GH Archive .json.gz files to import")
+	owners := flag.String("owners", defaultOwners(), "comma separated owners whose repositories are imported")
+	flag.Parse()
+
+	paths, err := archivePaths(*dir, flag.Args())
+	if err != nil {
+		log.Fatal("Importer: ", err)
+	}
+	if len(paths) == 0 {
+		log.Fatal("Importer: no archive files given, use -dir or list them")
+	}
+	ownerLogins := splitOwners(*owners)
+	if len(ownerLogins) == 0 {
+		log.Fatal("Importer: no owners given, use -owners or set GITHUB_OWNERS")
+	}
+
+	dbConn, err := openDB(os.Getenv("DSN"))
+	if err != nil {
+		log.Fatal("Importer: cannot connect to Postgres: ", err)
+	}
+	defer dbConn.Close()
+
+	importService := archives.NewArchiveImportService(
+		db.NewArchiveImportPersistence(dbConn),
+		db.NewRepositoryPersistence(dbConn),
+		db.NewCommitPersistence(dbConn),
+		ownerLogins)
+
+	log.Printf("Importer: importing %d files for %s\n", len(paths), strings.Join(ownerLogins, ", "))
+	started := time.Now()
+	summary, err := importService.Import(paths)
+	log.Printf("Importer: imported %d files (%d skipped), %d events, %d new repositories, %d new commits in %s\n",
+		summary.Files, summary.Skipped, summary.Events, summary.Repositories, summary.Commits,
+		time.Since(started).Round(time.Second))
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// archivePaths returns the .json.gz files of dir followed by the listed ones.
+func archivePaths(dir string, listed []string) ([]string, error) {
+	var paths []string
+	if dir != "" {
+		matches, err := filepath.Glob(filepath.Join(dir, "*.json.gz"))
+		if err != nil {
+			return nil, err
+		}
+		paths = append(paths, matches...)
+	}
+	return append(paths, listed...), nil
+}
+
+// defaultOwners returns the owners tracked by the discovery service.
+func defaultOwners() string {
+	if owners := os.Getenv("GITHUB_OWNERS"); owners != "" {
+		return owners
+	}
+	return os.Getenv("GITHUB_USERNAME")
+}
+
+// splitOwners reads a comma separated list of owners, each given as login or
+// login:type.
+func splitOwners(value string) []string {
+	var owners []string
+	for _, item := range strings.Split(value, ",") {
+		login, _, _ := strings.Cut(strings.TrimSpace(item), ":")
+		if login != "" {
+			owners = append(owners, login)
+		}
+	}
+	return owners
+}
+
+func openDB(dsn string) (*sql.DB, error) {
+	if dsn == "" {
+		return nil, fmt.Errorf("DSN is not set")
+	}
+	db, err := sql.Open("pgx", dsn)
+	if err != nil {
+		return nil, err
+	}
+
+	err = db.Ping()
+	if err != nil {
+		return nil, err
+	}
+
+	return db, nil
+}
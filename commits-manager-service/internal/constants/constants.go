@@ -3,3 +3,95 @@ package constants
 const  ISO_8601_TIME_LAYOUT = "2006-01-02T15:04:05Z"
 
 const GITHUB_API_TOPIC = "github_api_topic"
+
+// Providers hosting a repository
+const (
+	PROVIDER_GITHUB = "github"
+	PROVIDER_GITLAB = "gitlab"
+	PROVIDER_LOCAL  = "local"
+)
+
+// Sync statuses of a repository
+const (
+	SYNC_STATUS_ACTIVE    = "active"
+	SYNC_STATUS_EMPTY     = "empty"
+	SYNC_STATUS_NOT_FOUND = "not_found"
+)
+
+// States of a pull request. GitHub reports merged pull requests as closed,
+// they are stored as merged.
+const (
+	PULL_REQUEST_STATE_OPEN   = "open"
+	PULL_REQUEST_STATE_CLOSED = "closed"
+	PULL_REQUEST_STATE_MERGED = "merged"
+)
+
+// States of an issue
+const (
+	ISSUE_STATE_OPEN   = "open"
+	ISSUE_STATE_CLOSED = "closed"
+)
+
+// Statuses and conclusions of a GitHub Actions workflow run
+const (
+	WORKFLOW_RUN_STATUS_COMPLETED     = "completed"
+	WORKFLOW_RUN_CONCLUSION_SUCCESS   = "success"
+	WORKFLOW_RUN_CONCLUSION_FAILURE   = "failure"
+	WORKFLOW_RUN_CONCLUSION_TIMED_OUT = "timed_out"
+	WORKFLOW_RUN_CONCLUSION_STARTUP   = "startup_failure"
+	WORKFLOW_RUN_CONCLUSION_NEUTRAL   = "neutral"
+	WORKFLOW_RUN_CONCLUSION_SKIPPED   = "skipped"
+)
+
+// CI statuses of a commit, summing up its workflow runs
+const (
+	CI_STATUS_SUCCESS = "success"
+	CI_STATUS_FAILURE = "failure"
+	CI_STATUS_PENDING = "pending"
+)
+
+// Outcomes of fetching the commits of a repository
+const (
+	FETCH_OUTCOME_OK           = "ok"
+	FETCH_OUTCOME_NOT_MODIFIED = "not_modified"
+	FETCH_OUTCOME_EMPTY        = "empty"
+	FETCH_OUTCOME_NOT_FOUND    = "not_found"
+	FETCH_OUTCOME_RATE_LIMITED = "rate_limited"
+	FETCH_OUTCOME_UNAUTHORIZED = "unauthorized"
+	FETCH_OUTCOME_SERVER_ERROR = "server_error"
+	FETCH_OUTCOME_ERROR        = "error"
+)
+
+// Types of the GH Archive events loaded by the importer
+const (
+	ARCHIVE_PUSH_EVENT       = "PushEvent"
+	ARCHIVE_CREATE_EVENT     = "CreateEvent"
+	ARCHIVE_REPOSITORY_EVENT = "RepositoryEvent"
+)
+
+// Owner types, as reported by the GitHub API
+const (
+	OWNER_TYPE_USER         = "User"
+	OWNER_TYPE_ORGANIZATION = "Organization"
+)
+
+// POLL_RECENT_COMMITS_DAYS is the number of days the recent commits of a
+// repository are counted over in its poll schedule.
+const POLL_RECENT_COMMITS_DAYS = 7
+
+// Statuses of a backfill job
+const (
+	BACKFILL_STATUS_QUEUED    = "queued"
+	BACKFILL_STATUS_RUNNING   = "running"
+	BACKFILL_STATUS_COMPLETED = "completed"
+	BACKFILL_STATUS_FAILED    = "failed"
+)
+
+// BACKFILL_STALE_MINUTES is how long a running backfill job may go without
+// progress before it is handed out again, as after a restart of the monitor.
+const BACKFILL_STALE_MINUTES = 30
+
+// WORKFLOW_RUN_PENDING_HOURS is how long a workflow run that was not
+// completed yet holds back the workflow runs watermark. Runs stuck queued
+// longer than that are no longer waited for.
+const WORKFLOW_RUN_PENDING_HOURS = 72
@@ -109,6 +109,9 @@ type RepositoryResponse struct {
 		Triage   bool `json:"triage"`
 		Pull     bool `json:"pull"`
 	} `json:"permissions"`
+	// Provider is the service hosting the repository. It is not sent by
+	// GitHub, the discovery service sets it.
+	Provider string `json:"provider,omitempty"`
 }
 
 type CommitResponse struct {
@@ -187,4 +190,111 @@ type CommitResponse struct {
 		URL     string `json:"url"`
 		HTMLURL string `json:"html_url"`
 	} `json:"parents"`
-}
\ No newline at end of file
+	// Stats and Files are only returned for a single commit.
+	Stats *struct {
+		Total     int `json:"total"`
+		Additions int `json:"additions"`
+		Deletions int `json:"deletions"`
+	} `json:"stats,omitempty"`
+	Files []struct {
+		Sha              string `json:"sha"`
+		Filename         string `json:"filename"`
+		Status           string `json:"status"`
+		Additions        int    `json:"additions"`
+		Deletions        int    `json:"deletions"`
+		Changes          int    `json:"changes"`
+		PreviousFilename string `json:"previous_filename,omitempty"`
+	} `json:"files,omitempty"`
+}
+// PullRequestResponse is a pull request as listed by
+// GET /repos/{owner}/{repo}/pulls.
+type PullRequestResponse struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	Draft  bool   `json:"draft"`
+	User   struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	HTMLURL        string     `json:"html_url"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	ClosedAt       *time.Time `json:"closed_at"`
+	MergedAt       *time.Time `json:"merged_at"`
+	MergeCommitSha string     `json:"merge_commit_sha"`
+	Head           struct {
+		Ref string `json:"ref"`
+		Sha string `json:"sha"`
+	} `json:"head"`
+	Base struct {
+		Ref string `json:"ref"`
+		Sha string `json:"sha"`
+	} `json:"base"`
+}
+// IssueResponse is an issue as listed by GET /repos/{owner}/{repo}/issues.
+// The listing includes pull requests, which carry PullRequest.
+type IssueResponse struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	User   struct {
+		Login string `json:"login"`
+	} `json:"user"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Assignees []struct {
+		Login string `json:"login"`
+	} `json:"assignees"`
+	Comments    int        `json:"comments"`
+	HTMLURL     string     `json:"html_url"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ClosedAt    *time.Time `json:"closed_at"`
+	PullRequest *struct {
+		URL string `json:"url"`
+	} `json:"pull_request,omitempty"`
+}
+// ReleaseResponse is a release as listed by
+// GET /repos/{owner}/{repo}/releases.
+type ReleaseResponse struct {
+	ID              int    `json:"id"`
+	TagName         string `json:"tag_name"`
+	Name            string `json:"name"`
+	TargetCommitish string `json:"target_commitish"`
+	Draft           bool   `json:"draft"`
+	Prerelease      bool   `json:"prerelease"`
+	Author          struct {
+		Login string `json:"login"`
+	} `json:"author"`
+	HTMLURL     string     `json:"html_url"`
+	CreatedAt   time.Time  `json:"created_at"`
+	PublishedAt *time.Time `json:"published_at"`
+}
+
+// TagResponse is a tag as listed by GET /repos/{owner}/{repo}/tags.
+type TagResponse struct {
+	Name   string `json:"name"`
+	Commit struct {
+		Sha string `json:"sha"`
+		URL string `json:"url"`
+	} `json:"commit"`
+}
+
+// WorkflowRunResponse is a GitHub Actions workflow run as listed by
+// GET /repos/{owner}/{repo}/actions/runs.
+type WorkflowRunResponse struct {
+	ID           int64     `json:"id"`
+	Name         string    `json:"name"`
+	WorkflowID   int64     `json:"workflow_id"`
+	HeadBranch   string    `json:"head_branch"`
+	HeadSha      string    `json:"head_sha"`
+	Event        string    `json:"event"`
+	Status       string    `json:"status"`
+	Conclusion   string    `json:"conclusion"`
+	RunAttempt   int       `json:"run_attempt"`
+	HTMLURL      string    `json:"html_url"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+	RunStartedAt time.Time `json:"run_started_at"`
+}
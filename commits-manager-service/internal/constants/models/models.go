@@ -7,6 +7,11 @@ import (
 type Repository struct {
 	ID              int64     `json:"id"`
 	Name            string    `json:"name"`
+	Owner           string    `json:"owner"`
+	FullName        string    `json:"full_name"`
+	OwnerType       string    `json:"owner_type"`
+	Provider        string    `json:"provider"`
+	SyncStatus      string    `json:"sync_status"`
 	Description     string    `json:"description"`
 	URL             string    `json:"url"`
 	Language        string    `json:"language"`
@@ -16,6 +21,7 @@ type Repository struct {
 	WatchersCount   int       `json:"watchers_count"`
 	CreatedAt       time.Time `json:"created_at"`
 	UpdatedAt       time.Time `json:"updated_at"`
+	PushedAt        time.Time `json:"pushed_at"`
 }
 
 type Commit struct {
@@ -28,6 +34,180 @@ type Commit struct {
 	RepositoryName string    `json:"repository_name"`
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
+	// Bot is set for the commits authored by bots, which are left out of the
+	// listings of the repositories excluding them.
+	Bot bool `json:"bot"`
+
+	// Stats and Files are set once the commit details were fetched.
+	Stats *CommitStats `json:"stats,omitempty"`
+	Files []CommitFile `json:"files,omitempty"`
+	// Branches are the tracked branches the commit is reachable from.
+	Branches []string `json:"branches,omitempty"`
+	// Parents are the SHAs of the parent commits, first parent first.
+	Parents []string `json:"parents,omitempty"`
+	// CIStatus sums up the workflow runs of the commit, empty when it has
+	// none.
+	CIStatus     string         `json:"ci_status,omitempty"`
+	WorkflowRuns []*WorkflowRun `json:"workflow_runs,omitempty"`
+}
+
+// CommitStats are the line changes of a commit.
+type CommitStats struct {
+	Additions int `json:"additions"`
+	Deletions int `json:"deletions"`
+	Total     int `json:"total"`
+}
+
+// CommitFile is a file changed by a commit.
+type CommitFile struct {
+	Path      string `json:"path"`
+	Status    string `json:"status"`
+	Additions int    `json:"additions"`
+	Deletions int    `json:"deletions"`
+}
+
+// CommitDetails are the stats and changed files of a stored commit.
+type CommitDetails struct {
+	RepositoryName string
+	SHA            string
+	Stats          CommitStats
+	Files          []CommitFile
+}
+
+// PullRequest is a pull request of a repository with the SHAs of its
+// commits.
+type PullRequest struct {
+	ID             int64      `json:"-"`
+	RepositoryName string     `json:"repository_name"`
+	Number         int        `json:"number"`
+	Title          string     `json:"title"`
+	Author         string     `json:"author"`
+	State          string     `json:"state"`
+	HeadRef        string     `json:"head_ref"`
+	BaseRef        string     `json:"base_ref"`
+	URL            string     `json:"url"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	MergedAt       *time.Time `json:"merged_at"`
+	ClosedAt       *time.Time `json:"closed_at"`
+	CommitSHAs     []string   `json:"commit_shas"`
+}
+
+// Issue is an issue of a repository. Pull requests are stored apart.
+type Issue struct {
+	ID             int64      `json:"-"`
+	RepositoryName string     `json:"repository_name"`
+	Number         int        `json:"number"`
+	Title          string     `json:"title"`
+	Author         string     `json:"author"`
+	State          string     `json:"state"`
+	Comments       int        `json:"comments"`
+	URL            string     `json:"url"`
+	Labels         []string   `json:"labels"`
+	Assignees      []string   `json:"assignees"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	ClosedAt       *time.Time `json:"closed_at"`
+}
+
+// IssueTimeToClose sums up how long the issues of a repository closed in a
+// period were open.
+type IssueTimeToClose struct {
+	RepositoryName string  `json:"repository_name"`
+	Closed         int     `json:"closed"`
+	MeanHours      float64 `json:"mean_hours"`
+	MedianHours    float64 `json:"median_hours"`
+	P90Hours       float64 `json:"p90_hours"`
+}
+
+// IssueAgeBucket counts the open issues whose age is at least From and less
+// than To. To is empty for the last bucket.
+type IssueAgeBucket struct {
+	From  string `json:"from"`
+	To    string `json:"to,omitempty"`
+	Count int    `json:"count"`
+}
+
+// IssueAgeDistribution is how long the open issues of a repository have been
+// open.
+type IssueAgeDistribution struct {
+	RepositoryName string           `json:"repository_name"`
+	Open           int              `json:"open"`
+	MedianAgeHours float64          `json:"median_age_hours"`
+	Buckets        []IssueAgeBucket `json:"buckets"`
+}
+
+// Tag is a git tag of a repository and the commit it points to.
+type Tag struct {
+	RepositoryName string `json:"repository_name"`
+	Name           string `json:"name"`
+	SHA            string `json:"sha"`
+}
+
+// Release is a GitHub release of a repository. TargetSHA is the commit its
+// tag points to, empty when it is not known yet.
+type Release struct {
+	ID             int64      `json:"-"`
+	RepositoryName string     `json:"repository_name"`
+	TagName        string     `json:"tag_name"`
+	Name           string     `json:"name"`
+	TargetSHA      string     `json:"target_sha"`
+	Draft          bool       `json:"draft"`
+	Prerelease     bool       `json:"prerelease"`
+	Author         string     `json:"author"`
+	URL            string     `json:"url"`
+	CreatedAt      time.Time  `json:"created_at"`
+	PublishedAt    *time.Time `json:"published_at"`
+}
+
+// ReleasedAt is when the release was published, or created while it is a
+// draft.
+func (r Release) ReleasedAt() time.Time {
+	if r.PublishedAt != nil {
+		return *r.PublishedAt
+	}
+	return r.CreatedAt
+}
+
+// ReleaseCommits are the commits that went into a release since the previous
+// one, newest first. Truncated is set when only the newest of them are
+// listed.
+type ReleaseCommits struct {
+	Release         *Release  `json:"release"`
+	PreviousRelease *Release  `json:"previous_release"`
+	Commits         []*Commit `json:"commits"`
+	Truncated       bool      `json:"truncated"`
+}
+
+// WorkflowRun is a GitHub Actions workflow run of a repository.
+// DurationSeconds is set once the run completed.
+type WorkflowRun struct {
+	ID              int64     `json:"id"`
+	RepositoryName  string    `json:"repository_name"`
+	WorkflowID      int64     `json:"workflow_id"`
+	WorkflowName    string    `json:"workflow_name"`
+	HeadSHA         string    `json:"head_sha"`
+	HeadBranch      string    `json:"head_branch"`
+	Event           string    `json:"event"`
+	Status          string    `json:"status"`
+	Conclusion      string    `json:"conclusion"`
+	RunAttempt      int       `json:"run_attempt"`
+	URL             string    `json:"url"`
+	DurationSeconds int       `json:"duration_seconds"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	RunStartedAt    time.Time `json:"run_started_at"`
+}
+
+// WorkflowStats sums up the completed runs of a workflow. Runs counts those
+// that succeeded or failed; cancelled, skipped and neutral runs are left out.
+type WorkflowStats struct {
+	WorkflowID            int64   `json:"workflow_id"`
+	WorkflowName          string  `json:"workflow_name"`
+	Runs                  int     `json:"runs"`
+	Successes             int     `json:"successes"`
+	SuccessRate           float64 `json:"success_rate"`
+	MedianDurationSeconds float64 `json:"median_duration_seconds"`
 }
 
 type CommitAuthor struct {
@@ -37,6 +217,7 @@ type CommitAuthor struct {
 
 type ReposFetchHistory struct {
 	ID        int64
+	Owner     string
 	Total     int
 	LastPage  int
 	FetchedAt time.Time
@@ -45,11 +226,92 @@ type ReposFetchHistory struct {
 type CommitsFetchHistory struct {
 	ID             int64
 	RepositoryName string
-	LastPage       int
 	Total          int
 	FetchedAt      time.Time
 }
 
+// ArchiveImport records a GH Archive file imported offline, so that an
+// interrupted import resumes with the next file.
+type ArchiveImport struct {
+	FileName     string
+	Events       int
+	Repositories int
+	Commits      int
+	ImportedAt   time.Time
+}
+
+// CommitsFetchOutcome records how fetching the commits of a repository went.
+type CommitsFetchOutcome struct {
+	ID             int64     `json:"-"`
+	RepositoryName string    `json:"repository_name"`
+	Outcome        string    `json:"outcome"`
+	StatusCode     int       `json:"status_code"`
+	Message        string    `json:"message"`
+	Total          int       `json:"total"`
+	FetchedAt      time.Time `json:"fetched_at"`
+}
+
+// PollSchedule is when the commits monitor polls a repository next, along
+// with what it sets the interval from: the commits authored recently, the
+// last push and the polls in a row that found no new commit. A pinned
+// interval, which is the poll interval of the tracking settings of the
+// repository, overrides the computed one.
+type PollSchedule struct {
+	RepositoryName        string     `json:"repository_name"`
+	PushedAt              time.Time  `json:"pushed_at"`
+	RecentCommits         int        `json:"recent_commits"`
+	IntervalSeconds       int64      `json:"interval_seconds"`
+	PinnedIntervalSeconds int64      `json:"pinned_interval_seconds"`
+	EmptyFetches          int        `json:"empty_fetches"`
+	NextPollAt            *time.Time `json:"next_poll_at"`
+}
+
+// TrackedRepository holds the tracking settings of a repository, which
+// override the global configuration of the discovery and monitor services.
+// Paused repositories are neither refreshed nor polled. An unset sync start
+// date, nil branches and a zero poll interval keep the defaults, while an
+// empty branch list syncs the default branch only. The poll interval is the
+// one pinned in the poll schedule of the repository.
+type TrackedRepository struct {
+	RepositoryName      string     `json:"repository_name"`
+	Enabled             bool       `json:"enabled"`
+	SyncStartDate       *time.Time `json:"sync_start_date"`
+	Branches            []string   `json:"branches"`
+	PollIntervalSeconds int64      `json:"poll_interval_seconds"`
+	ExcludeBots         bool       `json:"exclude_bots"`
+	CreatedAt           time.Time  `json:"created_at"`
+	UpdatedAt           time.Time  `json:"updated_at"`
+}
+
+// BackfillJob fetches the commits of a repository, or of all repositories
+// when RepositoryName is empty, authored between StartDate and EndDate. The
+// commits monitor runs queued jobs by priority, highest first, and reports
+// their progress.
+type BackfillJob struct {
+	ID                int64      `json:"id"`
+	RepositoryName    string     `json:"repository_name"`
+	StartDate         time.Time  `json:"start_date"`
+	EndDate           time.Time  `json:"end_date"`
+	Priority          int        `json:"priority"`
+	Status            string     `json:"status"`
+	RepositoriesTotal int        `json:"repositories_total"`
+	RepositoriesDone  int        `json:"repositories_done"`
+	Commits           int        `json:"commits"`
+	Error             string     `json:"error"`
+	CreatedAt         time.Time  `json:"created_at"`
+	StartedAt         *time.Time `json:"started_at"`
+	FinishedAt        *time.Time `json:"finished_at"`
+	UpdatedAt         time.Time  `json:"updated_at"`
+}
+
+// CommitWatermark is the newest stored commit of a repository. The monitor
+// resumes fetching from it.
+type CommitWatermark struct {
+	RepositoryName string
+	SHA            string
+	AuthorDate     time.Time
+}
+
 type Config struct {
 	DSN            string `json:"dsn"`
 	GithubToken    string `json:"github_token"`
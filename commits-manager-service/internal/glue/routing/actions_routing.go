@@ -0,0 +1,25 @@
+package routing
+
+import (
+	"net/http"
+
+	h "commits-manager-service/internal/http/rest/handlers"
+	"commits-manager-service/platforms/routers"
+)
+
+func ActionsRouting(handler *h.ActionsHandler) []routers.Route {
+	return []routers.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        "/workflow-stats/{repositoryName}",
+			Handle:      handler.GetWorkflowStats,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/workflow-stats/{owner}/{repositoryName}",
+			Handle:      handler.GetWorkflowStats,
+			MiddleWares: []http.HandlerFunc{},
+		},
+	}
+}
@@ -0,0 +1,31 @@
+package routing
+
+import (
+	"net/http"
+
+	h "commits-manager-service/internal/http/rest/handlers"
+	"commits-manager-service/platforms/routers"
+)
+
+func BackfillsRouting(handler *h.BackfillsHandler) []routers.Route {
+	return []routers.Route{
+		{
+			Method:      http.MethodPost,
+			Path:        "/backfill-jobs",
+			Handle:      handler.CreateBackfillJob,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/backfill-jobs",
+			Handle:      handler.GetBackfillJobs,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/backfill-jobs/{id}",
+			Handle:      handler.GetBackfillJob,
+			MiddleWares: []http.HandlerFunc{},
+		},
+	}
+}
@@ -15,6 +15,30 @@ func CommitsRouting(handler *h.CommitsHandler) []routers.Route {
 			Handle:      handler.GetAllCommits,
 			MiddleWares: []http.HandlerFunc{},
 		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/commits/{owner}/{repositoryName}",
+			Handle:      handler.GetAllCommits,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/commits/{owner}/{repositoryName}/{sha}",
+			Handle:      handler.GetCommitDetails,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/commits-fetch-outcomes/{repositoryName}",
+			Handle:      handler.GetCommitsFetchOutcomes,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/commits-fetch-outcomes/{owner}/{repositoryName}",
+			Handle:      handler.GetCommitsFetchOutcomes,
+			MiddleWares: []http.HandlerFunc{},
+		},
 		{
 			Method:      http.MethodGet,
 			Path:        "/top-commit-authors",
@@ -27,5 +51,11 @@ func CommitsRouting(handler *h.CommitsHandler) []routers.Route {
 			Handle:      handler.GetTopCommitAuthorsByRepo,
 			MiddleWares: []http.HandlerFunc{},
 		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/top-commit-authors/{owner}/{repositoryName}",
+			Handle:      handler.GetTopCommitAuthorsByRepo,
+			MiddleWares: []http.HandlerFunc{},
+		},
 	}
 }
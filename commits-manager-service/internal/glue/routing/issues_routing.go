@@ -0,0 +1,49 @@
+package routing
+
+import (
+	"net/http"
+
+	h "commits-manager-service/internal/http/rest/handlers"
+	"commits-manager-service/platforms/routers"
+)
+
+func IssuesRouting(handler *h.IssuesHandler) []routers.Route {
+	return []routers.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        "/issues/{repositoryName}",
+			Handle:      handler.GetIssues,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/issues/{owner}/{repositoryName}",
+			Handle:      handler.GetIssues,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/issues-time-to-close/{repositoryName}",
+			Handle:      handler.GetIssuesTimeToClose,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/issues-time-to-close/{owner}/{repositoryName}",
+			Handle:      handler.GetIssuesTimeToClose,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/issues-open-age/{repositoryName}",
+			Handle:      handler.GetOpenIssueAges,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/issues-open-age/{owner}/{repositoryName}",
+			Handle:      handler.GetOpenIssueAges,
+			MiddleWares: []http.HandlerFunc{},
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package routing
+
+import (
+	"net/http"
+
+	h "commits-manager-service/internal/http/rest/handlers"
+	"commits-manager-service/platforms/routers"
+)
+
+func PullsRouting(handler *h.PullsHandler) []routers.Route {
+	return []routers.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        "/pulls/{repositoryName}",
+			Handle:      handler.GetPullRequests,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/pulls/{owner}/{repositoryName}",
+			Handle:      handler.GetPullRequests,
+			MiddleWares: []http.HandlerFunc{},
+		},
+	}
+}
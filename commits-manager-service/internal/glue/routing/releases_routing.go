@@ -0,0 +1,37 @@
+package routing
+
+import (
+	"net/http"
+
+	h "commits-manager-service/internal/http/rest/handlers"
+	"commits-manager-service/platforms/routers"
+)
+
+func ReleasesRouting(handler *h.ReleasesHandler) []routers.Route {
+	return []routers.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        "/repositories/{repositoryName}/releases",
+			Handle:      handler.GetReleases,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/repositories/{owner}/{repositoryName}/releases",
+			Handle:      handler.GetReleases,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/repositories/{repositoryName}/releases/{tag}/commits",
+			Handle:      handler.GetReleaseCommits,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/repositories/{owner}/{repositoryName}/releases/{tag}/commits",
+			Handle:      handler.GetReleaseCommits,
+			MiddleWares: []http.HandlerFunc{},
+		},
+	}
+}
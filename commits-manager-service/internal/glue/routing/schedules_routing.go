@@ -0,0 +1,43 @@
+package routing
+
+import (
+	"net/http"
+
+	h "commits-manager-service/internal/http/rest/handlers"
+	"commits-manager-service/platforms/routers"
+)
+
+func SchedulesRouting(handler *h.SchedulesHandler) []routers.Route {
+	return []routers.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        "/poll-schedules",
+			Handle:      handler.GetPollSchedules,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/poll-schedules/{repositoryName}",
+			Handle:      handler.GetPollSchedule,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/poll-schedules/{owner}/{repositoryName}",
+			Handle:      handler.GetPollSchedule,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/poll-schedules/{repositoryName}",
+			Handle:      handler.PinPollInterval,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/poll-schedules/{owner}/{repositoryName}",
+			Handle:      handler.PinPollInterval,
+			MiddleWares: []http.HandlerFunc{},
+		},
+	}
+}
@@ -0,0 +1,55 @@
+package routing
+
+import (
+	"net/http"
+
+	h "commits-manager-service/internal/http/rest/handlers"
+	"commits-manager-service/platforms/routers"
+)
+
+func TrackingRouting(handler *h.TrackingHandler) []routers.Route {
+	return []routers.Route{
+		{
+			Method:      http.MethodGet,
+			Path:        "/tracked-repositories",
+			Handle:      handler.GetTrackedRepositories,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/tracked-repositories/{repositoryName}",
+			Handle:      handler.GetTrackedRepository,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodGet,
+			Path:        "/tracked-repositories/{owner}/{repositoryName}",
+			Handle:      handler.GetTrackedRepository,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/tracked-repositories/{repositoryName}",
+			Handle:      handler.SaveTrackedRepository,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodPut,
+			Path:        "/tracked-repositories/{owner}/{repositoryName}",
+			Handle:      handler.SaveTrackedRepository,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/tracked-repositories/{repositoryName}",
+			Handle:      handler.DeleteTrackedRepository,
+			MiddleWares: []http.HandlerFunc{},
+		},
+		{
+			Method:      http.MethodDelete,
+			Path:        "/tracked-repositories/{owner}/{repositoryName}",
+			Handle:      handler.DeleteTrackedRepository,
+			MiddleWares: []http.HandlerFunc{},
+		},
+	}
+}
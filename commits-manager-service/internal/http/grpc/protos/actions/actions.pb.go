@@ -0,0 +1,222 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.6.1
+// source: actions.proto
+
+package actions
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type WorkflowRunsWatermarkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// full name (owner/name) of the repository
+	RepositoryName string `protobuf:"bytes,1,opt,name=repositoryName,proto3" json:"repositoryName,omitempty"`
+}
+
+func (x *WorkflowRunsWatermarkRequest) Reset() {
+	*x = WorkflowRunsWatermarkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_actions_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkflowRunsWatermarkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowRunsWatermarkRequest) ProtoMessage() {}
+
+func (x *WorkflowRunsWatermarkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_actions_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowRunsWatermarkRequest.ProtoReflect.Descriptor instead.
+func (*WorkflowRunsWatermarkRequest) Descriptor() ([]byte, []int) {
+	return file_actions_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *WorkflowRunsWatermarkRequest) GetRepositoryName() string {
+	if x != nil {
+		return x.RepositoryName
+	}
+	return ""
+}
+
+type WorkflowRunsWatermarkResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// creation time from which workflow runs are fetched again: that of the
+	// oldest stored run not completed yet, else that of the newest stored
+	// run, empty when none is stored
+	CreatedSince string `protobuf:"bytes,1,opt,name=createdSince,proto3" json:"createdSince,omitempty"`
+}
+
+func (x *WorkflowRunsWatermarkResponse) Reset() {
+	*x = WorkflowRunsWatermarkResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_actions_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WorkflowRunsWatermarkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WorkflowRunsWatermarkResponse) ProtoMessage() {}
+
+func (x *WorkflowRunsWatermarkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_actions_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WorkflowRunsWatermarkResponse.ProtoReflect.Descriptor instead.
+func (*WorkflowRunsWatermarkResponse) Descriptor() ([]byte, []int) {
+	return file_actions_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WorkflowRunsWatermarkResponse) GetCreatedSince() string {
+	if x != nil {
+		return x.CreatedSince
+	}
+	return ""
+}
+
+var File_actions_proto protoreflect.FileDescriptor
+
+var file_actions_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x07, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x46, 0x0a, 0x1c, 0x57, 0x6f, 0x72, 0x6b,
+	0x66, 0x6c, 0x6f, 0x77, 0x52, 0x75, 0x6e, 0x73, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72,
+	0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65,
+	0x22, 0x43, 0x0a, 0x1d, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x52, 0x75, 0x6e, 0x73,
+	0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x22, 0x0a, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64, 0x53, 0x69, 0x6e, 0x63,
+	0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0c, 0x63, 0x72, 0x65, 0x61, 0x74, 0x65, 0x64,
+	0x53, 0x69, 0x6e, 0x63, 0x65, 0x32, 0x7b, 0x0a, 0x0e, 0x41, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x69, 0x0a, 0x18, 0x47, 0x65, 0x74, 0x57, 0x6f,
+	0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x52, 0x75, 0x6e, 0x73, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d,
+	0x61, 0x72, 0x6b, 0x12, 0x25, 0x2e, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x57, 0x6f,
+	0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x52, 0x75, 0x6e, 0x73, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d,
+	0x61, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x26, 0x2e, 0x61, 0x63, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x2e, 0x57, 0x6f, 0x72, 0x6b, 0x66, 0x6c, 0x6f, 0x77, 0x52, 0x75, 0x6e,
+	0x73, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x42, 0x0a, 0x5a, 0x08, 0x2f, 0x61, 0x63, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x62, 0x06,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_actions_proto_rawDescOnce sync.Once
+	file_actions_proto_rawDescData = file_actions_proto_rawDesc
+)
+
+func file_actions_proto_rawDescGZIP() []byte {
+	file_actions_proto_rawDescOnce.Do(func() {
+		file_actions_proto_rawDescData = protoimpl.X.CompressGZIP(file_actions_proto_rawDescData)
+	})
+	return file_actions_proto_rawDescData
+}
+
+var file_actions_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_actions_proto_goTypes = []interface{}{
+	(*WorkflowRunsWatermarkRequest)(nil),  // 0: actions.WorkflowRunsWatermarkRequest
+	(*WorkflowRunsWatermarkResponse)(nil), // 1: actions.WorkflowRunsWatermarkResponse
+}
+var file_actions_proto_depIdxs = []int32{
+	0, // 0: actions.ActionsService.GetWorkflowRunsWatermark:input_type -> actions.WorkflowRunsWatermarkRequest
+	1, // 1: actions.ActionsService.GetWorkflowRunsWatermark:output_type -> actions.WorkflowRunsWatermarkResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_actions_proto_init() }
+func file_actions_proto_init() {
+	if File_actions_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_actions_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkflowRunsWatermarkRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_actions_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*WorkflowRunsWatermarkResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_actions_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_actions_proto_goTypes,
+		DependencyIndexes: file_actions_proto_depIdxs,
+		MessageInfos:      file_actions_proto_msgTypes,
+	}.Build()
+	File_actions_proto = out.File
+	file_actions_proto_rawDesc = nil
+	file_actions_proto_goTypes = nil
+	file_actions_proto_depIdxs = nil
+}
@@ -0,0 +1,141 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.6.1
+// source: pulls.proto
+
+package pulls
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// PullsServiceClient is the client API for PullsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type PullsServiceClient interface {
+	GetPullRequestsWatermark(ctx context.Context, in *PullRequestsWatermarkRequest, opts ...grpc.CallOption) (*PullRequestsWatermarkResponse, error)
+	GetPullRequests(ctx context.Context, in *PullRequestsRequest, opts ...grpc.CallOption) (*PullRequestsResponse, error)
+}
+
+type pullsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewPullsServiceClient(cc grpc.ClientConnInterface) PullsServiceClient {
+	return &pullsServiceClient{cc}
+}
+
+func (c *pullsServiceClient) GetPullRequestsWatermark(ctx context.Context, in *PullRequestsWatermarkRequest, opts ...grpc.CallOption) (*PullRequestsWatermarkResponse, error) {
+	out := new(PullRequestsWatermarkResponse)
+	err := c.cc.Invoke(ctx, "/pulls.PullsService/GetPullRequestsWatermark", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *pullsServiceClient) GetPullRequests(ctx context.Context, in *PullRequestsRequest, opts ...grpc.CallOption) (*PullRequestsResponse, error) {
+	out := new(PullRequestsResponse)
+	err := c.cc.Invoke(ctx, "/pulls.PullsService/GetPullRequests", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// PullsServiceServer is the server API for PullsService service.
+// All implementations must embed UnimplementedPullsServiceServer
+// for forward compatibility
+type PullsServiceServer interface {
+	GetPullRequestsWatermark(context.Context, *PullRequestsWatermarkRequest) (*PullRequestsWatermarkResponse, error)
+	GetPullRequests(context.Context, *PullRequestsRequest) (*PullRequestsResponse, error)
+	mustEmbedUnimplementedPullsServiceServer()
+}
+
+// UnimplementedPullsServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedPullsServiceServer struct {
+}
+
+func (UnimplementedPullsServiceServer) GetPullRequestsWatermark(context.Context, *PullRequestsWatermarkRequest) (*PullRequestsWatermarkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPullRequestsWatermark not implemented")
+}
+func (UnimplementedPullsServiceServer) GetPullRequests(context.Context, *PullRequestsRequest) (*PullRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPullRequests not implemented")
+}
+func (UnimplementedPullsServiceServer) mustEmbedUnimplementedPullsServiceServer() {}
+
+// UnsafePullsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to PullsServiceServer will
+// result in compilation errors.
+type UnsafePullsServiceServer interface {
+	mustEmbedUnimplementedPullsServiceServer()
+}
+
+func RegisterPullsServiceServer(s grpc.ServiceRegistrar, srv PullsServiceServer) {
+	s.RegisterService(&PullsService_ServiceDesc, srv)
+}
+
+func _PullsService_GetPullRequestsWatermark_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PullRequestsWatermarkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PullsServiceServer).GetPullRequestsWatermark(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulls.PullsService/GetPullRequestsWatermark",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PullsServiceServer).GetPullRequestsWatermark(ctx, req.(*PullRequestsWatermarkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PullsService_GetPullRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PullRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PullsServiceServer).GetPullRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/pulls.PullsService/GetPullRequests",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PullsServiceServer).GetPullRequests(ctx, req.(*PullRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// PullsService_ServiceDesc is the grpc.ServiceDesc for PullsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var PullsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "pulls.PullsService",
+	HandlerType: (*PullsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetPullRequestsWatermark",
+			Handler:    _PullsService_GetPullRequestsWatermark_Handler,
+		},
+		{
+			MethodName: "GetPullRequests",
+			Handler:    _PullsService_GetPullRequests_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "pulls.proto",
+}
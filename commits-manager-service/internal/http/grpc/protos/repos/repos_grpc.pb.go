@@ -25,6 +25,9 @@ type RepositoriesServiceClient interface {
 	GetRepositories(ctx context.Context, in *GetRepositoriesRequest, opts ...grpc.CallOption) (*GetRepositoriesResponse, error)
 	GetReposFetchHistory(ctx context.Context, in *GetReposFetchHistoryRequest, opts ...grpc.CallOption) (*GetReposFetchHistoryResponse, error)
 	GetRepositoryNames(ctx context.Context, in *GetRepositoryNamesRequest, opts ...grpc.CallOption) (*GetRepositoryNamesResponse, error)
+	GetPollSchedules(ctx context.Context, in *GetPollSchedulesRequest, opts ...grpc.CallOption) (*GetPollSchedulesResponse, error)
+	SavePollSchedule(ctx context.Context, in *SavePollScheduleRequest, opts ...grpc.CallOption) (*SavePollScheduleResponse, error)
+	GetTrackedRepositories(ctx context.Context, in *GetTrackedRepositoriesRequest, opts ...grpc.CallOption) (*GetTrackedRepositoriesResponse, error)
 }
 
 type repositoriesServiceClient struct {
@@ -62,6 +65,33 @@ func (c *repositoriesServiceClient) GetRepositoryNames(ctx context.Context, in *
 	return out, nil
 }
 
+func (c *repositoriesServiceClient) GetPollSchedules(ctx context.Context, in *GetPollSchedulesRequest, opts ...grpc.CallOption) (*GetPollSchedulesResponse, error) {
+	out := new(GetPollSchedulesResponse)
+	err := c.cc.Invoke(ctx, "/repos.RepositoriesService/GetPollSchedules", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repositoriesServiceClient) SavePollSchedule(ctx context.Context, in *SavePollScheduleRequest, opts ...grpc.CallOption) (*SavePollScheduleResponse, error) {
+	out := new(SavePollScheduleResponse)
+	err := c.cc.Invoke(ctx, "/repos.RepositoriesService/SavePollSchedule", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *repositoriesServiceClient) GetTrackedRepositories(ctx context.Context, in *GetTrackedRepositoriesRequest, opts ...grpc.CallOption) (*GetTrackedRepositoriesResponse, error) {
+	out := new(GetTrackedRepositoriesResponse)
+	err := c.cc.Invoke(ctx, "/repos.RepositoriesService/GetTrackedRepositories", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // RepositoriesServiceServer is the server API for RepositoriesService service.
 // All implementations must embed UnimplementedRepositoriesServiceServer
 // for forward compatibility
@@ -69,6 +99,9 @@ type RepositoriesServiceServer interface {
 	GetRepositories(context.Context, *GetRepositoriesRequest) (*GetRepositoriesResponse, error)
 	GetReposFetchHistory(context.Context, *GetReposFetchHistoryRequest) (*GetReposFetchHistoryResponse, error)
 	GetRepositoryNames(context.Context, *GetRepositoryNamesRequest) (*GetRepositoryNamesResponse, error)
+	GetPollSchedules(context.Context, *GetPollSchedulesRequest) (*GetPollSchedulesResponse, error)
+	SavePollSchedule(context.Context, *SavePollScheduleRequest) (*SavePollScheduleResponse, error)
+	GetTrackedRepositories(context.Context, *GetTrackedRepositoriesRequest) (*GetTrackedRepositoriesResponse, error)
 	mustEmbedUnimplementedRepositoriesServiceServer()
 }
 
@@ -85,6 +118,15 @@ func (UnimplementedRepositoriesServiceServer) GetReposFetchHistory(context.Conte
 func (UnimplementedRepositoriesServiceServer) GetRepositoryNames(context.Context, *GetRepositoryNamesRequest) (*GetRepositoryNamesResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetRepositoryNames not implemented")
 }
+func (UnimplementedRepositoriesServiceServer) GetPollSchedules(context.Context, *GetPollSchedulesRequest) (*GetPollSchedulesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPollSchedules not implemented")
+}
+func (UnimplementedRepositoriesServiceServer) SavePollSchedule(context.Context, *SavePollScheduleRequest) (*SavePollScheduleResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SavePollSchedule not implemented")
+}
+func (UnimplementedRepositoriesServiceServer) GetTrackedRepositories(context.Context, *GetTrackedRepositoriesRequest) (*GetTrackedRepositoriesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTrackedRepositories not implemented")
+}
 func (UnimplementedRepositoriesServiceServer) mustEmbedUnimplementedRepositoriesServiceServer() {}
 
 // UnsafeRepositoriesServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -152,6 +194,60 @@ func _RepositoriesService_GetRepositoryNames_Handler(srv interface{}, ctx contex
 	return interceptor(ctx, in, info, handler)
 }
 
+func _RepositoriesService_GetPollSchedules_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPollSchedulesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepositoriesServiceServer).GetPollSchedules(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/repos.RepositoriesService/GetPollSchedules",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepositoriesServiceServer).GetPollSchedules(ctx, req.(*GetPollSchedulesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepositoriesService_SavePollSchedule_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SavePollScheduleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepositoriesServiceServer).SavePollSchedule(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/repos.RepositoriesService/SavePollSchedule",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepositoriesServiceServer).SavePollSchedule(ctx, req.(*SavePollScheduleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RepositoriesService_GetTrackedRepositories_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTrackedRepositoriesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RepositoriesServiceServer).GetTrackedRepositories(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/repos.RepositoriesService/GetTrackedRepositories",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RepositoriesServiceServer).GetTrackedRepositories(ctx, req.(*GetTrackedRepositoriesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // RepositoriesService_ServiceDesc is the grpc.ServiceDesc for RepositoriesService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -171,6 +267,18 @@ var RepositoriesService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetRepositoryNames",
 			Handler:    _RepositoriesService_GetRepositoryNames_Handler,
 		},
+		{
+			MethodName: "GetPollSchedules",
+			Handler:    _RepositoriesService_GetPollSchedules_Handler,
+		},
+		{
+			MethodName: "SavePollSchedule",
+			Handler:    _RepositoriesService_SavePollSchedule_Handler,
+		},
+		{
+			MethodName: "GetTrackedRepositories",
+			Handler:    _RepositoriesService_GetTrackedRepositories_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "repos.proto",
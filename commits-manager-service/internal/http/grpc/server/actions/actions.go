@@ -0,0 +1,26 @@
+package actions
+
+import (
+	"commits-manager-service/internal/constants"
+	"commits-manager-service/internal/http/grpc/protos/actions"
+	"commits-manager-service/internal/storage/db"
+	"context"
+)
+
+type ActionsMetaDataServer struct {
+	actions.UnimplementedActionsServiceServer
+	WorkflowRunPersistence db.WorkflowRunRepository
+}
+
+func (amds *ActionsMetaDataServer) GetWorkflowRunsWatermark(ctx context.Context, req *actions.WorkflowRunsWatermarkRequest) (*actions.WorkflowRunsWatermarkResponse, error) {
+	createdAt, err := amds.WorkflowRunPersistence.GetWorkflowRunsWatermark(req.GetRepositoryName())
+	if err != nil {
+		return nil, err
+	}
+	if createdAt.IsZero() {
+		return &actions.WorkflowRunsWatermarkResponse{}, nil
+	}
+	return &actions.WorkflowRunsWatermarkResponse{
+		CreatedSince: createdAt.UTC().Format(constants.ISO_8601_TIME_LAYOUT),
+	}, nil
+}
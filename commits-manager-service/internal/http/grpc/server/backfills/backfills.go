@@ -0,0 +1,37 @@
+package backfills
+
+import (
+	"commits-manager-service/internal/constants"
+	"commits-manager-service/internal/http/grpc/protos/backfills"
+	"commits-manager-service/internal/storage/db"
+	"context"
+	"time"
+)
+
+type BackfillsMetaDataServer struct {
+	backfills.UnimplementedBackfillsServiceServer
+	BackfillJobPersistence db.BackfillJobRepository
+}
+
+// ClaimBackfillJob hands the next backfill job to run to the commits
+// monitor. Jobs left running without progress for BACKFILL_STALE_MINUTES
+// are handed out again.
+func (bmds *BackfillsMetaDataServer) ClaimBackfillJob(ctx context.Context, req *backfills.ClaimBackfillJobRequest) (*backfills.ClaimBackfillJobResponse, error) {
+	staleBefore := time.Now().UTC().Add(-constants.BACKFILL_STALE_MINUTES * time.Minute)
+	job, err := bmds.BackfillJobPersistence.ClaimBackfillJob(staleBefore)
+	if err != nil {
+		return nil, err
+	}
+	if job == nil {
+		return &backfills.ClaimBackfillJobResponse{}, nil
+	}
+	return &backfills.ClaimBackfillJobResponse{
+		Job: &backfills.BackfillJob{
+			Id:             job.ID,
+			RepositoryName: job.RepositoryName,
+			StartDate:      job.StartDate.UTC().Format(constants.ISO_8601_TIME_LAYOUT),
+			EndDate:        job.EndDate.UTC().Format(constants.ISO_8601_TIME_LAYOUT),
+			Priority:       int32(job.Priority),
+		},
+	}, nil
+}
@@ -7,18 +7,36 @@ import (
 	"context"
 )
 
+// maxCommitsWithoutStats caps the commits listed for enrichment at once.
+const maxCommitsWithoutStats = 500
+
 type CommitsMetaDataServer struct {
 	commits.UnimplementedCommitsServiceServer
 	CommitPersistence db.CommitRepository
 }
 
-func (cmds *CommitsMetaDataServer) GetCommitFetchHistory(ctx context.Context, req *commits.CommitFetchHistoryRequest) (*commits.CommitFetchHistoryResponse, error) {
-	commitsFetchHistory, err := cmds.CommitPersistence.GetLastCommitFetchTime(req.RepositoryName)
+func (cmds *CommitsMetaDataServer) GetCommitWatermark(ctx context.Context, req *commits.CommitWatermarkRequest) (*commits.CommitWatermarkResponse, error) {
+	watermark, err := cmds.CommitPersistence.GetCommitWatermark(req.RepositoryName, req.Branch)
 	if err != nil {
 		return nil, err
 	}
-	return &commits.CommitFetchHistoryResponse{
-		LastFetchTime: commitsFetchHistory.FetchedAt.UTC().Format(constants.ISO_8601_TIME_LAYOUT),
-		LastPage:      int32(commitsFetchHistory.LastPage),
+	if watermark.SHA == "" {
+		return &commits.CommitWatermarkResponse{}, nil
+	}
+	return &commits.CommitWatermarkResponse{
+		LastCommitSha:  watermark.SHA,
+		LastCommitDate: watermark.AuthorDate.UTC().Format(constants.ISO_8601_TIME_LAYOUT),
 	}, nil
 }
+
+func (cmds *CommitsMetaDataServer) GetCommitsWithoutStats(ctx context.Context, req *commits.CommitsWithoutStatsRequest) (*commits.CommitsWithoutStatsResponse, error) {
+	limit := int(req.GetLimit())
+	if limit < 1 || limit > maxCommitsWithoutStats {
+		limit = maxCommitsWithoutStats
+	}
+	shas, err := cmds.CommitPersistence.GetCommitsWithoutStats(req.GetRepositoryName(), limit)
+	if err != nil {
+		return nil, err
+	}
+	return &commits.CommitsWithoutStatsResponse{Shas: shas}, nil
+}
@@ -0,0 +1,26 @@
+package issues
+
+import (
+	"commits-manager-service/internal/constants"
+	"commits-manager-service/internal/http/grpc/protos/issues"
+	"commits-manager-service/internal/storage/db"
+	"context"
+)
+
+type IssuesMetaDataServer struct {
+	issues.UnimplementedIssuesServiceServer
+	IssuePersistence db.IssueRepository
+}
+
+func (imds *IssuesMetaDataServer) GetIssuesWatermark(ctx context.Context, req *issues.IssuesWatermarkRequest) (*issues.IssuesWatermarkResponse, error) {
+	updatedAt, err := imds.IssuePersistence.GetIssuesWatermark(req.GetRepositoryName())
+	if err != nil {
+		return nil, err
+	}
+	if updatedAt.IsZero() {
+		return &issues.IssuesWatermarkResponse{}, nil
+	}
+	return &issues.IssuesWatermarkResponse{
+		LastUpdatedAt: updatedAt.UTC().Format(constants.ISO_8601_TIME_LAYOUT),
+	}, nil
+}
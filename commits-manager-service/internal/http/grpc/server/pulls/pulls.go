@@ -0,0 +1,75 @@
+package pulls
+
+import (
+	"commits-manager-service/internal/constants"
+	"commits-manager-service/internal/constants/models"
+	"commits-manager-service/internal/http/grpc/protos/pulls"
+	"commits-manager-service/internal/storage/db"
+	"context"
+	"time"
+)
+
+// maxPullRequests caps the pull requests returned at once.
+const maxPullRequests = 100
+
+type PullsMetaDataServer struct {
+	pulls.UnimplementedPullsServiceServer
+	PullRequestPersistence db.PullRequestRepository
+}
+
+func (pmds *PullsMetaDataServer) GetPullRequestsWatermark(ctx context.Context, req *pulls.PullRequestsWatermarkRequest) (*pulls.PullRequestsWatermarkResponse, error) {
+	updatedAt, err := pmds.PullRequestPersistence.GetPullRequestsWatermark(req.GetRepositoryName())
+	if err != nil {
+		return nil, err
+	}
+	return &pulls.PullRequestsWatermarkResponse{LastUpdatedAt: formatTime(&updatedAt)}, nil
+}
+
+func (pmds *PullsMetaDataServer) GetPullRequests(ctx context.Context, req *pulls.PullRequestsRequest) (*pulls.PullRequestsResponse, error) {
+	limit := int(req.GetLimit())
+	if limit < 1 || limit > maxPullRequests {
+		limit = maxPullRequests
+	}
+	pullRequests, err := pmds.PullRequestPersistence.GetPullRequestsByRepoName(req.GetRepositoryName(), req.GetState(), limit, int(req.GetOffset()))
+	if err != nil {
+		return nil, err
+	}
+	total, err := pmds.PullRequestPersistence.GetTotalPullRequestsByRepoName(req.GetRepositoryName(), req.GetState())
+	if err != nil {
+		return nil, err
+	}
+	return &pulls.PullRequestsResponse{
+		PullRequests: Convert(pullRequests),
+		Total:        int32(total),
+	}, nil
+}
+
+func Convert(pullRequests []*models.PullRequest) []*pulls.PullRequest {
+	converted := make([]*pulls.PullRequest, 0, len(pullRequests))
+	for _, pr := range pullRequests {
+		converted = append(converted, &pulls.PullRequest{
+			RepositoryName: pr.RepositoryName,
+			Number:         int32(pr.Number),
+			Title:          pr.Title,
+			Author:         pr.Author,
+			State:          pr.State,
+			HeadRef:        pr.HeadRef,
+			BaseRef:        pr.BaseRef,
+			Url:            pr.URL,
+			CreatedAt:      formatTime(&pr.CreatedAt),
+			UpdatedAt:      formatTime(&pr.UpdatedAt),
+			MergedAt:       formatTime(pr.MergedAt),
+			ClosedAt:       formatTime(pr.ClosedAt),
+			CommitShas:     pr.CommitSHAs,
+		})
+	}
+	return converted
+}
+
+// formatTime formats t in the ISO 8601 layout, nil and zero times as empty.
+func formatTime(t *time.Time) string {
+	if t == nil || t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(constants.ISO_8601_TIME_LAYOUT)
+}
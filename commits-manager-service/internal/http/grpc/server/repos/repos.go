@@ -6,11 +6,14 @@ import (
 	"commits-manager-service/internal/http/grpc/protos/repos"
 	"commits-manager-service/internal/storage/db"
 	"context"
+	"time"
 )
 
 type ReposMetaDataServer struct {
 	repos.UnimplementedRepositoriesServiceServer
-	RepositoryPersistence db.GitReposRepository
+	RepositoryPersistence        db.GitReposRepository
+	PollSchedulePersistence      db.PollScheduleRepository
+	TrackedRepositoryPersistence db.TrackedRepositoryRepository
 }
 
 func (rmds *ReposMetaDataServer) GetRepositories(ctx context.Context, req *repos.GetRepositoriesRequest) (*repos.GetRepositoriesResponse, error) {
@@ -34,7 +37,7 @@ func (rmds *ReposMetaDataServer) GetRepositoryNames(ctx context.Context, req *re
 }
 
 func (rmds *ReposMetaDataServer) GetReposFetchHistory(ctx context.Context, req *repos.GetReposFetchHistoryRequest) (*repos.GetReposFetchHistoryResponse, error) {
-	reposFetchData, err := rmds.RepositoryPersistence.GetLastReposFetchHistory()
+	reposFetchData, err := rmds.RepositoryPersistence.GetLastReposFetchHistory(req.GetOwner())
 	if err != nil {
 		return nil, err
 	}
@@ -44,11 +47,90 @@ func (rmds *ReposMetaDataServer) GetReposFetchHistory(ctx context.Context, req *
 	}, nil
 }
 
+// GetPollSchedules returns the poll schedules of the repositories still
+// polled.
+func (rmds *ReposMetaDataServer) GetPollSchedules(ctx context.Context, req *repos.GetPollSchedulesRequest) (*repos.GetPollSchedulesResponse, error) {
+	since, err := time.Parse(constants.ISO_8601_TIME_LAYOUT, req.GetRecentCommitsSince())
+	if err != nil {
+		return nil, err
+	}
+	schedules, err := rmds.PollSchedulePersistence.GetPollSchedules("", since)
+	if err != nil {
+		return nil, err
+	}
+
+	converted := make([]*repos.PollSchedule, 0, len(schedules))
+	for _, schedule := range schedules {
+		nextPollAt := ""
+		if schedule.NextPollAt != nil {
+			nextPollAt = schedule.NextPollAt.UTC().Format(constants.ISO_8601_TIME_LAYOUT)
+		}
+		converted = append(converted, &repos.PollSchedule{
+			Repository:            schedule.RepositoryName,
+			PushedAt:              schedule.PushedAt.UTC().Format(constants.ISO_8601_TIME_LAYOUT),
+			RecentCommits:         int32(schedule.RecentCommits),
+			IntervalSeconds:       schedule.IntervalSeconds,
+			PinnedIntervalSeconds: schedule.PinnedIntervalSeconds,
+			EmptyFetches:          int32(schedule.EmptyFetches),
+			NextPollAt:            nextPollAt,
+		})
+	}
+	return &repos.GetPollSchedulesResponse{Schedules: converted}, nil
+}
+
+// SavePollSchedule stores when the commits monitor polls a repository next.
+func (rmds *ReposMetaDataServer) SavePollSchedule(ctx context.Context, req *repos.SavePollScheduleRequest) (*repos.SavePollScheduleResponse, error) {
+	nextPollAt, err := time.Parse(constants.ISO_8601_TIME_LAYOUT, req.GetNextPollAt())
+	if err != nil {
+		return nil, err
+	}
+	err = rmds.PollSchedulePersistence.SavePollSchedule(models.PollSchedule{
+		RepositoryName:  req.GetRepository(),
+		IntervalSeconds: req.GetIntervalSeconds(),
+		EmptyFetches:    int(req.GetEmptyFetches()),
+		NextPollAt:      &nextPollAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &repos.SavePollScheduleResponse{}, nil
+}
+
+// GetTrackedRepositories returns the settings of the repositories that
+// override the configured defaults.
+func (rmds *ReposMetaDataServer) GetTrackedRepositories(ctx context.Context, req *repos.GetTrackedRepositoriesRequest) (*repos.GetTrackedRepositoriesResponse, error) {
+	trackedRepositories, err := rmds.TrackedRepositoryPersistence.GetTrackedRepositories()
+	if err != nil {
+		return nil, err
+	}
+
+	converted := make([]*repos.TrackedRepository, 0, len(trackedRepositories))
+	for _, settings := range trackedRepositories {
+		syncStartDate := ""
+		if settings.SyncStartDate != nil {
+			syncStartDate = settings.SyncStartDate.UTC().Format(constants.ISO_8601_TIME_LAYOUT)
+		}
+		converted = append(converted, &repos.TrackedRepository{
+			Repository:    settings.RepositoryName,
+			Enabled:       settings.Enabled,
+			SyncStartDate: syncStartDate,
+			Branches:      settings.Branches,
+			BranchesSet:   settings.Branches != nil,
+			ExcludeBots:   settings.ExcludeBots,
+		})
+	}
+	return &repos.GetTrackedRepositoriesResponse{Repositories: converted}, nil
+}
+
 func Convert(repositories []*models.Repository) []*repos.Repository {
 	convertedRepos := make([]*repos.Repository, 0)
 	for i := range repositories {
 		convertedRepos = append(convertedRepos, &repos.Repository{
 			Name:            repositories[i].Name,
+			Owner:           repositories[i].Owner,
+			FullName:        repositories[i].FullName,
+			OwnerType:       repositories[i].OwnerType,
+			Provider:        repositories[i].Provider,
 			Description:     repositories[i].Description,
 			Url:             repositories[i].URL,
 			Language:        repositories[i].Language,
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"commits-manager-service/internal/module/actions"
+	"commits-manager-service/internal/module/repos"
+)
+
+type ActionsHandler struct {
+	ActionsManagerService    actions.ActionsManagerService
+	RepositoryManagerService repos.RepositoryManagerService
+}
+
+func NewActionsHandler(actionsManagerService actions.ActionsManagerService, repositoryManagerService repos.RepositoryManagerService) *ActionsHandler {
+	return &ActionsHandler{
+		ActionsManagerService:    actionsManagerService,
+		RepositoryManagerService: repositoryManagerService,
+	}
+}
+
+func (h *ActionsHandler) GetWorkflowStats(w http.ResponseWriter, r *http.Request) {
+	repoName, ok := repositoryFullName(w, r, h.RepositoryManagerService)
+	if !ok {
+		return
+	}
+
+	startDate := time.Time{}
+	endDate := time.Now()
+	var err error
+
+	if startDateStr := r.URL.Query().Get("startDate"); startDateStr != "" {
+		startDate, err = time.Parse(time.RFC3339, startDateStr)
+		if err != nil {
+			errorJSON(w, errors.New("invalid startDate format"), http.StatusBadRequest)
+			return
+		}
+	}
+	if endDateStr := r.URL.Query().Get("endDate"); endDateStr != "" {
+		endDate, err = time.Parse(time.RFC3339, endDateStr)
+		if err != nil {
+			errorJSON(w, errors.New("invalid endDate format"), http.StatusBadRequest)
+			return
+		}
+	}
+
+	workflowStats, err := h.ActionsManagerService.GetWorkflowStats(repoName, startDate, endDate)
+	if err != nil {
+		errorJSON(w, errors.New("failed to compute workflow stats"), http.StatusBadRequest)
+		return
+	}
+
+	payload := jsonResponse{
+		Error:   false,
+		Message: "workflow stats",
+		Data:    workflowStats,
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
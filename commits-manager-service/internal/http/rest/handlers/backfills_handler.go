@@ -0,0 +1,155 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"commits-manager-service/internal/module/backfills"
+	"commits-manager-service/internal/module/repos"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type BackfillsHandler struct {
+	BackfillsManagerService  backfills.BackfillsManagerService
+	RepositoryManagerService repos.RepositoryManagerService
+}
+
+func NewBackfillsHandler(backfillsManagerService backfills.BackfillsManagerService, repositoryManagerService repos.RepositoryManagerService) *BackfillsHandler {
+	return &BackfillsHandler{
+		BackfillsManagerService:  backfillsManagerService,
+		RepositoryManagerService: repositoryManagerService,
+	}
+}
+
+// CreateBackfillJob queues a backfill job, e.g.
+// {"repository": "owner/name", "startDate": "2020-01-01T00:00:00Z", "endDate": "2021-01-01T00:00:00Z", "priority": 5}.
+// Without a repository all repositories are backfilled and without an
+// endDate the job runs up to now. A repository that is not tracked is
+// answered with 404.
+func (h *BackfillsHandler) CreateBackfillJob(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Repository string `json:"repository"`
+		StartDate  string `json:"startDate"`
+		EndDate    string `json:"endDate"`
+		Priority   int    `json:"priority"`
+	}
+	if err := readJSON(w, r, &request); err != nil {
+		errorJSON(w, errors.New("invalid request body"), http.StatusBadRequest)
+		return
+	}
+
+	startDate, err := time.Parse(time.RFC3339, request.StartDate)
+	if err != nil {
+		errorJSON(w, errors.New("invalid startDate format"), http.StatusBadRequest)
+		return
+	}
+	endDate := time.Now().UTC()
+	if request.EndDate != "" {
+		endDate, err = time.Parse(time.RFC3339, request.EndDate)
+		if err != nil {
+			errorJSON(w, errors.New("invalid endDate format"), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// a repository resolved to no tracked one is reported not found by
+	// CreateBackfillJob
+	var repoName string
+	if request.Repository != "" {
+		owner, name, found := strings.Cut(request.Repository, "/")
+		if !found {
+			owner, name = "", request.Repository
+		}
+		repoName, err = h.RepositoryManagerService.ResolveFullName(owner, name)
+		if errors.Is(err, repos.ErrAmbiguousRepositoryName) {
+			errorJSON(w, err, http.StatusBadRequest)
+			return
+		}
+		if err != nil {
+			errorJSON(w, errors.New("failed to resolve repository"), http.StatusBadRequest)
+			return
+		}
+	}
+
+	job, err := h.BackfillsManagerService.CreateBackfillJob(repoName, startDate, endDate, request.Priority)
+	if errors.Is(err, backfills.ErrInvalidDateRange) {
+		errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+	if errors.Is(err, backfills.ErrRepositoryNotFound) {
+		errorJSON(w, err, http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		errorJSON(w, errors.New("failed to create backfill job"), http.StatusBadRequest)
+		return
+	}
+
+	payload := jsonResponse{
+		Error:   false,
+		Message: "backfill job",
+		Data:    job,
+	}
+
+	writeJSON(w, http.StatusCreated, payload)
+}
+
+// GetBackfillJobs lists the backfill jobs, newest first, optionally only
+// those with the given status.
+func (h *BackfillsHandler) GetBackfillJobs(w http.ResponseWriter, r *http.Request) {
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	status := r.URL.Query().Get("status")
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 20
+	}
+	offset := (page - 1) * limit
+
+	jobs, err := h.BackfillsManagerService.GetBackfillJobs(status, limit, offset)
+	if err != nil {
+		errorJSON(w, errors.New("failed to fetch backfill jobs"), http.StatusBadRequest)
+		return
+	}
+
+	payload := jsonResponse{
+		Error:   false,
+		Message: "backfill jobs",
+		Data:    jobs,
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
+
+func (h *BackfillsHandler) GetBackfillJob(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.ParseInt(chi.URLParam(r, "id"), 10, 64)
+	if err != nil {
+		errorJSON(w, errors.New("invalid backfill job id"), http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.BackfillsManagerService.GetBackfillJob(id)
+	if err != nil {
+		errorJSON(w, errors.New("failed to fetch backfill job"), http.StatusBadRequest)
+		return
+	}
+	if job == nil {
+		errorJSON(w, errors.New("backfill job not found"), http.StatusNotFound)
+		return
+	}
+
+	payload := jsonResponse{
+		Error:   false,
+		Message: "backfill job",
+		Data:    job,
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
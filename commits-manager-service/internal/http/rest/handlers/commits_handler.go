@@ -1,34 +1,43 @@
 package handlers
 
 import (
+	"database/sql"
 	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
 	"time"
 
 	"commits-manager-service/internal/module/commits"
+	"commits-manager-service/internal/module/repos"
 
 	"github.com/go-chi/chi/v5"
 )
 
 type CommitsHandler struct {
-	CommitsManagerService commits.CommitsManagerService
+	CommitsManagerService    commits.CommitsManagerService
+	RepositoryManagerService repos.RepositoryManagerService
 }
 
-func NewCommitsHandler(commitPersistence commits.CommitsManagerService) *CommitsHandler {
+func NewCommitsHandler(commitPersistence commits.CommitsManagerService, repositoryManagerService repos.RepositoryManagerService) *CommitsHandler {
 	return &CommitsHandler{
-		CommitsManagerService: commitPersistence,
+		CommitsManagerService:    commitPersistence,
+		RepositoryManagerService: repositoryManagerService,
 	}
 }
 
 func (h *CommitsHandler) GetAllCommits(w http.ResponseWriter, r *http.Request) {
-	repoName := chi.URLParam(r, "repositoryName")
+	repoName, ok := repositoryFullName(w, r, h.RepositoryManagerService)
+	if !ok {
+		return
+	}
 
 	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
 	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
 	startDateStr := r.URL.Query().Get("startDate")
 	endDateStr := r.URL.Query().Get("endDate")
+	branch := r.URL.Query().Get("branch")
 
 	if page < 1 {
 		page = 1
@@ -61,13 +70,13 @@ func (h *CommitsHandler) GetAllCommits(w http.ResponseWriter, r *http.Request) {
 		endDate = time.Now() 
 	}
 
-	commits, err := h.CommitsManagerService.GetCommitsByRepositoryName(repoName, limit, offset, startDate, endDate)
+	commits, err := h.CommitsManagerService.GetCommitsByRepositoryName(repoName, branch, limit, offset, startDate, endDate)
 	if err != nil {
 		errorJSON(w, errors.New("failed to fetch commits"), http.StatusBadRequest)
 		return
 	}
 
-	totalCommits, err := h.CommitsManagerService.GetTotalCommitsByRepositoryName(repoName, startDate, endDate)
+	totalCommits, err := h.CommitsManagerService.GetTotalCommitsByRepositoryName(repoName, branch, startDate, endDate)
 	if err != nil {
 		errorJSON(w, errors.New("failed to fetch total number of commits"), http.StatusBadRequest)
 		return
@@ -77,12 +86,12 @@ func (h *CommitsHandler) GetAllCommits(w http.ResponseWriter, r *http.Request) {
 
 	prevPage := ""
 	if page > 1 {
-		prevPage = fmt.Sprintf("/repositories/%s/commits?page=%d&limit=%d&startDate=%s&endDate=%s", repoName, page-1, limit, startDateStr, endDateStr)
+		prevPage = fmt.Sprintf("/repositories/%s/commits?page=%d&limit=%d&startDate=%s&endDate=%s&branch=%s", repoName, page-1, limit, startDateStr, endDateStr, url.QueryEscape(branch))
 	}
 
 	nextPage := ""
 	if page < totalPages {
-		nextPage = fmt.Sprintf("/repositories/%s/commits?page=%d&limit=%d&startDate=%s&endDate=%s", repoName, page+1, limit, startDateStr, endDateStr)
+		nextPage = fmt.Sprintf("/repositories/%s/commits?page=%d&limit=%d&startDate=%s&endDate=%s&branch=%s", repoName, page+1, limit, startDateStr, endDateStr, url.QueryEscape(branch))
 	}
 
 	payload := jsonResponse{
@@ -124,7 +133,10 @@ func (h *CommitsHandler) GetTopCommitAuthors(w http.ResponseWriter, r *http.Requ
 }
 
 func (h *CommitsHandler) GetTopCommitAuthorsByRepo(w http.ResponseWriter, r *http.Request) {
-	repoName := chi.URLParam(r, "repositoryName")
+	repoName, ok := repositoryFullName(w, r, h.RepositoryManagerService)
+	if !ok {
+		return
+	}
 	limitStr := r.URL.Query().Get("limit")
 	limit, err := strconv.Atoi(limitStr)
 	if err != nil {
@@ -146,3 +158,53 @@ func (h *CommitsHandler) GetTopCommitAuthorsByRepo(w http.ResponseWriter, r *htt
 
 	writeJSON(w, http.StatusOK, payload)
 }
+
+func (h *CommitsHandler) GetCommitsFetchOutcomes(w http.ResponseWriter, r *http.Request) {
+	repoName, ok := repositoryFullName(w, r, h.RepositoryManagerService)
+	if !ok {
+		return
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	if limit < 1 {
+		limit = 20
+	}
+
+	outcomes, err := h.CommitsManagerService.GetCommitsFetchOutcomes(repoName, limit)
+	if err != nil {
+		errorJSON(w, errors.New("failed to fetch commits fetch outcomes"), http.StatusBadRequest)
+		return
+	}
+
+	payload := jsonResponse{
+		Error:   false,
+		Message: "commits fetch outcomes",
+		Data:    outcomes,
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
+
+func (h *CommitsHandler) GetCommitDetails(w http.ResponseWriter, r *http.Request) {
+	repoName, ok := repositoryFullName(w, r, h.RepositoryManagerService)
+	if !ok {
+		return
+	}
+
+	commit, err := h.CommitsManagerService.GetCommitDetails(repoName, chi.URLParam(r, "sha"))
+	if errors.Is(err, sql.ErrNoRows) {
+		errorJSON(w, errors.New("commit not found"), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		errorJSON(w, errors.New("failed to fetch commit"), http.StatusBadRequest)
+		return
+	}
+
+	payload := jsonResponse{
+		Error:   false,
+		Message: "commit",
+		Data:    commit,
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
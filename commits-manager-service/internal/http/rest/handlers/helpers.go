@@ -2,7 +2,12 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
+
+	"commits-manager-service/internal/module/repos"
+
+	"github.com/go-chi/chi/v5"
 )
 
 type jsonResponse struct {
@@ -51,3 +56,34 @@ func errorJSON(w http.ResponseWriter, err error, status ...int) error {
 
 	return writeJSON(w, statusCode, payload)
 }
+
+// repositoryFullName resolves the repository addressed by the owner and
+// repositoryName route parameters and writes an error response when it
+// cannot be resolved.
+func repositoryFullName(w http.ResponseWriter, r *http.Request, repositories repos.RepositoryManagerService) (string, bool) {
+	fullName, err := repositories.ResolveFullName(chi.URLParam(r, "owner"), chi.URLParam(r, "repositoryName"))
+	if errors.Is(err, repos.ErrAmbiguousRepositoryName) {
+		errorJSON(w, err, http.StatusBadRequest)
+		return "", false
+	}
+	if err != nil {
+		errorJSON(w, errors.New("failed to resolve repository"), http.StatusBadRequest)
+		return "", false
+	}
+	return fullName, true
+}
+
+// readJSON decodes a json request body of at most 1 MB into data.
+func readJSON(w http.ResponseWriter, r *http.Request, data any) error {
+	r.Body = http.MaxBytesReader(w, r.Body, 1<<20)
+
+	dec := json.NewDecoder(r.Body)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(data); err != nil {
+		return err
+	}
+	if dec.More() {
+		return errors.New("body must have only a single json value")
+	}
+	return nil
+}
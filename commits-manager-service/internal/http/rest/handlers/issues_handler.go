@@ -0,0 +1,152 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"commits-manager-service/internal/constants"
+	"commits-manager-service/internal/module/issues"
+	"commits-manager-service/internal/module/repos"
+)
+
+type IssuesHandler struct {
+	IssuesManagerService     issues.IssuesManagerService
+	RepositoryManagerService repos.RepositoryManagerService
+}
+
+func NewIssuesHandler(issuesManagerService issues.IssuesManagerService, repositoryManagerService repos.RepositoryManagerService) *IssuesHandler {
+	return &IssuesHandler{
+		IssuesManagerService:     issuesManagerService,
+		RepositoryManagerService: repositoryManagerService,
+	}
+}
+
+func (h *IssuesHandler) GetIssues(w http.ResponseWriter, r *http.Request) {
+	repoName, ok := repositoryFullName(w, r, h.RepositoryManagerService)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	state := r.URL.Query().Get("state")
+	label := r.URL.Query().Get("label")
+
+	switch state {
+	case "", constants.ISSUE_STATE_OPEN, constants.ISSUE_STATE_CLOSED:
+	default:
+		errorJSON(w, errors.New("invalid state, expected open or closed"), http.StatusBadRequest)
+		return
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	offset := (page - 1) * limit
+
+	issues, err := h.IssuesManagerService.GetIssuesByRepositoryName(repoName, state, label, limit, offset)
+	if err != nil {
+		errorJSON(w, errors.New("failed to fetch issues"), http.StatusBadRequest)
+		return
+	}
+
+	totalIssues, err := h.IssuesManagerService.GetTotalIssuesByRepositoryName(repoName, state, label)
+	if err != nil {
+		errorJSON(w, errors.New("failed to fetch total number of issues"), http.StatusBadRequest)
+		return
+	}
+
+	totalPages := (totalIssues + limit - 1) / limit
+
+	prevPage := ""
+	if page > 1 {
+		prevPage = fmt.Sprintf("/issues/%s?page=%d&limit=%d&state=%s&label=%s", repoName, page-1, limit, state, url.QueryEscape(label))
+	}
+
+	nextPage := ""
+	if page < totalPages {
+		nextPage = fmt.Sprintf("/issues/%s?page=%d&limit=%d&state=%s&label=%s", repoName, page+1, limit, state, url.QueryEscape(label))
+	}
+
+	payload := jsonResponse{
+		Error:   false,
+		Message: "issues",
+		Data:    issues,
+		Pagination: map[string]interface{}{
+			"currentPage": page,
+			"prevPage":    prevPage,
+			"nextPage":    nextPage,
+			"totalPages":  totalPages,
+		},
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
+
+func (h *IssuesHandler) GetIssuesTimeToClose(w http.ResponseWriter, r *http.Request) {
+	repoName, ok := repositoryFullName(w, r, h.RepositoryManagerService)
+	if !ok {
+		return
+	}
+
+	startDate := time.Time{}
+	endDate := time.Now()
+	var err error
+
+	if startDateStr := r.URL.Query().Get("startDate"); startDateStr != "" {
+		startDate, err = time.Parse(time.RFC3339, startDateStr)
+		if err != nil {
+			errorJSON(w, errors.New("invalid startDate format"), http.StatusBadRequest)
+			return
+		}
+	}
+	if endDateStr := r.URL.Query().Get("endDate"); endDateStr != "" {
+		endDate, err = time.Parse(time.RFC3339, endDateStr)
+		if err != nil {
+			errorJSON(w, errors.New("invalid endDate format"), http.StatusBadRequest)
+			return
+		}
+	}
+
+	timeToClose, err := h.IssuesManagerService.GetTimeToClose(repoName, startDate, endDate)
+	if err != nil {
+		errorJSON(w, errors.New("failed to compute issues time to close"), http.StatusBadRequest)
+		return
+	}
+
+	payload := jsonResponse{
+		Error:   false,
+		Message: "issues time to close",
+		Data:    timeToClose,
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
+
+func (h *IssuesHandler) GetOpenIssueAges(w http.ResponseWriter, r *http.Request) {
+	repoName, ok := repositoryFullName(w, r, h.RepositoryManagerService)
+	if !ok {
+		return
+	}
+
+	distribution, err := h.IssuesManagerService.GetOpenIssueAges(repoName, time.Now())
+	if err != nil {
+		errorJSON(w, errors.New("failed to compute open issue ages"), http.StatusBadRequest)
+		return
+	}
+
+	payload := jsonResponse{
+		Error:   false,
+		Message: "open issue ages",
+		Data:    distribution,
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
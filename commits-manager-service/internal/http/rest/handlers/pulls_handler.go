@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"commits-manager-service/internal/constants"
+	"commits-manager-service/internal/module/pulls"
+	"commits-manager-service/internal/module/repos"
+)
+
+type PullsHandler struct {
+	PullsManagerService      pulls.PullsManagerService
+	RepositoryManagerService repos.RepositoryManagerService
+}
+
+func NewPullsHandler(pullsManagerService pulls.PullsManagerService, repositoryManagerService repos.RepositoryManagerService) *PullsHandler {
+	return &PullsHandler{
+		PullsManagerService:      pullsManagerService,
+		RepositoryManagerService: repositoryManagerService,
+	}
+}
+
+func (h *PullsHandler) GetPullRequests(w http.ResponseWriter, r *http.Request) {
+	repoName, ok := repositoryFullName(w, r, h.RepositoryManagerService)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	state := r.URL.Query().Get("state")
+
+	switch state {
+	case "", constants.PULL_REQUEST_STATE_OPEN, constants.PULL_REQUEST_STATE_CLOSED, constants.PULL_REQUEST_STATE_MERGED:
+	default:
+		errorJSON(w, errors.New("invalid state, expected open, closed or merged"), http.StatusBadRequest)
+		return
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 {
+		limit = 10
+	}
+	offset := (page - 1) * limit
+
+	pullRequests, err := h.PullsManagerService.GetPullRequestsByRepositoryName(repoName, state, limit, offset)
+	if err != nil {
+		errorJSON(w, errors.New("failed to fetch pull requests"), http.StatusBadRequest)
+		return
+	}
+
+	totalPullRequests, err := h.PullsManagerService.GetTotalPullRequestsByRepositoryName(repoName, state)
+	if err != nil {
+		errorJSON(w, errors.New("failed to fetch total number of pull requests"), http.StatusBadRequest)
+		return
+	}
+
+	totalPages := (totalPullRequests + limit - 1) / limit
+
+	prevPage := ""
+	if page > 1 {
+		prevPage = fmt.Sprintf("/pulls/%s?page=%d&limit=%d&state=%s", repoName, page-1, limit, state)
+	}
+
+	nextPage := ""
+	if page < totalPages {
+		nextPage = fmt.Sprintf("/pulls/%s?page=%d&limit=%d&state=%s", repoName, page+1, limit, state)
+	}
+
+	payload := jsonResponse{
+		Error:   false,
+		Message: "pull requests",
+		Data:    pullRequests,
+		Pagination: map[string]interface{}{
+			"currentPage": page,
+			"prevPage":    prevPage,
+			"nextPage":    nextPage,
+			"totalPages":  totalPages,
+		},
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
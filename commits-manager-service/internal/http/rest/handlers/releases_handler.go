@@ -0,0 +1,75 @@
+package handlers
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+
+	"commits-manager-service/internal/module/releases"
+	"commits-manager-service/internal/module/repos"
+
+	"github.com/go-chi/chi/v5"
+)
+
+type ReleasesHandler struct {
+	ReleasesManagerService   releases.ReleasesManagerService
+	RepositoryManagerService repos.RepositoryManagerService
+}
+
+func NewReleasesHandler(releasesManagerService releases.ReleasesManagerService, repositoryManagerService repos.RepositoryManagerService) *ReleasesHandler {
+	return &ReleasesHandler{
+		ReleasesManagerService:   releasesManagerService,
+		RepositoryManagerService: repositoryManagerService,
+	}
+}
+
+func (h *ReleasesHandler) GetReleases(w http.ResponseWriter, r *http.Request) {
+	repoName, ok := repositoryFullName(w, r, h.RepositoryManagerService)
+	if !ok {
+		return
+	}
+
+	releases, err := h.ReleasesManagerService.GetReleasesByRepositoryName(repoName)
+	if err != nil {
+		errorJSON(w, errors.New("failed to fetch releases"), http.StatusBadRequest)
+		return
+	}
+
+	payload := jsonResponse{
+		Error:   false,
+		Message: "releases",
+		Data:    releases,
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
+
+func (h *ReleasesHandler) GetReleaseCommits(w http.ResponseWriter, r *http.Request) {
+	repoName, ok := repositoryFullName(w, r, h.RepositoryManagerService)
+	if !ok {
+		return
+	}
+
+	releaseCommits, err := h.ReleasesManagerService.GetReleaseCommits(repoName, chi.URLParam(r, "tag"))
+	if errors.Is(err, sql.ErrNoRows) {
+		errorJSON(w, errors.New("release not found"), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		errorJSON(w, errors.New("failed to fetch release commits"), http.StatusBadRequest)
+		return
+	}
+
+	message := "release commits"
+	if releaseCommits.Truncated {
+		message = "release commits, truncated to the newest ones"
+	}
+
+	payload := jsonResponse{
+		Error:   false,
+		Message: message,
+		Data:    releaseCommits,
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"commits-manager-service/internal/module/repos"
+	"commits-manager-service/internal/module/schedules"
+)
+
+type SchedulesHandler struct {
+	SchedulesManagerService  schedules.SchedulesManagerService
+	RepositoryManagerService repos.RepositoryManagerService
+}
+
+func NewSchedulesHandler(schedulesManagerService schedules.SchedulesManagerService, repositoryManagerService repos.RepositoryManagerService) *SchedulesHandler {
+	return &SchedulesHandler{
+		SchedulesManagerService:  schedulesManagerService,
+		RepositoryManagerService: repositoryManagerService,
+	}
+}
+
+func (h *SchedulesHandler) GetPollSchedules(w http.ResponseWriter, r *http.Request) {
+	pollSchedules, err := h.SchedulesManagerService.GetPollSchedules("")
+	if err != nil {
+		errorJSON(w, errors.New("failed to fetch poll schedules"), http.StatusBadRequest)
+		return
+	}
+
+	payload := jsonResponse{
+		Error:   false,
+		Message: "poll schedules",
+		Data:    pollSchedules,
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
+
+func (h *SchedulesHandler) GetPollSchedule(w http.ResponseWriter, r *http.Request) {
+	repoName, ok := repositoryFullName(w, r, h.RepositoryManagerService)
+	if !ok {
+		return
+	}
+
+	pollSchedules, err := h.SchedulesManagerService.GetPollSchedules(repoName)
+	if err != nil {
+		errorJSON(w, errors.New("failed to fetch poll schedule"), http.StatusBadRequest)
+		return
+	}
+	if len(pollSchedules) == 0 {
+		errorJSON(w, errors.New("repository not found"), http.StatusNotFound)
+		return
+	}
+
+	payload := jsonResponse{
+		Error:   false,
+		Message: "poll schedule",
+		Data:    pollSchedules[0],
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
+
+// PinPollInterval pins the poll interval of a repository to the duration
+// given as pinnedInterval, e.g. {"pinnedInterval": "30m"}. An empty or zero
+// duration unpins it.
+func (h *SchedulesHandler) PinPollInterval(w http.ResponseWriter, r *http.Request) {
+	repoName, ok := repositoryFullName(w, r, h.RepositoryManagerService)
+	if !ok {
+		return
+	}
+
+	var request struct {
+		PinnedInterval string `json:"pinnedInterval"`
+	}
+	if err := readJSON(w, r, &request); err != nil {
+		errorJSON(w, errors.New("invalid request body"), http.StatusBadRequest)
+		return
+	}
+	var interval time.Duration
+	if request.PinnedInterval != "" {
+		var err error
+		interval, err = time.ParseDuration(request.PinnedInterval)
+		if err != nil {
+			errorJSON(w, errors.New("invalid pinnedInterval format"), http.StatusBadRequest)
+			return
+		}
+	}
+
+	pollSchedules, err := h.SchedulesManagerService.GetPollSchedules(repoName)
+	if err != nil {
+		errorJSON(w, errors.New("failed to fetch poll schedule"), http.StatusBadRequest)
+		return
+	}
+	if len(pollSchedules) == 0 {
+		errorJSON(w, errors.New("repository not found"), http.StatusNotFound)
+		return
+	}
+
+	err = h.SchedulesManagerService.PinPollInterval(repoName, interval)
+	if errors.Is(err, schedules.ErrPinnedIntervalTooShort) {
+		errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		errorJSON(w, errors.New("failed to pin poll interval"), http.StatusBadRequest)
+		return
+	}
+
+	pollSchedules, err = h.SchedulesManagerService.GetPollSchedules(repoName)
+	if err != nil || len(pollSchedules) == 0 {
+		errorJSON(w, errors.New("failed to fetch poll schedule"), http.StatusBadRequest)
+		return
+	}
+
+	payload := jsonResponse{
+		Error:   false,
+		Message: "poll schedule",
+		Data:    pollSchedules[0],
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
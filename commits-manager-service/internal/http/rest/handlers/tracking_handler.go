@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"commits-manager-service/internal/constants/models"
+	"commits-manager-service/internal/module/repos"
+	"commits-manager-service/internal/module/tracking"
+)
+
+type TrackingHandler struct {
+	TrackingManagerService   tracking.TrackingManagerService
+	RepositoryManagerService repos.RepositoryManagerService
+}
+
+func NewTrackingHandler(trackingManagerService tracking.TrackingManagerService, repositoryManagerService repos.RepositoryManagerService) *TrackingHandler {
+	return &TrackingHandler{
+		TrackingManagerService:   trackingManagerService,
+		RepositoryManagerService: repositoryManagerService,
+	}
+}
+
+func (h *TrackingHandler) GetTrackedRepositories(w http.ResponseWriter, r *http.Request) {
+	trackedRepositories, err := h.TrackingManagerService.GetTrackedRepositories()
+	if err != nil {
+		errorJSON(w, errors.New("failed to fetch tracked repositories"), http.StatusBadRequest)
+		return
+	}
+
+	payload := jsonResponse{
+		Error:   false,
+		Message: "tracked repositories",
+		Data:    trackedRepositories,
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
+
+func (h *TrackingHandler) GetTrackedRepository(w http.ResponseWriter, r *http.Request) {
+	repoName, ok := repositoryFullName(w, r, h.RepositoryManagerService)
+	if !ok {
+		return
+	}
+
+	settings, err := h.TrackingManagerService.GetTrackedRepository(repoName)
+	if err != nil {
+		errorJSON(w, errors.New("failed to fetch tracked repository"), http.StatusBadRequest)
+		return
+	}
+	if settings == nil {
+		errorJSON(w, errors.New("tracked repository not found"), http.StatusNotFound)
+		return
+	}
+
+	payload := jsonResponse{
+		Error:   false,
+		Message: "tracked repository",
+		Data:    settings,
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
+
+// SaveTrackedRepository creates or replaces the settings of a repository, e.g.
+// {"enabled": true, "syncStartDate": "2024-01-01T00:00:00Z", "branches": ["main", "release/*"], "pollInterval": "30m", "excludeBots": true}.
+// Repositories are enabled unless enabled is false; settings left out keep
+// the defaults of the services, so leaving out pollInterval unpins it. An
+// empty branches list syncs the default branch only.
+func (h *TrackingHandler) SaveTrackedRepository(w http.ResponseWriter, r *http.Request) {
+	repoName, ok := repositoryFullName(w, r, h.RepositoryManagerService)
+	if !ok {
+		return
+	}
+	if !strings.Contains(repoName, "/") {
+		errorJSON(w, errors.New("repository not found, use /{owner}/{repositoryName}"), http.StatusNotFound)
+		return
+	}
+
+	var request struct {
+		Enabled       *bool    `json:"enabled"`
+		SyncStartDate string   `json:"syncStartDate"`
+		Branches      []string `json:"branches"`
+		PollInterval  string   `json:"pollInterval"`
+		ExcludeBots   bool     `json:"excludeBots"`
+	}
+	if err := readJSON(w, r, &request); err != nil {
+		errorJSON(w, errors.New("invalid request body"), http.StatusBadRequest)
+		return
+	}
+
+	settings := models.TrackedRepository{
+		RepositoryName: repoName,
+		Enabled:        request.Enabled == nil || *request.Enabled,
+		Branches:       request.Branches,
+		ExcludeBots:    request.ExcludeBots,
+	}
+	if request.SyncStartDate != "" {
+		syncStartDate, err := time.Parse(time.RFC3339, request.SyncStartDate)
+		if err != nil {
+			errorJSON(w, errors.New("invalid syncStartDate format"), http.StatusBadRequest)
+			return
+		}
+		settings.SyncStartDate = &syncStartDate
+	}
+	if request.PollInterval != "" {
+		interval, err := time.ParseDuration(request.PollInterval)
+		if err != nil {
+			errorJSON(w, errors.New("invalid pollInterval format"), http.StatusBadRequest)
+			return
+		}
+		settings.PollIntervalSeconds = int64(interval / time.Second)
+	}
+
+	saved, err := h.TrackingManagerService.SaveTrackedRepository(settings)
+	if errors.Is(err, tracking.ErrPollIntervalTooShort) || errors.Is(err, tracking.ErrInvalidBranchPattern) {
+		errorJSON(w, err, http.StatusBadRequest)
+		return
+	}
+	if err != nil {
+		errorJSON(w, errors.New("failed to save tracked repository"), http.StatusBadRequest)
+		return
+	}
+
+	payload := jsonResponse{
+		Error:   false,
+		Message: "tracked repository",
+		Data:    saved,
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
+
+func (h *TrackingHandler) DeleteTrackedRepository(w http.ResponseWriter, r *http.Request) {
+	repoName, ok := repositoryFullName(w, r, h.RepositoryManagerService)
+	if !ok {
+		return
+	}
+
+	deleted, err := h.TrackingManagerService.DeleteTrackedRepository(repoName)
+	if err != nil {
+		errorJSON(w, errors.New("failed to delete tracked repository"), http.StatusBadRequest)
+		return
+	}
+	if !deleted {
+		errorJSON(w, errors.New("tracked repository not found"), http.StatusNotFound)
+		return
+	}
+
+	payload := jsonResponse{
+		Error:   false,
+		Message: "tracked repository deleted",
+	}
+
+	writeJSON(w, http.StatusOK, payload)
+}
@@ -7,26 +7,45 @@ import (
 	"encoding/json"
 	"fmt"
 	"log"
+	"regexp"
+	"strings"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 type Consumer struct {
-	conn                  *amqp.Connection
-	queueName             string
-	CommitPersistence     db.CommitRepository
-	RepositoryPersistence db.GitReposRepository
+	conn                   *amqp.Connection
+	queueName              string
+	CommitPersistence      db.CommitRepository
+	RepositoryPersistence  db.GitReposRepository
+	PullRequestPersistence db.PullRequestRepository
+	IssuePersistence       db.IssueRepository
+	ReleasePersistence     db.ReleaseRepository
+	WorkflowRunPersistence db.WorkflowRunRepository
+	BackfillJobPersistence db.BackfillJobRepository
+	ordered                *orderedJobs
 }
 
 func NewConsumer(conn *amqp.Connection, queueName string,
 	commitPersistence db.CommitRepository,
-	repositoryPersistence db.GitReposRepository) (Consumer, error) {
+	repositoryPersistence db.GitReposRepository,
+	pullRequestPersistence db.PullRequestRepository,
+	issuePersistence db.IssueRepository,
+	releasePersistence db.ReleaseRepository,
+	workflowRunPersistence db.WorkflowRunRepository,
+	backfillJobPersistence db.BackfillJobRepository) (Consumer, error) {
 	consumer := Consumer{
-		conn:                  conn,
-		queueName:             queueName,
-		CommitPersistence:     commitPersistence,
-		RepositoryPersistence: repositoryPersistence,
+		conn:                   conn,
+		queueName:              queueName,
+		CommitPersistence:      commitPersistence,
+		RepositoryPersistence:  repositoryPersistence,
+		PullRequestPersistence: pullRequestPersistence,
+		IssuePersistence:       issuePersistence,
+		ReleasePersistence:     releasePersistence,
+		WorkflowRunPersistence: workflowRunPersistence,
+		BackfillJobPersistence: backfillJobPersistence,
+		ordered:                newOrderedJobs(),
 	}
 
 	err := consumer.setup()
@@ -77,7 +96,7 @@ func (consumer *Consumer) Listen(topics []string) error {
 		}
 	}
 
-	messages, err := ch.Consume(q.Name, "", true, false, false, false, nil)
+	messages, err := ch.Consume(q.Name, "", false, false, false, false, nil)
 	if err != nil {
 		return err
 	}
@@ -88,13 +107,38 @@ func (consumer *Consumer) Listen(topics []string) error {
 			var payload Payload
 			_ = json.Unmarshal(d.Body, &payload)
 
+			switch payload.Name {
+			case "commits":
+				consumer.saveInOrder(d, payload, consumer.proccessAndSaveCommits)
+				continue
+			case "commit-branches":
+				consumer.saveInOrder(d, payload, consumer.proccessAndSaveBranchLinks)
+				continue
+			case "pulls":
+				consumer.saveInOrder(d, payload, consumer.proccessAndSavePullRequests)
+				continue
+			}
+
+			// the other messages are acked as they are received and saved
+			// concurrently
+			d.Ack(false)
 			switch payload.Name {
 			case "repos":
 				go consumer.proccessAndSaveNewRepos(payload)
 			case "repo":
 				go consumer.proccessAndUpdateRepoMetaData(payload)
-			case "commits":
-				go consumer.proccessAndSaveCommits(payload)
+			case "commit-details":
+				go consumer.proccessAndSaveCommitDetails(payload)
+			case "commits-outcome":
+				go consumer.proccessAndSaveCommitsOutcome(payload)
+			case "backfill-progress":
+				go consumer.proccessAndSaveBackfillProgress(payload)
+			case "issues":
+				go consumer.proccessAndSaveIssues(payload)
+			case "releases":
+				go consumer.proccessAndSaveReleases(payload)
+			case "workflow-runs":
+				go consumer.proccessAndSaveWorkflowRuns(payload)
 			default:
 				log.Println("recieved payload-->", payload)
 			}
@@ -107,44 +151,198 @@ func (consumer *Consumer) Listen(topics []string) error {
 	return nil
 }
 
-func (consumer *Consumer) proccessAndSaveCommits(entry Payload) {
+// saveInOrder saves a message with the messages of the same repository
+// received before it saved first, as the monitor publishes the pages of a
+// repository oldest first so its stored watermark never moves past a page
+// that was not stored, and links the commits of a branch only once they
+// were stored. A failed save is retried, holding back the later
+// messages of the repository, and the message is acked once it is saved.
+func (consumer *Consumer) saveInOrder(d amqp.Delivery, entry Payload, save func(entry Payload) error) {
+	data, _ := entry.Data.(map[string]any)
+	repository, _ := data["Repository"].(string)
+
+	consumer.ordered.push(repository, func() {
+		for delay := time.Second; ; delay = min(2*delay, maxSaveRetryDelay) {
+			err := save(entry)
+			if err == nil {
+				break
+			}
+			fmt.Println("Consumer: Error saving ", entry.Name, " of ", repository, ", retrying in ", delay)
+			fmt.Println("Consumer: ERR:", err)
+			time.Sleep(delay)
+		}
+		d.Ack(false)
+	})
+}
+
+// maxSaveRetryDelay caps the time between two attempts to save a message.
+const maxSaveRetryDelay = time.Minute
+
+// proccessAndSaveCommits saves a page of the commits of a repository. Pages
+// that cannot be read are dropped, only errors saving them are returned.
+func (consumer *Consumer) proccessAndSaveCommits(entry Payload) error {
 	jsonData, _ := json.MarshalIndent(entry.Data, "", "\t")
 
 	var commitMetaData CommitMetaData
 	err := json.Unmarshal(jsonData, &commitMetaData)
+	if err != nil {
+		log.Println("Consumer: Cannot Convert To Commit MetaData")
+		return nil
+	}
 
-	if err == nil {
-		log.Println("Consumer-Recieved-Commit->", commitMetaData.Repository, len(commitMetaData.Commits))
-		if len(commitMetaData.Commits) > 0 {
-			commits := make([]models.Commit, len(commitMetaData.Commits))
-			for i, commit := range commitMetaData.Commits {
-				commits[i] = ConvertCommitResponseToCommit(commit, commitMetaData.Repository)
-			}
+	log.Println("Consumer-Recieved-Commit->", commitMetaData.Repository, len(commitMetaData.Commits))
+	if len(commitMetaData.Commits) == 0 {
+		return nil
+	}
 
-			err := consumer.CommitPersistence.SaveAllCommits(commits)
-			if err != nil {
-				fmt.Println("Consumer: Error saving commits of ", commitMetaData.Repository)
-				fmt.Println("Consumer: ERR:", err)
-				return
-			}
+	commits := make([]models.Commit, len(commitMetaData.Commits))
+	for i, commit := range commitMetaData.Commits {
+		commits[i] = ConvertCommitResponseToCommit(commit, commitMetaData.Repository)
+	}
 
-			err = consumer.CommitPersistence.SaveCommitsFetchData(models.CommitsFetchHistory{
-				RepositoryName: commitMetaData.Repository,
-				FetchedAt:      commitMetaData.FetchTime,
-				Total:          len(commits),
-				LastPage:       commitMetaData.LastPage,
-			})
+	err = consumer.CommitPersistence.SaveAllCommits(commits)
+	if err != nil {
+		return fmt.Errorf("saving commits: %w", err)
+	}
 
-			if err != nil {
-				fmt.Println("Consumer: Error updating last commit fetch time ", commitMetaData.Repository)
-				fmt.Println("Consumer: ERR:", err)
-				return
-			}
+	if commitMetaData.Branch != "" {
+		shas := make([]string, len(commits))
+		for i, commit := range commits {
+			shas[i] = commit.SHA
 		}
-	} else {
-		log.Println("Consumer: Cannot Convert To Commit MetaData")
+		err = consumer.CommitPersistence.SaveCommitBranches(commitMetaData.Repository, commitMetaData.Branch, shas)
+		if err != nil {
+			return fmt.Errorf("saving branch commits: %w", err)
+		}
+	}
+
+	err = consumer.CommitPersistence.SaveCommitsFetchData(models.CommitsFetchHistory{
+		RepositoryName: commitMetaData.Repository,
+		FetchedAt:      commitMetaData.FetchTime,
+		Total:          len(commits),
+	})
+	if err != nil {
+		return fmt.Errorf("updating last commit fetch time: %w", err)
+	}
+	return nil
+}
+
+// proccessAndSaveBranchLinks links stored commits of a repository to a
+// branch. Links that cannot be read are dropped, only errors saving them are
+// returned.
+func (consumer *Consumer) proccessAndSaveBranchLinks(entry Payload) error {
+	jsonData, _ := json.MarshalIndent(entry.Data, "", "\t")
+
+	var links BranchLinksMetaData
+	err := json.Unmarshal(jsonData, &links)
+	if err != nil {
+		log.Println("Consumer: Cannot Convert To Branch Links")
+		return nil
 	}
 
+	log.Println("Consumer-Recieved-Branch-Links->", links.Repository, links.Branch, len(links.Shas))
+	err = consumer.CommitPersistence.SaveCommitBranches(links.Repository, links.Branch, links.Shas)
+	if err != nil {
+		return fmt.Errorf("saving branch commits: %w", err)
+	}
+	return nil
+}
+
+func (consumer *Consumer) proccessAndSaveCommitDetails(entry Payload) {
+	jsonData, _ := json.MarshalIndent(entry.Data, "", "\t")
+
+	var detailsMetaData CommitDetailsMetaData
+	err := json.Unmarshal(jsonData, &detailsMetaData)
+	if err != nil {
+		log.Println("Consumer: Cannot Convert To Commit Details")
+		return
+	}
+
+	log.Println("Consumer-Recieved-Commit-Details->", detailsMetaData.Repository, len(detailsMetaData.Commits), len(detailsMetaData.Failures))
+	for _, commit := range detailsMetaData.Commits {
+		err := consumer.CommitPersistence.SaveCommitDetails(ConvertCommitResponseToCommitDetails(commit, detailsMetaData.Repository))
+		if err != nil {
+			fmt.Println("Consumer: Error saving details of commit ", commit.Sha)
+			fmt.Println("Consumer: ERR:", err)
+			return
+		}
+	}
+	for _, failure := range detailsMetaData.Failures {
+		err := consumer.CommitPersistence.SaveCommitDetailsFailure(detailsMetaData.Repository, failure.Sha, failure.StatusCode, failure.Message)
+		if err != nil {
+			fmt.Println("Consumer: Error saving details failure of commit ", failure.Sha)
+			fmt.Println("Consumer: ERR:", err)
+			return
+		}
+	}
+}
+
+func (consumer *Consumer) proccessAndSaveCommitsOutcome(entry Payload) {
+	jsonData, _ := json.MarshalIndent(entry.Data, "", "\t")
+
+	var outcome CommitsFetchOutcome
+	err := json.Unmarshal(jsonData, &outcome)
+	if err != nil {
+		log.Println("Consumer: Cannot Convert To Commits Fetch Outcome")
+		return
+	}
+
+	log.Println("Consumer-Recieved-Commits-Outcome->", outcome.Repository, outcome.Outcome)
+	err = consumer.CommitPersistence.SaveCommitsFetchOutcome(models.CommitsFetchOutcome{
+		RepositoryName: outcome.Repository,
+		Outcome:        outcome.Outcome,
+		StatusCode:     outcome.StatusCode,
+		Message:        outcome.Message,
+		Total:          outcome.Total,
+		FetchedAt:      outcome.FetchTime,
+	})
+	if err != nil {
+		fmt.Println("Consumer: Error saving commits fetch outcome of ", outcome.Repository)
+		fmt.Println("Consumer: ERR:", err)
+		return
+	}
+
+	var syncStatus string
+	switch outcome.Outcome {
+	case constants.FETCH_OUTCOME_OK, constants.FETCH_OUTCOME_NOT_MODIFIED:
+		syncStatus = constants.SYNC_STATUS_ACTIVE
+	case constants.FETCH_OUTCOME_EMPTY:
+		syncStatus = constants.SYNC_STATUS_EMPTY
+	case constants.FETCH_OUTCOME_NOT_FOUND:
+		syncStatus = constants.SYNC_STATUS_NOT_FOUND
+	default:
+		return
+	}
+
+	err = consumer.RepositoryPersistence.UpdateRepositorySyncStatus(outcome.Repository, syncStatus)
+	if err != nil {
+		fmt.Println("Consumer: Error updating sync status of ", outcome.Repository)
+		fmt.Println("Consumer: ERR:", err)
+	}
+}
+
+func (consumer *Consumer) proccessAndSaveBackfillProgress(entry Payload) {
+	jsonData, _ := json.MarshalIndent(entry.Data, "", "\t")
+
+	var progress BackfillProgress
+	err := json.Unmarshal(jsonData, &progress)
+	if err != nil {
+		log.Println("Consumer: Cannot Convert To Backfill Progress")
+		return
+	}
+
+	err = consumer.BackfillJobPersistence.UpdateBackfillJobProgress(models.BackfillJob{
+		ID:                progress.JobID,
+		Status:            progress.Status,
+		RepositoriesTotal: progress.RepositoriesTotal,
+		RepositoriesDone:  progress.RepositoriesDone,
+		Commits:           progress.Commits,
+		Error:             progress.Error,
+	})
+	if err != nil {
+		fmt.Println("Consumer: Error saving progress of backfill job ", progress.JobID)
+		fmt.Println("Consumer: ERR:", err)
+	}
 }
 
 func (consumer *Consumer) proccessAndSaveNewRepos(entry Payload) {
@@ -168,6 +366,7 @@ func (consumer *Consumer) proccessAndSaveNewRepos(entry Payload) {
 				return
 			}
 			err = consumer.RepositoryPersistence.SaveReposFetchHistory(models.ReposFetchHistory{
+				Owner:     reposMetaData.Owner,
 				FetchedAt: reposMetaData.FetchTime,
 				Total:     len(repositories),
 				LastPage:  reposMetaData.LastPage,
@@ -194,8 +393,9 @@ func (consumer *Consumer) proccessAndUpdateRepoMetaData(entry Payload) {
 	if err == nil {
 		log.Println("Consumer-Recieved-Repository MetaData->", repository.Name)
 
-		err := consumer.RepositoryPersistence.UpdateRepository(
-			ConvertRepositoryResponseToRepository(repository))
+		// repositories created since the last discovery arrive by webhook
+		err := consumer.RepositoryPersistence.SaveAllRepositories([]models.Repository{
+			ConvertRepositoryResponseToRepository(repository)})
 		if err != nil {
 			fmt.Println("Consumer: Error updating repository metadat")
 			fmt.Println("Consumer: ERR:", err)
@@ -207,8 +407,120 @@ func (consumer *Consumer) proccessAndUpdateRepoMetaData(entry Payload) {
 	}
 }
 
+// proccessAndSavePullRequests saves a batch of the pull requests of a
+// repository. Batches that cannot be read are dropped, only errors saving
+// them are returned.
+func (consumer *Consumer) proccessAndSavePullRequests(entry Payload) error {
+	jsonData, _ := json.MarshalIndent(entry.Data, "", "\t")
+
+	var pullsMetaData PullRequestsMetaData
+	err := json.Unmarshal(jsonData, &pullsMetaData)
+	if err != nil {
+		log.Println("Consumer: Cannot Convert To Pull Requests MetaData")
+		return nil
+	}
+
+	log.Println("Consumer-Recieved-Pulls->", pullsMetaData.Repository, len(pullsMetaData.PullRequests))
+	pullRequests := make([]models.PullRequest, len(pullsMetaData.PullRequests))
+	for i, pr := range pullsMetaData.PullRequests {
+		pullRequests[i] = ConvertPullRequestResponseToPullRequest(pr.PullRequest, pullsMetaData.Repository, pr.Commits)
+	}
+
+	err = consumer.PullRequestPersistence.SavePullRequests(pullRequests)
+	if err != nil {
+		return fmt.Errorf("saving pull requests: %w", err)
+	}
+	return nil
+}
+
+func (consumer *Consumer) proccessAndSaveIssues(entry Payload) {
+	jsonData, _ := json.MarshalIndent(entry.Data, "", "\t")
+
+	var issuesMetaData IssuesMetaData
+	err := json.Unmarshal(jsonData, &issuesMetaData)
+	if err != nil {
+		log.Println("Consumer: Cannot Convert To Issues MetaData")
+		return
+	}
+
+	log.Println("Consumer-Recieved-Issues->", issuesMetaData.Repository, len(issuesMetaData.Issues))
+	issues := make([]models.Issue, 0, len(issuesMetaData.Issues))
+	for _, issue := range issuesMetaData.Issues {
+		if issue.PullRequest != nil {
+			continue
+		}
+		issues = append(issues, ConvertIssueResponseToIssue(issue, issuesMetaData.Repository))
+	}
+
+	err = consumer.IssuePersistence.SaveIssues(issues)
+	if err != nil {
+		fmt.Println("Consumer: Error saving issues of ", issuesMetaData.Repository)
+		fmt.Println("Consumer: ERR:", err)
+	}
+}
+
+func (consumer *Consumer) proccessAndSaveReleases(entry Payload) {
+	jsonData, _ := json.MarshalIndent(entry.Data, "", "\t")
+
+	var releasesMetaData ReleasesMetaData
+	err := json.Unmarshal(jsonData, &releasesMetaData)
+	if err != nil {
+		log.Println("Consumer: Cannot Convert To Releases MetaData")
+		return
+	}
+
+	log.Println("Consumer-Recieved-Releases->", releasesMetaData.Repository, len(releasesMetaData.Releases), len(releasesMetaData.Tags))
+	tagSHAs := make(map[string]string, len(releasesMetaData.Tags))
+	tags := make([]models.Tag, len(releasesMetaData.Tags))
+	for i, tag := range releasesMetaData.Tags {
+		tagSHAs[tag.Name] = tag.Commit.Sha
+		tags[i] = models.Tag{RepositoryName: releasesMetaData.Repository, Name: tag.Name, SHA: tag.Commit.Sha}
+	}
+
+	err = consumer.ReleasePersistence.SaveTags(tags)
+	if err != nil {
+		fmt.Println("Consumer: Error saving tags of ", releasesMetaData.Repository)
+		fmt.Println("Consumer: ERR:", err)
+		return
+	}
+
+	releases := make([]models.Release, len(releasesMetaData.Releases))
+	for i, release := range releasesMetaData.Releases {
+		releases[i] = ConvertReleaseResponseToRelease(release, releasesMetaData.Repository, tagSHAs)
+	}
+
+	err = consumer.ReleasePersistence.SaveReleases(releases)
+	if err != nil {
+		fmt.Println("Consumer: Error saving releases of ", releasesMetaData.Repository)
+		fmt.Println("Consumer: ERR:", err)
+	}
+}
+
+func (consumer *Consumer) proccessAndSaveWorkflowRuns(entry Payload) {
+	jsonData, _ := json.MarshalIndent(entry.Data, "", "\t")
+
+	var runsMetaData WorkflowRunsMetaData
+	err := json.Unmarshal(jsonData, &runsMetaData)
+	if err != nil {
+		log.Println("Consumer: Cannot Convert To Workflow Runs MetaData")
+		return
+	}
+
+	log.Println("Consumer-Recieved-Workflow-Runs->", runsMetaData.Repository, len(runsMetaData.WorkflowRuns))
+	runs := make([]models.WorkflowRun, len(runsMetaData.WorkflowRuns))
+	for i, run := range runsMetaData.WorkflowRuns {
+		runs[i] = ConvertWorkflowRunResponseToWorkflowRun(run, runsMetaData.Repository)
+	}
+
+	err = consumer.WorkflowRunPersistence.SaveWorkflowRuns(runs)
+	if err != nil {
+		fmt.Println("Consumer: Error saving workflow runs of ", runsMetaData.Repository)
+		fmt.Println("Consumer: ERR:", err)
+	}
+}
+
 func ConvertCommitResponseToCommit(response models.CommitResponse, repositoryName string) models.Commit {
-	return models.Commit{
+	commit := models.Commit{
 		SHA:            response.Sha,
 		URL:            response.URL,
 		Message:        response.Commit.Message,
@@ -217,17 +529,162 @@ func ConvertCommitResponseToCommit(response models.CommitResponse, repositoryNam
 		CreatedAt:      time.Now(),
 		UpdatedAt:      time.Now(),
 		RepositoryName: repositoryName,
+		Bot:            isBotCommit(response),
+	}
+	for _, parent := range response.Parents {
+		commit.Parents = append(commit.Parents, parent.Sha)
+	}
+	return commit
+}
+
+// isBotCommit reports whether a commit was authored by a bot, a GitHub App
+// account or one whose login or name ends with [bot].
+func isBotCommit(response models.CommitResponse) bool {
+	return response.Author.Type == "Bot" || strings.HasSuffix(response.Author.Login, "[bot]") ||
+		strings.HasSuffix(response.Commit.Author.Name, "[bot]")
+}
+
+// ConvertReleaseResponseToRelease maps a GitHub release to the stored one.
+// Its target SHA is the commit its tag points to; target_commitish is only
+// used when it is a SHA itself, as it usually names a branch.
+func ConvertReleaseResponseToRelease(response models.ReleaseResponse, repositoryName string, tagSHAs map[string]string) models.Release {
+	targetSHA := tagSHAs[response.TagName]
+	if targetSHA == "" && shaPattern.MatchString(response.TargetCommitish) {
+		targetSHA = response.TargetCommitish
+	}
+
+	return models.Release{
+		RepositoryName: repositoryName,
+		TagName:        response.TagName,
+		Name:           response.Name,
+		TargetSHA:      targetSHA,
+		Draft:          response.Draft,
+		Prerelease:     response.Prerelease,
+		Author:         response.Author.Login,
+		URL:            response.HTMLURL,
+		CreatedAt:      response.CreatedAt,
+		PublishedAt:    response.PublishedAt,
 	}
 }
 
+// ConvertWorkflowRunResponseToWorkflowRun maps a GitHub Actions workflow run
+// to the stored one. The duration of a completed run is the time from when
+// its latest attempt started to its last update.
+func ConvertWorkflowRunResponseToWorkflowRun(response models.WorkflowRunResponse, repositoryName string) models.WorkflowRun {
+	run := models.WorkflowRun{
+		ID:             response.ID,
+		RepositoryName: repositoryName,
+		WorkflowID:     response.WorkflowID,
+		WorkflowName:   response.Name,
+		HeadSHA:        response.HeadSha,
+		HeadBranch:     response.HeadBranch,
+		Event:          response.Event,
+		Status:         response.Status,
+		Conclusion:     response.Conclusion,
+		RunAttempt:     response.RunAttempt,
+		URL:            response.HTMLURL,
+		CreatedAt:      response.CreatedAt,
+		UpdatedAt:      response.UpdatedAt,
+		RunStartedAt:   response.RunStartedAt,
+	}
+	if run.RunStartedAt.IsZero() {
+		run.RunStartedAt = run.CreatedAt
+	}
+	if run.Status == constants.WORKFLOW_RUN_STATUS_COMPLETED && run.UpdatedAt.After(run.RunStartedAt) {
+		run.DurationSeconds = int(run.UpdatedAt.Sub(run.RunStartedAt).Seconds())
+	}
+	return run
+}
+
+var shaPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+func ConvertCommitResponseToCommitDetails(response models.CommitResponse, repositoryName string) models.CommitDetails {
+	details := models.CommitDetails{RepositoryName: repositoryName, SHA: response.Sha}
+	if response.Stats != nil {
+		details.Stats = models.CommitStats{
+			Additions: response.Stats.Additions,
+			Deletions: response.Stats.Deletions,
+			Total:     response.Stats.Total,
+		}
+	}
+	for _, file := range response.Files {
+		details.Files = append(details.Files, models.CommitFile{
+			Path:      file.Filename,
+			Status:    file.Status,
+			Additions: file.Additions,
+			Deletions: file.Deletions,
+		})
+	}
+	return details
+}
+
+// ConvertPullRequestResponseToPullRequest maps a GitHub pull request to the
+// stored one. Merged pull requests, which GitHub reports as closed, get the
+// merged state.
+func ConvertPullRequestResponseToPullRequest(response models.PullRequestResponse, repositoryName string, commitSHAs []string) models.PullRequest {
+	state := response.State
+	if response.MergedAt != nil {
+		state = constants.PULL_REQUEST_STATE_MERGED
+	}
+
+	return models.PullRequest{
+		RepositoryName: repositoryName,
+		Number:         response.Number,
+		Title:          response.Title,
+		Author:         response.User.Login,
+		State:          state,
+		HeadRef:        response.Head.Ref,
+		BaseRef:        response.Base.Ref,
+		URL:            response.HTMLURL,
+		CreatedAt:      response.CreatedAt,
+		UpdatedAt:      response.UpdatedAt,
+		MergedAt:       response.MergedAt,
+		ClosedAt:       response.ClosedAt,
+		CommitSHAs:     commitSHAs,
+	}
+}
+
+func ConvertIssueResponseToIssue(response models.IssueResponse, repositoryName string) models.Issue {
+	issue := models.Issue{
+		RepositoryName: repositoryName,
+		Number:         response.Number,
+		Title:          response.Title,
+		Author:         response.User.Login,
+		State:          response.State,
+		Comments:       response.Comments,
+		URL:            response.HTMLURL,
+		Labels:         make([]string, 0, len(response.Labels)),
+		Assignees:      make([]string, 0, len(response.Assignees)),
+		CreatedAt:      response.CreatedAt,
+		UpdatedAt:      response.UpdatedAt,
+		ClosedAt:       response.ClosedAt,
+	}
+	for _, label := range response.Labels {
+		issue.Labels = append(issue.Labels, label.Name)
+	}
+	for _, assignee := range response.Assignees {
+		issue.Assignees = append(issue.Assignees, assignee.Login)
+	}
+	return issue
+}
+
 func ConvertRepositoryResponseToRepository(response models.RepositoryResponse) models.Repository {
 	description := ""
 	if response.Description != nil {
 		description = response.Description.(string)
 	}
 
+	provider := response.Provider
+	if provider == "" {
+		provider = constants.PROVIDER_GITHUB
+	}
+
 	return models.Repository{
 		Name:            response.Name,
+		Owner:           response.Owner.Login,
+		FullName:        response.FullName,
+		OwnerType:       response.Owner.Type,
+		Provider:        provider,
 		Description:     description,
 		URL:             response.HTMLURL,
 		Language:        response.Language,
@@ -237,5 +694,6 @@ func ConvertRepositoryResponseToRepository(response models.RepositoryResponse) m
 		WatchersCount:   response.WatchersCount,
 		CreatedAt:       response.CreatedAt,
 		UpdatedAt:       response.UpdatedAt,
+		PushedAt:        response.PushedAt,
 	}
 }
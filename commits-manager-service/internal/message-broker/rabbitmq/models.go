@@ -6,13 +6,101 @@ import (
 )
 
 type CommitMetaData struct {
-	LastPage int
+	Owner string
+	// Repository is the full name (owner/name) of the repository.
 	Repository string
+	// Branch is the branch the commits were listed from.
+	Branch    string
+	FetchTime time.Time
+	Commits   []models.CommitResponse
+}
+
+// BranchLinksMetaData are stored commits of a repository reachable from a
+// branch, sent at the end of the first sync of the branch.
+type BranchLinksMetaData struct {
+	// Repository is the full name (owner/name) of the repository.
+	Repository string
+	Branch     string
 	FetchTime  time.Time
+	Shas       []string
+}
+
+// CommitDetailsMetaData carries single commit responses, which include the
+// stats and changed files, of a repository, and the commits whose details
+// cannot be fetched.
+type CommitDetailsMetaData struct {
+	Repository string
 	Commits    []models.CommitResponse
+	Failures   []CommitDetailsFailure
+}
+
+// CommitDetailsFailure is a commit whose details fetch failed for good.
+type CommitDetailsFailure struct {
+	Sha        string
+	StatusCode int
+	Message    string
+}
+
+// CommitsFetchOutcome is how fetching the commits of a repository went.
+type CommitsFetchOutcome struct {
+	Repository string
+	Outcome    string
+	StatusCode int
+	Message    string
+	Total      int
+	FetchTime  time.Time
+}
+
+// BackfillProgress is the progress of a backfill job run by the commits
+// monitor.
+type BackfillProgress struct {
+	JobID             int64
+	Status            string
+	RepositoriesTotal int
+	RepositoriesDone  int
+	Commits           int
+	Error             string
+}
+
+// PullRequestsMetaData carries pull requests of a repository updated since
+// the last fetch.
+type PullRequestsMetaData struct {
+	Repository   string
+	FetchTime    time.Time
+	PullRequests []PullRequestMetaData
+}
+
+// PullRequestMetaData is a pull request with the SHAs of its commits.
+type PullRequestMetaData struct {
+	PullRequest models.PullRequestResponse
+	Commits     []string
+}
+
+// IssuesMetaData carries issues of a repository updated since the last
+// fetch.
+type IssuesMetaData struct {
+	Repository string
+	FetchTime  time.Time
+	Issues     []models.IssueResponse
+}
+
+// ReleasesMetaData carries the releases and tags of a repository.
+type ReleasesMetaData struct {
+	Repository string
+	FetchTime  time.Time
+	Releases   []models.ReleaseResponse
+	Tags       []models.TagResponse
+}
+
+// WorkflowRunsMetaData carries GitHub Actions workflow runs of a repository.
+type WorkflowRunsMetaData struct {
+	Repository   string
+	FetchTime    time.Time
+	WorkflowRuns []models.WorkflowRunResponse
 }
 
 type ReposMetaData struct {
+	Owner     string
 	LastPage  int
 	FetchTime time.Time
 	Repos     []models.RepositoryResponse
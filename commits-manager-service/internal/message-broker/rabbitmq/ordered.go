@@ -0,0 +1,46 @@
+package event
+
+import (
+	"sync"
+)
+
+// orderedJobs runs jobs one at a time per key, in the order they were pushed,
+// while jobs of different keys run concurrently.
+type orderedJobs struct {
+	mu      sync.Mutex
+	pending map[string][]func()
+}
+
+func newOrderedJobs() *orderedJobs {
+	return &orderedJobs{pending: map[string][]func(){}}
+}
+
+// push queues job after the jobs of key pushed before it.
+func (o *orderedJobs) push(key string, job func()) {
+	o.mu.Lock()
+	jobs, running := o.pending[key]
+	o.pending[key] = append(jobs, job)
+	o.mu.Unlock()
+
+	if !running {
+		go o.drain(key)
+	}
+}
+
+// drain runs the jobs of key until none is left.
+func (o *orderedJobs) drain(key string) {
+	for {
+		o.mu.Lock()
+		jobs := o.pending[key]
+		if len(jobs) == 0 {
+			delete(o.pending, key)
+			o.mu.Unlock()
+			return
+		}
+		job := jobs[0]
+		o.pending[key] = jobs[1:]
+		o.mu.Unlock()
+
+		job()
+	}
+}
@@ -0,0 +1,52 @@
+package event
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrderedJobs(t *testing.T) {
+	jobs := newOrderedJobs()
+
+	var mu sync.Mutex
+	ran := map[string][]int{}
+	var wg sync.WaitGroup
+	release := make(chan struct{})
+	for i := 0; i < 50; i++ {
+		for _, key := range []string{"acme/api", "acme/web"} {
+			i, key := i, key
+			wg.Add(1)
+			jobs.push(key, func() {
+				defer wg.Done()
+				if i == 0 && key == "acme/api" {
+					// a slow job holds back the later jobs of its key only
+					<-release
+				}
+				mu.Lock()
+				ran[key] = append(ran[key], i)
+				mu.Unlock()
+			})
+		}
+	}
+
+	require.Eventually(t, func() bool {
+		mu.Lock()
+		defer mu.Unlock()
+		return len(ran["acme/web"]) == 50
+	}, 5*time.Second, time.Millisecond)
+	mu.Lock()
+	require.Empty(t, ran["acme/api"])
+	mu.Unlock()
+
+	close(release)
+	wg.Wait()
+	for _, key := range []string{"acme/api", "acme/web"} {
+		for i, n := range ran[key] {
+			require.Equal(t, i, n, key)
+		}
+	}
+	require.Empty(t, jobs.pending)
+}
@@ -0,0 +1,77 @@
+package actions
+
+import (
+	"commits-manager-service/internal/constants"
+	"commits-manager-service/internal/constants/models"
+	"commits-manager-service/internal/storage/db"
+	"math"
+	"sort"
+	"time"
+)
+
+type ActionsManagerService struct {
+	WorkflowRunPersistence db.WorkflowRunRepository
+}
+
+func NewActionsManagerService(workflowRunPersistence db.WorkflowRunRepository) ActionsManagerService {
+	return ActionsManagerService{WorkflowRunPersistence: workflowRunPersistence}
+}
+
+// GetWorkflowStats returns the success rate and median duration of the runs
+// of each workflow of a repository created between startDate and endDate,
+// most run workflows first. Only runs that succeeded or failed count.
+func (as ActionsManagerService) GetWorkflowStats(repoName string, startDate, endDate time.Time) ([]*models.WorkflowStats, error) {
+	runs, err := as.WorkflowRunPersistence.GetCompletedWorkflowRuns(repoName, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	byWorkflow := make(map[int64]*models.WorkflowStats)
+	durations := make(map[int64][]int)
+	for _, run := range runs {
+		switch run.Conclusion {
+		case constants.WORKFLOW_RUN_CONCLUSION_SUCCESS, constants.WORKFLOW_RUN_CONCLUSION_FAILURE,
+			constants.WORKFLOW_RUN_CONCLUSION_TIMED_OUT, constants.WORKFLOW_RUN_CONCLUSION_STARTUP:
+		default:
+			continue
+		}
+
+		stats, ok := byWorkflow[run.WorkflowID]
+		if !ok {
+			stats = &models.WorkflowStats{WorkflowID: run.WorkflowID}
+			byWorkflow[run.WorkflowID] = stats
+		}
+		// runs are oldest first, so a renamed workflow shows its latest name
+		stats.WorkflowName = run.WorkflowName
+		stats.Runs++
+		if run.Conclusion == constants.WORKFLOW_RUN_CONCLUSION_SUCCESS {
+			stats.Successes++
+		}
+		durations[run.WorkflowID] = append(durations[run.WorkflowID], run.DurationSeconds)
+	}
+
+	workflowStats := make([]*models.WorkflowStats, 0, len(byWorkflow))
+	for id, stats := range byWorkflow {
+		stats.SuccessRate = math.Round(float64(stats.Successes)/float64(stats.Runs)*10000) / 10000
+		stats.MedianDurationSeconds = median(durations[id])
+		workflowStats = append(workflowStats, stats)
+	}
+	sort.Slice(workflowStats, func(i, j int) bool {
+		if workflowStats[i].Runs != workflowStats[j].Runs {
+			return workflowStats[i].Runs > workflowStats[j].Runs
+		}
+		return workflowStats[i].WorkflowName < workflowStats[j].WorkflowName
+	})
+	return workflowStats, nil
+}
+
+// median returns the median of values, the mean of the middle two for an
+// even count.
+func median(values []int) float64 {
+	sort.Ints(values)
+	middle := len(values) / 2
+	if len(values)%2 == 1 {
+		return float64(values[middle])
+	}
+	return float64(values[middle-1]+values[middle]) / 2
+}
@@ -0,0 +1,322 @@
+package archives
+
+import (
+	"bufio"
+	"commits-manager-service/internal/constants"
+	"commits-manager-service/internal/constants/models"
+	event "commits-manager-service/internal/message-broker/rabbitmq"
+	"commits-manager-service/internal/storage/db"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ArchiveImportService loads the repositories and commits of the tracked
+// owners from GH Archive hourly dumps (https://www.gharchive.org), without
+// using any API quota. Files are imported oldest first and recorded once
+// done, so an interrupted import resumes with the first file not recorded.
+//
+// Archived data only fills gaps: stored repositories and commits are kept as
+// they are. Push events carry at most 20 commits each, without parents or
+// author dates; the time of the push stands for the author date. The
+// commits are not linked to their branch, so the branch watermarks of the
+// commits monitor are left alone and it still fetches the full history.
+type ArchiveImportService struct {
+	ArchiveImportPersistence db.ArchiveImportRepository
+	RepositoryPersistence    db.GitReposRepository
+	CommitPersistence        db.CommitRepository
+
+	// owners are the lower cased logins of the tracked owners.
+	owners map[string]bool
+}
+
+func NewArchiveImportService(
+	archiveImportPersistence db.ArchiveImportRepository,
+	repositoryPersistence db.GitReposRepository,
+	commitPersistence db.CommitRepository,
+	owners []string,
+) ArchiveImportService {
+	service := ArchiveImportService{
+		ArchiveImportPersistence: archiveImportPersistence,
+		RepositoryPersistence:    repositoryPersistence,
+		CommitPersistence:        commitPersistence,
+		owners:                   map[string]bool{},
+	}
+	for _, owner := range owners {
+		service.owners[strings.ToLower(owner)] = true
+	}
+	return service
+}
+
+// ImportSummary sums up an import run.
+type ImportSummary struct {
+	Files        int
+	Skipped      int
+	Events       int
+	Repositories int
+	Commits      int
+}
+
+// Import imports the archive files in chronological order, skipping the
+// ones imported before, and logs the progress after each file.
+func (as ArchiveImportService) Import(paths []string) (ImportSummary, error) {
+	paths = SortArchives(paths)
+
+	var summary ImportSummary
+	started := time.Now()
+	for i, path := range paths {
+		fileName := filepath.Base(path)
+		imported, err := as.ArchiveImportPersistence.IsArchiveImported(fileName)
+		if err != nil {
+			return summary, err
+		}
+		if imported {
+			summary.Skipped++
+			log.Printf("Importer: [%d/%d] %s imported before, skipped\n", i+1, len(paths), fileName)
+			continue
+		}
+
+		fileStarted := time.Now()
+		archiveImport, err := as.importFile(path)
+		if err != nil {
+			return summary, fmt.Errorf("Importer: %s: %w", fileName, err)
+		}
+		if err := as.ArchiveImportPersistence.SaveArchiveImport(archiveImport); err != nil {
+			return summary, err
+		}
+
+		summary.Files++
+		summary.Events += archiveImport.Events
+		summary.Repositories += archiveImport.Repositories
+		summary.Commits += archiveImport.Commits
+
+		// the remaining files are expected to take as long as the ones
+		// imported in this run so far
+		remaining := time.Duration(0)
+		if left := len(paths) - i - 1; left > 0 {
+			remaining = time.Since(started) / time.Duration(summary.Files) * time.Duration(left)
+		}
+		log.Printf("Importer: [%d/%d] %s: %d events, %d new repositories, %d new commits in %s, about %s left\n",
+			i+1, len(paths), fileName, archiveImport.Events, archiveImport.Repositories, archiveImport.Commits,
+			time.Since(fileStarted).Round(time.Millisecond), remaining.Round(time.Second))
+	}
+	return summary, nil
+}
+
+// archiveEvent is an event of a GH Archive dump, as listed by the GitHub
+// events API since 2015.
+type archiveEvent struct {
+	Type string `json:"type"`
+	Repo struct {
+		Name string `json:"name"`
+	} `json:"repo"`
+	Org *struct {
+		Login string `json:"login"`
+	} `json:"org"`
+	CreatedAt time.Time       `json:"created_at"`
+	Payload   json.RawMessage `json:"payload"`
+}
+
+type pushPayload struct {
+	Commits []struct {
+		Sha    string `json:"sha"`
+		Author struct {
+			Name  string `json:"name"`
+			Email string `json:"email"`
+		} `json:"author"`
+		Message string `json:"message"`
+		URL     string `json:"url"`
+	} `json:"commits"`
+}
+
+type createPayload struct {
+	RefType     string `json:"ref_type"`
+	Description string `json:"description"`
+}
+
+type repositoryPayload struct {
+	Action     string                     `json:"action"`
+	Repository *models.RepositoryResponse `json:"repository"`
+}
+
+// importFile loads the repositories and commits of the tracked owners from
+// one archive file. Reading it again after a failure is harmless.
+func (as ArchiveImportService) importFile(path string) (models.ArchiveImport, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return models.ArchiveImport{}, err
+	}
+	defer file.Close()
+
+	reader, err := gzip.NewReader(bufio.NewReader(file))
+	if err != nil {
+		return models.ArchiveImport{}, err
+	}
+	defer reader.Close()
+
+	archiveImport := models.ArchiveImport{FileName: filepath.Base(path)}
+	repositories := map[string]models.Repository{}
+	var repositoryNames []string
+	var commits []models.Commit
+
+	addRepository := func(repository models.Repository) {
+		if _, ok := repositories[repository.FullName]; !ok {
+			repositories[repository.FullName] = repository
+			repositoryNames = append(repositoryNames, repository.FullName)
+		}
+	}
+
+	decoder := json.NewDecoder(reader)
+	for {
+		var archived archiveEvent
+		err := decoder.Decode(&archived)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return archiveImport, err
+		}
+		archiveImport.Events++
+
+		if !as.isTracked(archived.Repo.Name) {
+			continue
+		}
+
+		switch archived.Type {
+		case constants.ARCHIVE_PUSH_EVENT:
+			var payload pushPayload
+			if err := json.Unmarshal(archived.Payload, &payload); err != nil {
+				log.Println("Importer: Cannot Convert To Push Event of ", archived.Repo.Name)
+				continue
+			}
+			addRepository(archived.repository(""))
+			for _, pushed := range payload.Commits {
+				var response models.CommitResponse
+				response.Sha = pushed.Sha
+				response.URL = pushed.URL
+				response.HTMLURL = "https://github.com/" + archived.Repo.Name + "/commit/" + pushed.Sha
+				response.Commit.Message = pushed.Message
+				response.Commit.Author.Name = pushed.Author.Name
+				response.Commit.Author.Email = pushed.Author.Email
+				response.Commit.Author.Date = archived.CreatedAt
+				commits = append(commits, event.ConvertCommitResponseToCommit(response, archived.Repo.Name))
+			}
+
+		case constants.ARCHIVE_CREATE_EVENT:
+			var payload createPayload
+			if err := json.Unmarshal(archived.Payload, &payload); err != nil {
+				log.Println("Importer: Cannot Convert To Create Event of ", archived.Repo.Name)
+				continue
+			}
+			if payload.RefType == "repository" {
+				addRepository(archived.repository(payload.Description))
+			}
+
+		case constants.ARCHIVE_REPOSITORY_EVENT:
+			var payload repositoryPayload
+			if err := json.Unmarshal(archived.Payload, &payload); err != nil {
+				log.Println("Importer: Cannot Convert To Repository Event of ", archived.Repo.Name)
+				continue
+			}
+			if payload.Action == "deleted" {
+				continue
+			}
+			if payload.Repository != nil && payload.Repository.FullName == archived.Repo.Name {
+				addRepository(event.ConvertRepositoryResponseToRepository(*payload.Repository))
+			} else {
+				addRepository(archived.repository(""))
+			}
+		}
+	}
+
+	// repositories go first, commits reference them
+	missing := make([]models.Repository, 0, len(repositoryNames))
+	for _, name := range repositoryNames {
+		missing = append(missing, repositories[name])
+	}
+	archiveImport.Repositories, err = as.RepositoryPersistence.InsertMissingRepositories(missing)
+	if err != nil {
+		return archiveImport, err
+	}
+	archiveImport.Commits, err = as.CommitPersistence.InsertMissingCommits(commits)
+	if err != nil {
+		return archiveImport, err
+	}
+
+	archiveImport.ImportedAt = time.Now().UTC()
+	return archiveImport, nil
+}
+
+// isTracked reports whether the repository, given by its full name, belongs
+// to a tracked owner.
+func (as ArchiveImportService) isTracked(fullName string) bool {
+	owner, _, ok := strings.Cut(fullName, "/")
+	return ok && as.owners[strings.ToLower(owner)]
+}
+
+// repository returns the repository of an event with what the event tells:
+// its name, its owner, whether that is an organization, and the time of the
+// event as creation time.
+func (e archiveEvent) repository(description string) models.Repository {
+	var response models.RepositoryResponse
+	response.FullName = e.Repo.Name
+	response.Owner.Login, response.Name, _ = strings.Cut(e.Repo.Name, "/")
+	response.Owner.Type = constants.OWNER_TYPE_USER
+	if e.Org != nil && strings.EqualFold(e.Org.Login, response.Owner.Login) {
+		response.Owner.Type = constants.OWNER_TYPE_ORGANIZATION
+	}
+	response.HTMLURL = "https://github.com/" + e.Repo.Name
+	if description != "" {
+		response.Description = description
+	}
+	response.CreatedAt = e.CreatedAt
+	response.UpdatedAt = e.CreatedAt
+	return event.ConvertRepositoryResponseToRepository(response)
+}
+
+// SortArchives sorts archive files chronologically. GH Archive names them
+// YYYY-MM-DD-H.json.gz with hours not padded, so that 2015-01-01-10 comes
+// after 2015-01-01-9; other names are sorted by name after them.
+func SortArchives(paths []string) []string {
+	sorted := append([]string(nil), paths...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		dayI, hourI, okI := archiveHour(sorted[i])
+		dayJ, hourJ, okJ := archiveHour(sorted[j])
+		switch {
+		case okI != okJ:
+			return okI
+		case !okI:
+			return filepath.Base(sorted[i]) < filepath.Base(sorted[j])
+		case dayI != dayJ:
+			return dayI < dayJ
+		}
+		return hourI < hourJ
+	})
+	return sorted
+}
+
+// archiveHour splits the name of an archive file into its day and hour.
+func archiveHour(path string) (string, int, bool) {
+	name := strings.TrimSuffix(filepath.Base(path), ".json.gz")
+	i := strings.LastIndex(name, "-")
+	if i < 0 {
+		return "", 0, false
+	}
+	if _, err := time.Parse("2006-01-02", name[:i]); err != nil {
+		return "", 0, false
+	}
+	hour, err := strconv.Atoi(name[i+1:])
+	if err != nil {
+		return "", 0, false
+	}
+	return name[:i], hour, true
+}
@@ -0,0 +1,221 @@
+package archives
+
+import (
+	"commits-manager-service/internal/constants"
+	"commits-manager-service/internal/constants/models"
+	"commits-manager-service/internal/storage/db"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// fixture is an hour of GH Archive events of tracked and untracked owners.
+const fixture = `{"type": "PushEvent", "repo": {"name": "acme/api"}, "org": {"login": "acme"}, "created_at": "2015-01-01T09:10:00Z",
+ "payload": {"commits": [
+  {"sha": "1111", "author": {"name": "Jane", "email": "jane@acme.io"}, "message": "Fix login", "url": "https://api.github.com/repos/acme/api/commits/1111"},
+  {"sha": "2222", "author": {"name": "John", "email": "john@acme.io"}, "message": "Add tests", "url": "https://api.github.com/repos/acme/api/commits/2222"}]}}
+{"type": "PushEvent", "repo": {"name": "someone/else"}, "created_at": "2015-01-01T09:11:00Z",
+ "payload": {"commits": [{"sha": "3333", "author": {"name": "Eve"}, "message": "Untracked", "url": "https://api.github.com/repos/someone/else/commits/3333"}]}}
+{"type": "WatchEvent", "repo": {"name": "acme/api"}, "created_at": "2015-01-01T09:12:00Z", "payload": {"action": "started"}}
+{"type": "CreateEvent", "repo": {"name": "ACME/web"}, "org": {"login": "acme"}, "created_at": "2015-01-01T09:20:00Z",
+ "payload": {"ref_type": "repository", "description": "The web site"}}
+{"type": "CreateEvent", "repo": {"name": "acme/api"}, "created_at": "2015-01-01T09:21:00Z",
+ "payload": {"ref_type": "branch", "description": "ignored"}}
+{"type": "CreateEvent", "repo": {"name": "jane/dotfiles"}, "created_at": "2015-01-01T09:22:00Z",
+ "payload": {"ref_type": "repository"}}
+{"type": "RepositoryEvent", "repo": {"name": "acme/docs"}, "created_at": "2015-01-01T09:30:00Z",
+ "payload": {"action": "created", "repository": {"name": "docs", "full_name": "acme/docs", "owner": {"login": "acme", "type": "Organization"},
+  "description": "Docs", "language": "Go", "stargazers_count": 5, "created_at": "2014-12-31T00:00:00Z"}}}
+{"type": "RepositoryEvent", "repo": {"name": "acme/legacy"}, "created_at": "2015-01-01T09:31:00Z",
+ "payload": {"action": "deleted", "repository": {"name": "legacy", "full_name": "acme/legacy"}}}
+{"type": "RepositoryEvent", "repo": {"name": "acme/renamed"}, "created_at": "2015-01-01T09:32:00Z",
+ "payload": {"action": "renamed", "repository": {"name": "old", "full_name": "acme/old"}}}
+`
+
+// fakeArchiveImports records the imported files in memory.
+type fakeArchiveImports struct {
+	imported map[string]models.ArchiveImport
+}
+
+func (f *fakeArchiveImports) IsArchiveImported(fileName string) (bool, error) {
+	_, ok := f.imported[fileName]
+	return ok, nil
+}
+
+func (f *fakeArchiveImports) SaveArchiveImport(archiveImport models.ArchiveImport) error {
+	f.imported[archiveImport.FileName] = archiveImport
+	return nil
+}
+
+// fakeRepositories records the repositories inserted by the importer.
+type fakeRepositories struct {
+	db.GitReposRepository
+	inserted []models.Repository
+}
+
+func (f *fakeRepositories) InsertMissingRepositories(repos []models.Repository) (int, error) {
+	f.inserted = append(f.inserted, repos...)
+	return len(repos), nil
+}
+
+// fakeCommits records the commits inserted by the importer.
+type fakeCommits struct {
+	db.CommitRepository
+	inserted []models.Commit
+}
+
+func (f *fakeCommits) InsertMissingCommits(commits []models.Commit) (int, error) {
+	f.inserted = append(f.inserted, commits...)
+	return len(commits), nil
+}
+
+func writeArchive(t *testing.T, path string, content string) {
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+	writer := gzip.NewWriter(file)
+	_, err = writer.Write([]byte(content))
+	require.NoError(t, err)
+	require.NoError(t, writer.Close())
+}
+
+func newTestService(owners ...string) (ArchiveImportService, *fakeArchiveImports, *fakeRepositories, *fakeCommits) {
+	archiveImports := &fakeArchiveImports{imported: map[string]models.ArchiveImport{}}
+	repositories := &fakeRepositories{}
+	commits := &fakeCommits{}
+	return NewArchiveImportService(archiveImports, repositories, commits, owners), archiveImports, repositories, commits
+}
+
+func TestImportFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "2015-01-01-9.json.gz")
+	writeArchive(t, path, fixture)
+	service, _, repositories, commits := newTestService("Acme")
+
+	archiveImport, err := service.importFile(path)
+	require.NoError(t, err)
+	require.Equal(t, "2015-01-01-9.json.gz", archiveImport.FileName)
+	require.Equal(t, 9, archiveImport.Events)
+	require.Equal(t, 4, archiveImport.Repositories)
+	require.Equal(t, 2, archiveImport.Commits)
+
+	// repositories in the order their first event was read, deleted ones
+	// and other owners left out
+	names := make([]string, 0, len(repositories.inserted))
+	for _, repository := range repositories.inserted {
+		names = append(names, repository.FullName)
+	}
+	require.Equal(t, []string{"acme/api", "ACME/web", "acme/docs", "acme/renamed"}, names)
+
+	pushed := repositories.inserted[0]
+	require.Equal(t, "acme", pushed.Owner)
+	require.Equal(t, "api", pushed.Name)
+	require.Equal(t, constants.OWNER_TYPE_ORGANIZATION, pushed.OwnerType)
+	require.Equal(t, constants.PROVIDER_GITHUB, pushed.Provider)
+	require.Equal(t, "2015-01-01T09:10:00Z", pushed.CreatedAt.Format(time.RFC3339))
+
+	created := repositories.inserted[1]
+	require.Equal(t, "The web site", created.Description)
+	require.Equal(t, constants.OWNER_TYPE_ORGANIZATION, created.OwnerType)
+
+	// a repository event carries the full repository
+	documented := repositories.inserted[2]
+	require.Equal(t, "Docs", documented.Description)
+	require.Equal(t, "Go", documented.Language)
+	require.Equal(t, 5, documented.StarsCount)
+	require.Equal(t, "2014-12-31T00:00:00Z", documented.CreatedAt.Format(time.RFC3339))
+
+	// unless it does not match the event, then only the name is known
+	renamed := repositories.inserted[3]
+	require.Equal(t, "renamed", renamed.Name)
+	require.Equal(t, constants.OWNER_TYPE_USER, renamed.OwnerType)
+
+	// pushed commits take the time of the push for author date
+	require.Len(t, commits.inserted, 2)
+	commit := commits.inserted[0]
+	require.Equal(t, "1111", commit.SHA)
+	require.Equal(t, "acme/api", commit.RepositoryName)
+	require.Equal(t, "Fix login", commit.Message)
+	require.Equal(t, "Jane", commit.AuthorName)
+	require.Equal(t, "https://api.github.com/repos/acme/api/commits/1111", commit.URL)
+	require.Equal(t, "2015-01-01T09:10:00Z", commit.AuthorDate.Format(time.RFC3339))
+	require.Empty(t, commit.Parents)
+	require.Equal(t, "2222", commits.inserted[1].SHA)
+}
+
+func TestImportFileNotGzipped(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "2015-01-01-9.json.gz")
+	require.NoError(t, os.WriteFile(path, []byte(fixture), 0o644))
+	service, _, _, _ := newTestService("acme")
+
+	_, err := service.importFile(path)
+	require.Error(t, err)
+}
+
+func TestImportResumes(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"2015-01-01-9.json.gz", "2015-01-01-10.json.gz", "2015-01-01-11.json.gz"} {
+		writeArchive(t, filepath.Join(dir, name), strings.ReplaceAll(fixture, "1111", name))
+	}
+	service, archiveImports, _, commits := newTestService("acme")
+	archiveImports.imported["2015-01-01-9.json.gz"] = models.ArchiveImport{FileName: "2015-01-01-9.json.gz"}
+
+	summary, err := service.Import([]string{
+		filepath.Join(dir, "2015-01-01-11.json.gz"),
+		filepath.Join(dir, "2015-01-01-9.json.gz"),
+		filepath.Join(dir, "2015-01-01-10.json.gz"),
+	})
+	require.NoError(t, err)
+	require.Equal(t, ImportSummary{Files: 2, Skipped: 1, Events: 18, Repositories: 8, Commits: 4}, summary)
+	require.Len(t, archiveImports.imported, 3)
+	require.Equal(t, 2, archiveImports.imported["2015-01-01-11.json.gz"].Commits)
+
+	// the hour imported before is skipped, the others are read in order
+	require.Equal(t, "2015-01-01-10.json.gz", commits.inserted[0].SHA)
+	require.Equal(t, "2015-01-01-11.json.gz", commits.inserted[2].SHA)
+
+	// a second run has nothing left to import
+	summary, err = service.Import([]string{filepath.Join(dir, "2015-01-01-10.json.gz")})
+	require.NoError(t, err)
+	require.Equal(t, ImportSummary{Skipped: 1}, summary)
+}
+
+func TestSortArchives(t *testing.T) {
+	tests := []struct {
+		name  string
+		paths []string
+		want  []string
+	}{
+		{
+			name:  "unpadded hours",
+			paths: []string{"2015-01-01-10.json.gz", "2015-01-01-9.json.gz", "2015-01-01-0.json.gz", "2015-01-01-23.json.gz"},
+			want:  []string{"2015-01-01-0.json.gz", "2015-01-01-9.json.gz", "2015-01-01-10.json.gz", "2015-01-01-23.json.gz"},
+		},
+		{
+			name:  "days before hours",
+			paths: []string{"2015-01-02-1.json.gz", "2014-12-31-22.json.gz", "2015-01-01-15.json.gz"},
+			want:  []string{"2014-12-31-22.json.gz", "2015-01-01-15.json.gz", "2015-01-02-1.json.gz"},
+		},
+		{
+			name:  "directories are ignored",
+			paths: []string{"/b/2015-01-01-2.json.gz", "/a/2015-01-01-12.json.gz"},
+			want:  []string{"/b/2015-01-01-2.json.gz", "/a/2015-01-01-12.json.gz"},
+		},
+		{
+			name:  "other names last, by name",
+			paths: []string{"notes.json.gz", "2015-01-01-3.json.gz", "2015-13-01-1.json.gz", "backup.json.gz"},
+			want:  []string{"2015-01-01-3.json.gz", "2015-13-01-1.json.gz", "backup.json.gz", "notes.json.gz"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paths := append([]string(nil), tt.paths...)
+			require.Equal(t, tt.want, SortArchives(paths))
+			require.Equal(t, tt.paths, paths)
+		})
+	}
+}
@@ -0,0 +1,61 @@
+package backfills
+
+import (
+	"commits-manager-service/internal/constants/models"
+	"commits-manager-service/internal/storage/db"
+	"errors"
+	"time"
+)
+
+// ErrInvalidDateRange is returned when a backfill job does not start before
+// it ends.
+var ErrInvalidDateRange = errors.New("startDate must be before endDate")
+
+// ErrRepositoryNotFound is returned when a backfill job is created for a
+// repository that is not tracked.
+var ErrRepositoryNotFound = errors.New("repository not found")
+
+type BackfillsManagerService struct {
+	BackfillJobPersistence db.BackfillJobRepository
+	RepositoryPersistence  db.GitReposRepository
+}
+
+func NewBackfillsManagerService(backfillJobPersistence db.BackfillJobRepository, repositoryPersistence db.GitReposRepository) BackfillsManagerService {
+	return BackfillsManagerService{
+		BackfillJobPersistence: backfillJobPersistence,
+		RepositoryPersistence:  repositoryPersistence,
+	}
+}
+
+// CreateBackfillJob queues a backfill job of the commits of a repository, or
+// of all repositories when repoName is empty, authored between startDate and
+// endDate.
+func (bs BackfillsManagerService) CreateBackfillJob(repoName string, startDate, endDate time.Time, priority int) (*models.BackfillJob, error) {
+	if !startDate.Before(endDate) {
+		return nil, ErrInvalidDateRange
+	}
+	if repoName != "" {
+		fullNames, err := bs.RepositoryPersistence.GetRepositoryFullNames(repoName)
+		if err != nil {
+			return nil, err
+		}
+		if len(fullNames) != 1 || fullNames[0] != repoName {
+			return nil, ErrRepositoryNotFound
+		}
+	}
+
+	return bs.BackfillJobPersistence.InsertBackfillJob(models.BackfillJob{
+		RepositoryName: repoName,
+		StartDate:      startDate,
+		EndDate:        endDate,
+		Priority:       priority,
+	})
+}
+
+func (bs BackfillsManagerService) GetBackfillJobs(status string, limit, offset int) ([]*models.BackfillJob, error) {
+	return bs.BackfillJobPersistence.GetBackfillJobs(status, limit, offset)
+}
+
+func (bs BackfillsManagerService) GetBackfillJob(id int64) (*models.BackfillJob, error) {
+	return bs.BackfillJobPersistence.GetBackfillJobByID(id)
+}
@@ -1,21 +1,62 @@
 package commits
 
 import (
+	"commits-manager-service/internal/constants"
 	"commits-manager-service/internal/constants/models"
 	"commits-manager-service/internal/storage/db"
 	"time"
 )
 
 type CommitsManagerService struct {
-	CommitsPersistence db.CommitRepository
+	CommitsPersistence     db.CommitRepository
+	WorkflowRunPersistence db.WorkflowRunRepository
 }
 
-func NewCommitsManagerService(commitsPersistence db.CommitRepository) CommitsManagerService {
-	return CommitsManagerService{CommitsPersistence: commitsPersistence}
+func NewCommitsManagerService(commitsPersistence db.CommitRepository, workflowRunPersistence db.WorkflowRunRepository) CommitsManagerService {
+	return CommitsManagerService{CommitsPersistence: commitsPersistence, WorkflowRunPersistence: workflowRunPersistence}
 }
 
-func (rc CommitsManagerService) GetCommitsByRepositoryName(repoName string, limit, offset int, startDate, endDate time.Time) ([]*models.Commit, error) {
-	return rc.CommitsPersistence.GetCommitsByRepoName(repoName, limit, offset, startDate, endDate)
+// GetCommitsByRepositoryName returns a page of the commits of a repository
+// with their CI status.
+func (rc CommitsManagerService) GetCommitsByRepositoryName(repoName, branch string, limit, offset int, startDate, endDate time.Time) ([]*models.Commit, error) {
+	commits, err := rc.CommitsPersistence.GetCommitsByRepoName(repoName, branch, limit, offset, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	for _, commit := range commits {
+		if err := rc.setCIStatus(commit); err != nil {
+			return nil, err
+		}
+	}
+	return commits, nil
+}
+
+// setCIStatus sets the workflow runs of a commit and sums them up: pending
+// while any run is not completed, success when every run succeeded or was
+// skipped or neutral, failure otherwise.
+func (rc CommitsManagerService) setCIStatus(commit *models.Commit) error {
+	runs, err := rc.WorkflowRunPersistence.GetWorkflowRunsByHeadSHA(commit.RepositoryName, commit.SHA)
+	if err != nil {
+		return err
+	}
+	if len(runs) == 0 {
+		return nil
+	}
+
+	commit.WorkflowRuns = runs
+	commit.CIStatus = constants.CI_STATUS_SUCCESS
+	for _, run := range runs {
+		if run.Status != constants.WORKFLOW_RUN_STATUS_COMPLETED {
+			commit.CIStatus = constants.CI_STATUS_PENDING
+			return nil
+		}
+		switch run.Conclusion {
+		case constants.WORKFLOW_RUN_CONCLUSION_SUCCESS, constants.WORKFLOW_RUN_CONCLUSION_SKIPPED, constants.WORKFLOW_RUN_CONCLUSION_NEUTRAL:
+		default:
+			commit.CIStatus = constants.CI_STATUS_FAILURE
+		}
+	}
+	return nil
 }
 
 func (rc CommitsManagerService) GetTopCommitAuthors(limit int) ([]*models.CommitAuthor, error) {
@@ -25,6 +66,31 @@ func (rc CommitsManagerService) GetTopCommitAuthorsByRepoName(repoName string, l
 	return rc.CommitsPersistence.GetTopCommitAuthorsByRepo(repoName, limit)
 }
 
-func (rc CommitsManagerService) GetTotalCommitsByRepositoryName(repoName string, startDate, endDate time.Time) (int, error) {
-	return rc.CommitsPersistence.GetTotalCommitsByRepoName(repoName, startDate, endDate)
+// GetCommitDetails returns a commit of a repository with its stats, changed
+// files, the branches it is reachable from and its CI status.
+func (rc CommitsManagerService) GetCommitDetails(repoName, sha string) (*models.Commit, error) {
+	commit, err := rc.CommitsPersistence.GetCommitBySHA(repoName, sha)
+	if err != nil {
+		return nil, err
+	}
+	commit.Files, err = rc.CommitsPersistence.GetCommitFiles(repoName, sha)
+	if err != nil {
+		return nil, err
+	}
+	commit.Branches, err = rc.CommitsPersistence.GetCommitBranches(repoName, sha)
+	if err != nil {
+		return nil, err
+	}
+	if err := rc.setCIStatus(commit); err != nil {
+		return nil, err
+	}
+	return commit, nil
+}
+
+func (rc CommitsManagerService) GetCommitsFetchOutcomes(repoName string, limit int) ([]*models.CommitsFetchOutcome, error) {
+	return rc.CommitsPersistence.GetCommitsFetchOutcomes(repoName, limit)
+}
+
+func (rc CommitsManagerService) GetTotalCommitsByRepositoryName(repoName, branch string, startDate, endDate time.Time) (int, error) {
+	return rc.CommitsPersistence.GetTotalCommitsByRepoName(repoName, branch, startDate, endDate)
 }
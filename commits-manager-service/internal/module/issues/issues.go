@@ -0,0 +1,113 @@
+package issues
+
+import (
+	"commits-manager-service/internal/constants/models"
+	"commits-manager-service/internal/storage/db"
+	"math"
+	"sort"
+	"time"
+)
+
+// ageBuckets are the upper bounds of the open issue age buckets. Issues
+// older than the last bound fall into a final open ended bucket.
+var ageBuckets = []struct {
+	label string
+	age   time.Duration
+}{
+	{"0d", 0},
+	{"1d", 24 * time.Hour},
+	{"7d", 7 * 24 * time.Hour},
+	{"30d", 30 * 24 * time.Hour},
+	{"90d", 90 * 24 * time.Hour},
+	{"365d", 365 * 24 * time.Hour},
+}
+
+type IssuesManagerService struct {
+	IssuePersistence db.IssueRepository
+}
+
+func NewIssuesManagerService(issuePersistence db.IssueRepository) IssuesManagerService {
+	return IssuesManagerService{IssuePersistence: issuePersistence}
+}
+
+func (is IssuesManagerService) GetIssuesByRepositoryName(repoName, state, label string, limit, offset int) ([]*models.Issue, error) {
+	return is.IssuePersistence.GetIssuesByRepoName(repoName, state, label, limit, offset)
+}
+
+func (is IssuesManagerService) GetTotalIssuesByRepositoryName(repoName, state, label string) (int, error) {
+	return is.IssuePersistence.GetTotalIssuesByRepoName(repoName, state, label)
+}
+
+// GetTimeToClose returns how long the issues of a repository closed between
+// startDate and endDate were open.
+func (is IssuesManagerService) GetTimeToClose(repoName string, startDate, endDate time.Time) (*models.IssueTimeToClose, error) {
+	durations, err := is.IssuePersistence.GetIssueCloseDurations(repoName, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	timeToClose := &models.IssueTimeToClose{RepositoryName: repoName, Closed: len(durations)}
+	if len(durations) == 0 {
+		return timeToClose, nil
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	var sum time.Duration
+	for _, d := range durations {
+		sum += d
+	}
+	timeToClose.MeanHours = hours(sum / time.Duration(len(durations)))
+	timeToClose.MedianHours = hours(percentile(durations, 0.5))
+	timeToClose.P90Hours = hours(percentile(durations, 0.9))
+	return timeToClose, nil
+}
+
+// GetOpenIssueAges returns how long the open issues of a repository have been
+// open at now, bucketed by age.
+func (is IssuesManagerService) GetOpenIssueAges(repoName string, now time.Time) (*models.IssueAgeDistribution, error) {
+	createdAt, err := is.IssuePersistence.GetOpenIssueCreationTimes(repoName)
+	if err != nil {
+		return nil, err
+	}
+
+	distribution := &models.IssueAgeDistribution{RepositoryName: repoName, Open: len(createdAt)}
+	for i, bucket := range ageBuckets {
+		ageBucket := models.IssueAgeBucket{From: bucket.label}
+		if i+1 < len(ageBuckets) {
+			ageBucket.To = ageBuckets[i+1].label
+		}
+		distribution.Buckets = append(distribution.Buckets, ageBucket)
+	}
+	if len(createdAt) == 0 {
+		return distribution, nil
+	}
+
+	ages := make([]time.Duration, len(createdAt))
+	for i, t := range createdAt {
+		ages[i] = now.Sub(t)
+		bucket := sort.Search(len(ageBuckets), func(b int) bool { return ageBuckets[b].age > ages[i] }) - 1
+		if bucket < 0 {
+			bucket = 0
+		}
+		distribution.Buckets[bucket].Count++
+	}
+
+	sort.Slice(ages, func(i, j int) bool { return ages[i] < ages[j] })
+	distribution.MedianAgeHours = hours(percentile(ages, 0.5))
+	return distribution, nil
+}
+
+// percentile returns the p-th percentile of sorted durations by the nearest
+// rank method.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	return sorted[rank]
+}
+
+// hours returns d in hours rounded to two decimals.
+func hours(d time.Duration) float64 {
+	return math.Round(d.Hours()*100) / 100
+}
@@ -0,0 +1,22 @@
+package pulls
+
+import (
+	"commits-manager-service/internal/constants/models"
+	"commits-manager-service/internal/storage/db"
+)
+
+type PullsManagerService struct {
+	PullRequestPersistence db.PullRequestRepository
+}
+
+func NewPullsManagerService(pullRequestPersistence db.PullRequestRepository) PullsManagerService {
+	return PullsManagerService{PullRequestPersistence: pullRequestPersistence}
+}
+
+func (ps PullsManagerService) GetPullRequestsByRepositoryName(repoName, state string, limit, offset int) ([]*models.PullRequest, error) {
+	return ps.PullRequestPersistence.GetPullRequestsByRepoName(repoName, state, limit, offset)
+}
+
+func (ps PullsManagerService) GetTotalPullRequestsByRepositoryName(repoName, state string) (int, error) {
+	return ps.PullRequestPersistence.GetTotalPullRequestsByRepoName(repoName, state)
+}
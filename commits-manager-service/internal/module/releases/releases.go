@@ -0,0 +1,93 @@
+package releases
+
+import (
+	"commits-manager-service/internal/constants/models"
+	"commits-manager-service/internal/storage/db"
+	"time"
+)
+
+// maxReleaseCommits caps the commits listed for a release.
+const maxReleaseCommits = 1000
+
+type ReleasesManagerService struct {
+	ReleasePersistence db.ReleaseRepository
+	CommitsPersistence db.CommitRepository
+}
+
+func NewReleasesManagerService(releasePersistence db.ReleaseRepository, commitsPersistence db.CommitRepository) ReleasesManagerService {
+	return ReleasesManagerService{ReleasePersistence: releasePersistence, CommitsPersistence: commitsPersistence}
+}
+
+func (rs ReleasesManagerService) GetReleasesByRepositoryName(repoName string) ([]*models.Release, error) {
+	return rs.ReleasePersistence.GetReleasesByRepoName(repoName)
+}
+
+// GetReleaseCommits returns the newest maxReleaseCommits of the commits that
+// went into the release of a tag since the previous release, and marks the
+// result truncated when there are more. They are walked along the stored
+// commit parents from the release target down to the previous release
+// target. When the parents of the target are not stored, the commits
+// authored between the two releases are listed instead.
+func (rs ReleasesManagerService) GetReleaseCommits(repoName, tagName string) (*models.ReleaseCommits, error) {
+	release, err := rs.ReleasePersistence.GetRelease(repoName, tagName)
+	if err != nil {
+		return nil, err
+	}
+
+	previous, err := rs.ReleasePersistence.GetPreviousRelease(repoName, release.ReleasedAt(), release.Prerelease)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.ReleaseCommits{Release: release, PreviousRelease: previous}
+
+	var parents []string
+	if release.TargetSHA != "" {
+		parents, err = rs.CommitsPersistence.GetCommitParents(repoName, release.TargetSHA)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if len(parents) > 0 {
+		var since string
+		if previous != nil {
+			since = previous.TargetSHA
+		}
+		// one more than listed tells whether there are more
+		commits, err := rs.CommitsPersistence.GetCommitsBetween(repoName, since, release.TargetSHA, maxReleaseCommits+1)
+		if err != nil {
+			return nil, err
+		}
+		result.Commits, result.Truncated = truncate(commits)
+		return result, nil
+	}
+
+	var start time.Time
+	if previous != nil {
+		start = previous.ReleasedAt()
+	}
+	commits, err := rs.CommitsPersistence.GetNewestCommitsByRepoName(repoName, maxReleaseCommits+1, start, release.ReleasedAt())
+	if err != nil {
+		return nil, err
+	}
+	commits, result.Truncated = truncate(commits)
+
+	result.Commits = make([]*models.Commit, 0, len(commits))
+	for _, commit := range commits {
+		if previous != nil && !commit.AuthorDate.After(start) {
+			continue
+		}
+		result.Commits = append(result.Commits, commit)
+	}
+	return result, nil
+}
+
+// truncate keeps the first maxReleaseCommits commits and reports whether
+// there were more.
+func truncate(commits []*models.Commit) ([]*models.Commit, bool) {
+	if len(commits) > maxReleaseCommits {
+		return commits[:maxReleaseCommits], true
+	}
+	return commits, false
+}
@@ -2,6 +2,11 @@ package repos
 
 import "commits-manager-service/internal/storage/db"
 import "commits-manager-service/internal/constants/models"
+import "errors"
+
+// ErrAmbiguousRepositoryName is returned when a repository name without an
+// owner matches repositories of several owners.
+var ErrAmbiguousRepositoryName = errors.New("repository name matches several owners, use /{owner}/{repositoryName}")
 
 type RepositoryManagerService struct {
 	RepositoryPersistence db.GitReposRepository
@@ -18,3 +23,23 @@ func (rc RepositoryManagerService) GetRepositories(limit, offset int) ([]*models
 func (rc RepositoryManagerService) GetTotalRepositories() (int, error) {
 	return rc.RepositoryPersistence.GetTotalRepositories()
 }
+
+// ResolveFullName returns the full name (owner/name) of a repository. Without
+// an owner the name must belong to a single tracked repository.
+func (rc RepositoryManagerService) ResolveFullName(owner, name string) (string, error) {
+	if owner != "" {
+		return owner + "/" + name, nil
+	}
+
+	fullNames, err := rc.RepositoryPersistence.GetRepositoryFullNames(name)
+	if err != nil {
+		return "", err
+	}
+	switch len(fullNames) {
+	case 0:
+		return name, nil
+	case 1:
+		return fullNames[0], nil
+	}
+	return "", ErrAmbiguousRepositoryName
+}
@@ -0,0 +1,42 @@
+package schedules
+
+import (
+	"commits-manager-service/internal/constants"
+	"commits-manager-service/internal/constants/models"
+	"commits-manager-service/internal/storage/db"
+	"errors"
+	"time"
+)
+
+// ErrPinnedIntervalTooShort is returned when a repository is pinned to poll
+// more often than MinPinnedInterval.
+var ErrPinnedIntervalTooShort = errors.New("pinned interval must be at least 1m")
+
+// MinPinnedInterval is the shortest interval a repository can be pinned to.
+const MinPinnedInterval = time.Minute
+
+type SchedulesManagerService struct {
+	PollSchedulePersistence db.PollScheduleRepository
+}
+
+func NewSchedulesManagerService(pollSchedulePersistence db.PollScheduleRepository) SchedulesManagerService {
+	return SchedulesManagerService{PollSchedulePersistence: pollSchedulePersistence}
+}
+
+// GetPollSchedules returns the poll schedules of the polled repositories, or
+// of the given one, counting the commits of the last
+// POLL_RECENT_COMMITS_DAYS days.
+func (ss SchedulesManagerService) GetPollSchedules(repoName string) ([]*models.PollSchedule, error) {
+	since := time.Now().UTC().AddDate(0, 0, -constants.POLL_RECENT_COMMITS_DAYS)
+	return ss.PollSchedulePersistence.GetPollSchedules(repoName, since)
+}
+
+// PinPollInterval polls a repository at a fixed interval, or at the interval
+// the commits monitor sets when interval is 0. The repository is polled
+// next right away.
+func (ss SchedulesManagerService) PinPollInterval(repoName string, interval time.Duration) error {
+	if interval != 0 && interval < MinPinnedInterval {
+		return ErrPinnedIntervalTooShort
+	}
+	return ss.PollSchedulePersistence.PinPollInterval(repoName, int64(interval/time.Second), time.Now().UTC())
+}
@@ -0,0 +1,70 @@
+package tracking
+
+import (
+	"commits-manager-service/internal/constants/models"
+	"commits-manager-service/internal/storage/db"
+	"errors"
+	"path"
+	"strings"
+	"time"
+)
+
+// ErrPollIntervalTooShort is returned when a repository is set to be polled
+// more often than MinPollInterval.
+var ErrPollIntervalTooShort = errors.New("pollInterval must be at least 1m")
+
+// ErrInvalidBranchPattern is returned when a branch of the list is not a
+// valid glob pattern.
+var ErrInvalidBranchPattern = errors.New("branches must be branch names or glob patterns")
+
+// MinPollInterval is the shortest interval a repository can be set to be
+// polled at.
+const MinPollInterval = time.Minute
+
+type TrackingManagerService struct {
+	TrackedRepositoryPersistence db.TrackedRepositoryRepository
+}
+
+func NewTrackingManagerService(trackedRepositoryPersistence db.TrackedRepositoryRepository) TrackingManagerService {
+	return TrackingManagerService{TrackedRepositoryPersistence: trackedRepositoryPersistence}
+}
+
+func (ts TrackingManagerService) GetTrackedRepositories() ([]*models.TrackedRepository, error) {
+	return ts.TrackedRepositoryPersistence.GetTrackedRepositories()
+}
+
+func (ts TrackingManagerService) GetTrackedRepository(repoName string) (*models.TrackedRepository, error) {
+	return ts.TrackedRepositoryPersistence.GetTrackedRepository(repoName)
+}
+
+// SaveTrackedRepository replaces the settings of a repository once they are
+// valid. Blank branches are dropped; nil branches keep the configured ones.
+func (ts TrackingManagerService) SaveTrackedRepository(settings models.TrackedRepository) (*models.TrackedRepository, error) {
+	if settings.PollIntervalSeconds != 0 && time.Duration(settings.PollIntervalSeconds)*time.Second < MinPollInterval {
+		return nil, ErrPollIntervalTooShort
+	}
+
+	if settings.Branches == nil {
+		return ts.TrackedRepositoryPersistence.SaveTrackedRepository(settings)
+	}
+	branches := make([]string, 0, len(settings.Branches))
+	for _, branch := range settings.Branches {
+		branch = strings.TrimSpace(branch)
+		if branch == "" {
+			continue
+		}
+		if _, err := path.Match(branch, ""); err != nil || strings.Contains(branch, ",") {
+			return nil, ErrInvalidBranchPattern
+		}
+		branches = append(branches, branch)
+	}
+	settings.Branches = branches
+
+	return ts.TrackedRepositoryPersistence.SaveTrackedRepository(settings)
+}
+
+// DeleteTrackedRepository hands a repository back to the defaults. It
+// returns whether it had settings.
+func (ts TrackingManagerService) DeleteTrackedRepository(repoName string) (bool, error) {
+	return ts.TrackedRepositoryPersistence.DeleteTrackedRepository(repoName)
+}
@@ -0,0 +1,49 @@
+package db
+
+import (
+	"commits-manager-service/internal/constants/models"
+	"database/sql"
+	"log"
+)
+
+type ArchiveImportRepository interface {
+	IsArchiveImported(fileName string) (bool, error)
+	SaveArchiveImport(archiveImport models.ArchiveImport) error
+}
+
+type ArchiveImportPersistence struct {
+	db *sql.DB
+}
+
+// NewArchiveImportPersistence creates an instance of the ArchiveImportPersistence.
+func NewArchiveImportPersistence(dbPool *sql.DB) ArchiveImportRepository {
+	return &ArchiveImportPersistence{db: dbPool}
+}
+
+// IsArchiveImported reports whether the GH Archive file was imported already.
+func (ap *ArchiveImportPersistence) IsArchiveImported(fileName string) (bool, error) {
+	var exists bool
+	query := "SELECT EXISTS (SELECT 1 FROM archive_imports WHERE file_name = $1)"
+	err := ap.db.QueryRow(query, fileName).Scan(&exists)
+	if err != nil {
+		log.Println("Error querying archive import:", err)
+	}
+	return exists, err
+}
+
+// SaveArchiveImport records that a GH Archive file was imported, replacing
+// the record of an earlier import of the same file.
+func (ap *ArchiveImportPersistence) SaveArchiveImport(archiveImport models.ArchiveImport) error {
+	stmt := `INSERT INTO archive_imports (file_name, events, repositories, commits, imported_at)
+             VALUES ($1, $2, $3, $4, $5)
+             ON CONFLICT (file_name) DO UPDATE SET
+                 events = excluded.events, repositories = excluded.repositories, commits = excluded.commits,
+                 imported_at = excluded.imported_at`
+	_, err := ap.db.Exec(stmt, archiveImport.FileName, archiveImport.Events, archiveImport.Repositories,
+		archiveImport.Commits, archiveImport.ImportedAt)
+	if err != nil {
+		log.Println("Error saving archive import:", err)
+		return err
+	}
+	return nil
+}
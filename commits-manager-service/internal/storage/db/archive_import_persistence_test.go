@@ -0,0 +1,35 @@
+package db_test
+
+import (
+	"commits-manager-service/internal/constants/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveArchiveImport(t *testing.T) {
+	fileName := uuid.New().String() + ".json.gz"
+
+	imported, err := archiveImportsQueries.IsArchiveImported(fileName)
+	require.NoError(t, err)
+	require.False(t, imported)
+
+	archiveImport := models.ArchiveImport{
+		FileName:     fileName,
+		Events:       120,
+		Repositories: 2,
+		Commits:      14,
+		ImportedAt:   time.Now().UTC(),
+	}
+	require.NoError(t, archiveImportsQueries.SaveArchiveImport(archiveImport))
+
+	// importing a file again replaces its record
+	archiveImport.Commits = 15
+	require.NoError(t, archiveImportsQueries.SaveArchiveImport(archiveImport))
+
+	imported, err = archiveImportsQueries.IsArchiveImported(fileName)
+	require.NoError(t, err)
+	require.True(t, imported)
+}
@@ -0,0 +1,178 @@
+package db
+
+import (
+	"commits-manager-service/internal/constants"
+	"commits-manager-service/internal/constants/models"
+	"database/sql"
+	"errors"
+	"log"
+	"time"
+)
+
+type BackfillJobRepository interface {
+	InsertBackfillJob(job models.BackfillJob) (*models.BackfillJob, error)
+	GetBackfillJobs(status string, limit int, offset int) ([]*models.BackfillJob, error)
+	GetBackfillJobByID(id int64) (*models.BackfillJob, error)
+	ClaimBackfillJob(staleBefore time.Time) (*models.BackfillJob, error)
+	UpdateBackfillJobProgress(job models.BackfillJob) error
+}
+
+type BackfillJobPersistence struct {
+	db *sql.DB
+}
+
+// NewBackfillJobPersistence creates an instance of the BackfillJobPersistence.
+func NewBackfillJobPersistence(dbPool *sql.DB) BackfillJobRepository {
+	return &BackfillJobPersistence{db: dbPool}
+}
+
+const backfillJobColumns = `id, repository_name, start_date, end_date, priority, status, repositories_total,
+        repositories_done, commits, error, created_at, started_at, finished_at, updated_at`
+
+// InsertBackfillJob queues a backfill job and returns it as stored.
+func (bp *BackfillJobPersistence) InsertBackfillJob(job models.BackfillJob) (*models.BackfillJob, error) {
+	now := time.Now().UTC()
+	stmt := `INSERT INTO backfill_jobs (repository_name, start_date, end_date, priority, status, created_at, updated_at)
+             VALUES ($1, $2, $3, $4, $5, $6, $6)
+             RETURNING id`
+	var id int64
+	err := bp.db.QueryRow(stmt, job.RepositoryName, job.StartDate.UTC(), job.EndDate.UTC(), job.Priority,
+		constants.BACKFILL_STATUS_QUEUED, now).Scan(&id)
+	if err != nil {
+		log.Println("Error inserting backfill job:", err)
+		return nil, err
+	}
+	return bp.GetBackfillJobByID(id)
+}
+
+// GetBackfillJobs returns the backfill jobs with the given status, or all of
+// them, newest first.
+func (bp *BackfillJobPersistence) GetBackfillJobs(status string, limit int, offset int) ([]*models.BackfillJob, error) {
+	query := `SELECT ` + backfillJobColumns + `
+        FROM backfill_jobs
+        WHERE CAST($1 AS TEXT) = '' OR status = $1
+        ORDER BY created_at DESC, id DESC
+        LIMIT $2 OFFSET $3`
+	rows, err := bp.db.Query(query, status, limit, offset)
+	if err != nil {
+		log.Println("Error querying backfill jobs:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	jobs := make([]*models.BackfillJob, 0)
+	for rows.Next() {
+		job, err := scanBackfillJob(rows)
+		if err != nil {
+			log.Println("Error scanning backfill job row:", err)
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Error iterating through backfill jobs:", err)
+		return nil, err
+	}
+
+	return jobs, nil
+}
+
+// GetBackfillJobByID returns a backfill job, or nil when there is none with
+// that id.
+func (bp *BackfillJobPersistence) GetBackfillJobByID(id int64) (*models.BackfillJob, error) {
+	query := `SELECT ` + backfillJobColumns + ` FROM backfill_jobs WHERE id = $1`
+	job, err := scanBackfillJob(bp.db.QueryRow(query, id))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Println("Error querying backfill job:", err)
+		return nil, err
+	}
+	return job, nil
+}
+
+// ClaimBackfillJob marks the queued backfill job with the highest priority,
+// the oldest first among equals, as running and returns it. Running jobs not
+// updated since staleBefore are claimed again, as their runner is gone. It
+// returns nil when there is no job to run.
+func (bp *BackfillJobPersistence) ClaimBackfillJob(staleBefore time.Time) (*models.BackfillJob, error) {
+	query := `SELECT id FROM backfill_jobs
+        WHERE status = $1 OR (status = $2 AND updated_at < $3)
+        ORDER BY priority DESC, created_at, id
+        LIMIT 1`
+	claim := `UPDATE backfill_jobs SET status = $1, started_at = COALESCE(started_at, $2), updated_at = $2
+             WHERE id = $3 AND (status = $4 OR (status = $1 AND updated_at < $5))`
+
+	// another claim may win the job between the two statements, in which
+	// case the next one is tried
+	for attempt := 0; attempt < 3; attempt++ {
+		var id int64
+		err := bp.db.QueryRow(query, constants.BACKFILL_STATUS_QUEUED, constants.BACKFILL_STATUS_RUNNING,
+			staleBefore.UTC()).Scan(&id)
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		if err != nil {
+			log.Println("Error querying backfill job to claim:", err)
+			return nil, err
+		}
+
+		result, err := bp.db.Exec(claim, constants.BACKFILL_STATUS_RUNNING, time.Now().UTC(), id,
+			constants.BACKFILL_STATUS_QUEUED, staleBefore.UTC())
+		if err != nil {
+			log.Println("Error claiming backfill job:", err)
+			return nil, err
+		}
+		if claimed, err := result.RowsAffected(); err != nil || claimed == 0 {
+			continue
+		}
+		return bp.GetBackfillJobByID(id)
+	}
+	return nil, nil
+}
+
+// UpdateBackfillJobProgress stores the status, progress and error reported
+// for a backfill job. Completed and failed jobs are given their finish time
+// and are not updated anymore, so progress reported late does not reopen
+// them.
+func (bp *BackfillJobPersistence) UpdateBackfillJobProgress(job models.BackfillJob) error {
+	now := time.Now().UTC()
+	var finishedAt *time.Time
+	if job.Status == constants.BACKFILL_STATUS_COMPLETED || job.Status == constants.BACKFILL_STATUS_FAILED {
+		finishedAt = &now
+	}
+	stmt := `UPDATE backfill_jobs SET status = $1, repositories_total = $2, repositories_done = $3, commits = $4,
+                 error = $5, finished_at = $6, updated_at = $7
+             WHERE id = $8 AND status NOT IN ($9, $10)`
+	_, err := bp.db.Exec(stmt, job.Status, job.RepositoriesTotal, job.RepositoriesDone, job.Commits, job.Error,
+		finishedAt, now, job.ID, constants.BACKFILL_STATUS_COMPLETED, constants.BACKFILL_STATUS_FAILED)
+	if err != nil {
+		log.Println("Error updating backfill job progress:", err)
+		return err
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanBackfillJob(row rowScanner) (*models.BackfillJob, error) {
+	var job models.BackfillJob
+	var startedAt, finishedAt sql.NullTime
+	err := row.Scan(&job.ID, &job.RepositoryName, &job.StartDate, &job.EndDate, &job.Priority, &job.Status,
+		&job.RepositoriesTotal, &job.RepositoriesDone, &job.Commits, &job.Error, &job.CreatedAt, &startedAt,
+		&finishedAt, &job.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if startedAt.Valid {
+		job.StartedAt = &startedAt.Time
+	}
+	if finishedAt.Valid {
+		job.FinishedAt = &finishedAt.Time
+	}
+	return &job, nil
+}
@@ -0,0 +1,87 @@
+package db_test
+
+import (
+	"commits-manager-service/internal/constants"
+	"commits-manager-service/internal/constants/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBackfillJobs(t *testing.T) {
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	low, err := backfillJobsQueries.InsertBackfillJob(models.BackfillJob{
+		RepositoryName: "owner/low", StartDate: start, EndDate: end, Priority: 1,
+	})
+	require.NoError(t, err)
+	require.Equal(t, constants.BACKFILL_STATUS_QUEUED, low.Status)
+	require.True(t, start.Equal(low.StartDate))
+	require.Nil(t, low.StartedAt)
+
+	high, err := backfillJobsQueries.InsertBackfillJob(models.BackfillJob{
+		StartDate: start, EndDate: end, Priority: 5,
+	})
+	require.NoError(t, err)
+
+	// the highest priority is claimed first, then nothing is left to claim
+	// until the claimed job goes stale
+	claimed, err := backfillJobsQueries.ClaimBackfillJob(time.Now().UTC().Add(-time.Hour))
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	require.Equal(t, high.ID, claimed.ID)
+	require.Equal(t, "", claimed.RepositoryName)
+	require.Equal(t, constants.BACKFILL_STATUS_RUNNING, claimed.Status)
+	require.NotNil(t, claimed.StartedAt)
+
+	claimed, err = backfillJobsQueries.ClaimBackfillJob(time.Now().UTC().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, low.ID, claimed.ID)
+
+	claimed, err = backfillJobsQueries.ClaimBackfillJob(time.Now().UTC().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Nil(t, claimed)
+
+	claimed, err = backfillJobsQueries.ClaimBackfillJob(time.Now().UTC().Add(time.Hour))
+	require.NoError(t, err)
+	require.NotNil(t, claimed)
+	require.Equal(t, high.ID, claimed.ID)
+
+	require.NoError(t, backfillJobsQueries.UpdateBackfillJobProgress(models.BackfillJob{
+		ID: high.ID, Status: constants.BACKFILL_STATUS_RUNNING, RepositoriesTotal: 3, RepositoriesDone: 1, Commits: 42,
+	}))
+	job, err := backfillJobsQueries.GetBackfillJobByID(high.ID)
+	require.NoError(t, err)
+	require.Equal(t, 3, job.RepositoriesTotal)
+	require.Equal(t, 1, job.RepositoriesDone)
+	require.Equal(t, 42, job.Commits)
+	require.Nil(t, job.FinishedAt)
+
+	require.NoError(t, backfillJobsQueries.UpdateBackfillJobProgress(models.BackfillJob{
+		ID: low.ID, Status: constants.BACKFILL_STATUS_FAILED, Error: "not found",
+	}))
+	failed, err := backfillJobsQueries.GetBackfillJobs(constants.BACKFILL_STATUS_FAILED, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, failed, 1)
+	require.Equal(t, low.ID, failed[0].ID)
+	require.Equal(t, "not found", failed[0].Error)
+	require.NotNil(t, failed[0].FinishedAt)
+
+	// a failed job stays failed
+	require.NoError(t, backfillJobsQueries.UpdateBackfillJobProgress(models.BackfillJob{
+		ID: low.ID, Status: constants.BACKFILL_STATUS_RUNNING,
+	}))
+	job, err = backfillJobsQueries.GetBackfillJobByID(low.ID)
+	require.NoError(t, err)
+	require.Equal(t, constants.BACKFILL_STATUS_FAILED, job.Status)
+
+	jobs, err := backfillJobsQueries.GetBackfillJobs("", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, jobs, 2)
+
+	job, err = backfillJobsQueries.GetBackfillJobByID(-1)
+	require.NoError(t, err)
+	require.Nil(t, job)
+}
@@ -10,18 +10,30 @@ import (
 
 type CommitRepository interface {
 	GetAllCommits() ([]*models.Commit, error)
-	GetCommitBySHA(sha string) (*models.Commit, error)
+	GetCommitBySHA(repoName, sha string) (*models.Commit, error)
 	UpdateCommit(commit models.Commit) error
-	DeleteCommit(sha string) error
+	DeleteCommit(repoName, sha string) error
 	InsertCommit(commit models.Commit) error
 	SaveAllCommits(commits []models.Commit) error
-	CommitExists(sha string) (bool, error)
-	GetCommitsByRepoName(repoName string, limit, offset int, startDate, endDate time.Time) ([]*models.Commit, error)
-	GetTotalCommitsByRepoName(repoName string, startDate, endDate time.Time) (int, error)
+	InsertMissingCommits(commits []models.Commit) (int, error)
+	CommitExists(repoName, sha string) (bool, error)
+	GetCommitsByRepoName(repoName, branch string, limit, offset int, startDate, endDate time.Time) ([]*models.Commit, error)
+	GetNewestCommitsByRepoName(repoName string, limit int, startDate, endDate time.Time) ([]*models.Commit, error)
+	GetTotalCommitsByRepoName(repoName, branch string, startDate, endDate time.Time) (int, error)
 	GetTopCommitAuthors(limit int) ([]*models.CommitAuthor, error)
 	GetTopCommitAuthorsByRepo(repoName string, limit int) ([]*models.CommitAuthor, error)
 	SaveCommitsFetchData(metadata models.CommitsFetchHistory) error
-	GetLastCommitFetchTime(repositoryName string) (*models.CommitsFetchHistory, error)
+	SaveCommitsFetchOutcome(outcome models.CommitsFetchOutcome) error
+	GetCommitsFetchOutcomes(repositoryName string, limit int) ([]*models.CommitsFetchOutcome, error)
+	GetCommitWatermark(repositoryName, branch string) (*models.CommitWatermark, error)
+	SaveCommitBranches(repoName, branch string, shas []string) error
+	GetCommitBranches(repoName, sha string) ([]string, error)
+	GetCommitParents(repoName, sha string) ([]string, error)
+	GetCommitsBetween(repoName, sinceSHA, untilSHA string, limit int) ([]*models.Commit, error)
+	GetCommitsWithoutStats(repositoryName string, limit int) ([]string, error)
+	SaveCommitDetails(details models.CommitDetails) error
+	SaveCommitDetailsFailure(repoName, sha string, statusCode int, message string) error
+	GetCommitFiles(repoName, sha string) ([]models.CommitFile, error)
 }
 
 type CommitPersistence struct {
@@ -59,20 +71,24 @@ func (cp *CommitPersistence) GetAllCommits() ([]*models.Commit, error) {
 	return commits, nil
 }
 
-func (cp *CommitPersistence) GetCommitBySHA(sha string) (*models.Commit, error) {
+func (cp *CommitPersistence) GetCommitBySHA(repoName, sha string) (*models.Commit, error) {
 	var commit models.Commit
-	err := cp.db.QueryRow("SELECT id, sha, url, message, author_name, author_date, created_at, updated_at, repository_name FROM commits WHERE sha = $1", sha).
-		Scan(&commit.ID, &commit.SHA, &commit.URL, &commit.Message, &commit.AuthorName, &commit.AuthorDate, &commit.CreatedAt, &commit.UpdatedAt, &commit.RepositoryName)
+	var additions, deletions, total sql.NullInt64
+	err := cp.db.QueryRow("SELECT c.id, c.sha, c.url, c.message, c.author_name, c.author_date, c.created_at, c.updated_at, c.repository_name, s.additions, s.deletions, s.total FROM commits c LEFT JOIN commit_stats s ON s.repository_name = c.repository_name AND s.sha = c.sha WHERE c.repository_name = $1 AND c.sha = $2", repoName, sha).
+		Scan(&commit.ID, &commit.SHA, &commit.URL, &commit.Message, &commit.AuthorName, &commit.AuthorDate, &commit.CreatedAt, &commit.UpdatedAt, &commit.RepositoryName, &additions, &deletions, &total)
 	if err != nil {
 		log.Println("Error querying commit by SHA:", err)
 		return nil, err
 	}
+	if total.Valid {
+		commit.Stats = &models.CommitStats{Additions: int(additions.Int64), Deletions: int(deletions.Int64), Total: int(total.Int64)}
+	}
 	return &commit, nil
 }
 
 func (cp *CommitPersistence) UpdateCommit(commit models.Commit) error {
-	_, err := cp.db.Exec("UPDATE commits SET url = $1, message = $2, author_name = $3, author_date = $4, created_at = $5, updated_at = $6, repository_name = $7 WHERE sha = $8",
-		commit.URL, commit.Message, commit.AuthorName, commit.AuthorDate, commit.CreatedAt, commit.UpdatedAt, commit.RepositoryName, commit.SHA)
+	_, err := cp.db.Exec("UPDATE commits SET url = $1, message = $2, author_name = $3, author_date = $4, created_at = $5, updated_at = $6, bot = $7 WHERE repository_name = $8 AND sha = $9",
+		commit.URL, commit.Message, commit.AuthorName, commit.AuthorDate, commit.CreatedAt, commit.UpdatedAt, commit.Bot, commit.RepositoryName, commit.SHA)
 	if err != nil {
 		log.Println("Error updating commit:", err)
 		return err
@@ -80,8 +96,8 @@ func (cp *CommitPersistence) UpdateCommit(commit models.Commit) error {
 	return nil
 }
 
-func (cp *CommitPersistence) DeleteCommit(sha string) error {
-	_, err := cp.db.Exec("DELETE FROM commits WHERE sha = $1", sha)
+func (cp *CommitPersistence) DeleteCommit(repoName, sha string) error {
+	_, err := cp.db.Exec("DELETE FROM commits WHERE repository_name = $1 AND sha = $2", repoName, sha)
 	if err != nil {
 		log.Println("Error deleting commit:", err)
 		return err
@@ -94,10 +110,10 @@ func (cp *CommitPersistence) InsertCommit(commit models.Commit) error {
 	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
 	defer cancel()
 
-	stmt := `INSERT INTO commits (sha, url, message, author_name, author_date, created_at, updated_at, repository_name) 
-             VALUES ($1, $2, $3, $4, $5, $6, $7, $8)`
+	stmt := `INSERT INTO commits (sha, url, message, author_name, author_date, created_at, updated_at, repository_name, bot) 
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
 
-	_, err := cp.db.ExecContext(ctx, stmt, commit.SHA, commit.URL, commit.Message, commit.AuthorName, commit.AuthorDate, commit.CreatedAt, commit.UpdatedAt, commit.RepositoryName)
+	_, err := cp.db.ExecContext(ctx, stmt, commit.SHA, commit.URL, commit.Message, commit.AuthorName, commit.AuthorDate, commit.CreatedAt, commit.UpdatedAt, commit.RepositoryName, commit.Bot)
 	if err != nil {
 		log.Println("Error inserting commit:", err)
 		return err
@@ -107,7 +123,7 @@ func (cp *CommitPersistence) InsertCommit(commit models.Commit) error {
 
 func (cp *CommitPersistence) SaveAllCommits(commits []models.Commit) error {
 	for _, commit := range commits {
-		exists, err := cp.CommitExists(commit.SHA)
+		exists, err := cp.CommitExists(commit.RepositoryName, commit.SHA)
 		if err != nil {
 			return err
 		}
@@ -120,27 +136,114 @@ func (cp *CommitPersistence) SaveAllCommits(commits []models.Commit) error {
 				return err
 			}
 		}
+		if err := cp.saveCommitParents(commit); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// InsertMissingCommits inserts the commits not stored yet with their parents
+// and keeps the stored ones as they are. It returns the number inserted.
+func (cp *CommitPersistence) InsertMissingCommits(commits []models.Commit) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), bulkTimeout)
+	defer cancel()
+
+	tx, err := cp.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Error starting commits transaction:", err)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	stmt := `INSERT INTO commits (sha, url, message, author_name, author_date, created_at, updated_at, repository_name, bot)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+             ON CONFLICT (repository_name, sha) DO NOTHING`
+
+	var inserted int
+	for _, commit := range commits {
+		result, err := tx.ExecContext(ctx, stmt, commit.SHA, commit.URL, commit.Message, commit.AuthorName, commit.AuthorDate, commit.CreatedAt, commit.UpdatedAt, commit.RepositoryName, commit.Bot)
+		if err != nil {
+			log.Println("Error inserting commit:", err)
+			return 0, err
+		}
+		if affected, _ := result.RowsAffected(); affected == 0 {
+			continue
+		}
+		inserted++
+
+		for i, parent := range commit.Parents {
+			_, err := tx.ExecContext(ctx, `INSERT INTO commit_parents (repository_name, sha, parent_sha, position) VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING`,
+				commit.RepositoryName, commit.SHA, parent, i)
+			if err != nil {
+				log.Println("Error inserting commit parent:", err)
+				return 0, err
+			}
+		}
+	}
+
+	return inserted, tx.Commit()
+}
+
+// saveCommitParents records the parents of a commit. They never change, so
+// already known ones are kept.
+func (cp *CommitPersistence) saveCommitParents(commit models.Commit) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	for i, parent := range commit.Parents {
+		_, err := cp.db.ExecContext(ctx, `INSERT INTO commit_parents (repository_name, sha, parent_sha, position) VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING`,
+			commit.RepositoryName, commit.SHA, parent, i)
+		if err != nil {
+			log.Println("Error inserting commit parent:", err)
+			return err
+		}
 	}
 	return nil
 }
 
-func (cp *CommitPersistence) CommitExists(sha string) (bool, error) {
+func (cp *CommitPersistence) CommitExists(repoName, sha string) (bool, error) {
 	var exists bool
-	query := "SELECT EXISTS (SELECT 1 FROM commits WHERE sha = $1)"
-	err := cp.db.QueryRow(query, sha).Scan(&exists)
+	query := "SELECT EXISTS (SELECT 1 FROM commits WHERE repository_name = $1 AND sha = $2)"
+	err := cp.db.QueryRow(query, repoName, sha).Scan(&exists)
 	return exists, err
 }
 
-func (cp *CommitPersistence) GetCommitsByRepoName(repoName string, limit, offset int, startDate, endDate time.Time) ([]*models.Commit, error) {
+// withoutExcludedBots leaves out of the commits c those authored by bots in
+// the repositories whose tracking settings exclude them. They are stored all
+// the same, so the watermark of a branch reaches its head and the commits
+// they are the parents of are linked to them.
+const withoutExcludedBots = `NOT (c.bot AND EXISTS (
+            SELECT 1 FROM tracked_repositories t WHERE t.repository_name = c.repository_name AND t.exclude_bots))`
+
+// GetCommitsByRepoName returns a page of the commits of a repository, only
+// those reachable from branch when it is not empty.
+func (cp *CommitPersistence) GetCommitsByRepoName(repoName, branch string, limit, offset int, startDate, endDate time.Time) ([]*models.Commit, error) {
+	return cp.getCommitsByRepoName("ASC", repoName, branch, limit, offset, startDate, endDate)
+}
+
+// GetNewestCommitsByRepoName returns the newest commits of a repository
+// authored between startDate and endDate, newest first.
+func (cp *CommitPersistence) GetNewestCommitsByRepoName(repoName string, limit int, startDate, endDate time.Time) ([]*models.Commit, error) {
+	return cp.getCommitsByRepoName("DESC", repoName, "", limit, 0, startDate, endDate)
+}
+
+// getCommitsByRepoName lists the commits of a repository by author date in
+// the given order, ASC or DESC.
+func (cp *CommitPersistence) getCommitsByRepoName(order string, repoName, branch string, limit, offset int, startDate, endDate time.Time) ([]*models.Commit, error) {
 	query := `
-        SELECT id, sha, url, message, author_name, author_date, created_at, updated_at, repository_name
-        FROM commits
-        WHERE repository_name = $1 AND author_date >= $2 AND author_date <= $3
-        ORDER BY author_date ASC
-        LIMIT $4 OFFSET $5
+        SELECT c.id, c.sha, c.url, c.message, c.author_name, c.author_date, c.created_at, c.updated_at, c.repository_name,
+               s.additions, s.deletions, s.total
+        FROM commits c
+        LEFT JOIN commit_stats s ON s.repository_name = c.repository_name AND s.sha = c.sha
+        WHERE c.repository_name = $1 AND c.author_date >= $2 AND c.author_date <= $3
+          AND (CAST($4 AS TEXT) = '' OR EXISTS (SELECT 1 FROM commit_branches b WHERE b.repository_name = c.repository_name AND b.sha = c.sha AND b.branch = $4))
+          AND ` + withoutExcludedBots + `
+        ORDER BY c.author_date ` + order + `
+        LIMIT $5 OFFSET $6
     `
 
-	rows, err := cp.db.Query(query, repoName, startDate, endDate, limit, offset)
+	rows, err := cp.db.Query(query, repoName, startDate, endDate, branch, limit, offset)
 	if err != nil {
 		log.Println("Error querying commits by repository name:", err)
 		return nil, err
@@ -150,10 +253,14 @@ func (cp *CommitPersistence) GetCommitsByRepoName(repoName string, limit, offset
 	var commits []*models.Commit
 	for rows.Next() {
 		var commit models.Commit
-		if err := rows.Scan(&commit.ID, &commit.SHA, &commit.URL, &commit.Message, &commit.AuthorName, &commit.AuthorDate, &commit.CreatedAt, &commit.UpdatedAt, &commit.RepositoryName); err != nil {
+		var additions, deletions, total sql.NullInt64
+		if err := rows.Scan(&commit.ID, &commit.SHA, &commit.URL, &commit.Message, &commit.AuthorName, &commit.AuthorDate, &commit.CreatedAt, &commit.UpdatedAt, &commit.RepositoryName, &additions, &deletions, &total); err != nil {
 			log.Println("Error scanning commit row:", err)
 			return nil, err
 		}
+		if total.Valid {
+			commit.Stats = &models.CommitStats{Additions: int(additions.Int64), Deletions: int(deletions.Int64), Total: int(total.Int64)}
+		}
 		commits = append(commits, &commit)
 	}
 
@@ -165,14 +272,16 @@ func (cp *CommitPersistence) GetCommitsByRepoName(repoName string, limit, offset
 	return commits, nil
 }
 
-func (cp *CommitPersistence) GetTotalCommitsByRepoName(repoName string, startDate, endDate time.Time) (int, error) {
+func (cp *CommitPersistence) GetTotalCommitsByRepoName(repoName, branch string, startDate, endDate time.Time) (int, error) {
 	query := `
         SELECT COUNT(*)
-        FROM commits
-        WHERE repository_name = $1 AND author_date >= $2 AND author_date <= $3
+        FROM commits c
+        WHERE c.repository_name = $1 AND c.author_date >= $2 AND c.author_date <= $3
+          AND (CAST($4 AS TEXT) = '' OR EXISTS (SELECT 1 FROM commit_branches b WHERE b.repository_name = c.repository_name AND b.sha = c.sha AND b.branch = $4))
+          AND ` + withoutExcludedBots + `
     `
 	var count int
-	err := cp.db.QueryRow(query, repoName, startDate, endDate).Scan(&count)
+	err := cp.db.QueryRow(query, repoName, startDate, endDate, branch).Scan(&count)
 	if err != nil {
 		log.Println("Error querying total commits by repository name:", err)
 		return 0, err
@@ -184,7 +293,8 @@ func (cp *CommitPersistence) GetTotalCommitsByRepoName(repoName string, startDat
 func (cp *CommitPersistence) GetTopCommitAuthors(limit int) ([]*models.CommitAuthor, error) {
 	query := `
         SELECT author_name, COUNT(*) as commit_count
-        FROM commits
+        FROM commits c
+        WHERE ` + withoutExcludedBots + `
         GROUP BY author_name
         ORDER BY commit_count DESC
         LIMIT $1;
@@ -210,8 +320,8 @@ func (cp *CommitPersistence) GetTopCommitAuthors(limit int) ([]*models.CommitAut
 func (cp *CommitPersistence) GetTopCommitAuthorsByRepo(repoName string, limit int) ([]*models.CommitAuthor, error) {
 	query := `
         SELECT author_name, COUNT(*) as commit_count
-        FROM commits
-        WHERE repository_name = $1
+        FROM commits c
+        WHERE repository_name = $1 AND ` + withoutExcludedBots + `
         GROUP BY author_name
         ORDER BY commit_count DESC
         LIMIT $2;
@@ -235,8 +345,8 @@ func (cp *CommitPersistence) GetTopCommitAuthorsByRepo(repoName string, limit in
 }
 
 func (cp *CommitPersistence) SaveCommitsFetchData(metadata models.CommitsFetchHistory) error {
-	stmt := `INSERT INTO commits_fetch_history (repository_name, total, last_page, fetched_at) VALUES ($1, $2, $3, $4)`
-	_, err := cp.db.Exec(stmt, metadata.RepositoryName, metadata.Total, metadata.LastPage, metadata.FetchedAt)
+	stmt := `INSERT INTO commits_fetch_history (repository_name, total, fetched_at) VALUES ($1, $2, $3)`
+	_, err := cp.db.Exec(stmt, metadata.RepositoryName, metadata.Total, metadata.FetchedAt)
 	if err != nil {
 		log.Println("Error inserting fetch commits metadata:", err)
 		return err
@@ -244,16 +354,328 @@ func (cp *CommitPersistence) SaveCommitsFetchData(metadata models.CommitsFetchHi
 	return nil
 }
 
-func (cp *CommitPersistence) GetLastCommitFetchTime(repositoryName string) (*models.CommitsFetchHistory, error) {
-	var commitsFetchHistory models.CommitsFetchHistory
-	query := `SELECT id, repository_name, total, last_page, fetched_at FROM commits_fetch_history WHERE repository_name = $1 AND last_page = (SELECT MAX(last_page) FROM commits_fetch_history WHERE repository_name = $1)`
-	err := cp.db.QueryRow(query, repositoryName).Scan(&commitsFetchHistory.ID, &commitsFetchHistory.RepositoryName, &commitsFetchHistory.Total, &commitsFetchHistory.LastPage, &commitsFetchHistory.FetchedAt)
+// SaveCommitsFetchOutcome records how fetching the commits of a repository
+// went.
+func (cp *CommitPersistence) SaveCommitsFetchOutcome(outcome models.CommitsFetchOutcome) error {
+	stmt := `INSERT INTO commits_fetch_outcomes (repository_name, outcome, status_code, message, total, fetched_at) VALUES ($1, $2, $3, $4, $5, $6)`
+	_, err := cp.db.Exec(stmt, outcome.RepositoryName, outcome.Outcome, outcome.StatusCode, outcome.Message, outcome.Total, outcome.FetchedAt)
+	if err != nil {
+		log.Println("Error inserting commits fetch outcome:", err)
+		return err
+	}
+	return nil
+}
+
+// GetCommitsFetchOutcomes returns the latest fetch outcomes of a repository,
+// newest first.
+func (cp *CommitPersistence) GetCommitsFetchOutcomes(repositoryName string, limit int) ([]*models.CommitsFetchOutcome, error) {
+	query := `
+        SELECT id, repository_name, outcome, status_code, message, total, fetched_at
+        FROM commits_fetch_outcomes
+        WHERE repository_name = $1
+        ORDER BY fetched_at DESC, id DESC
+        LIMIT $2
+    `
+	rows, err := cp.db.Query(query, repositoryName, limit)
+	if err != nil {
+		log.Println("Error querying commits fetch outcomes:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	outcomes := make([]*models.CommitsFetchOutcome, 0)
+	for rows.Next() {
+		var outcome models.CommitsFetchOutcome
+		if err := rows.Scan(&outcome.ID, &outcome.RepositoryName, &outcome.Outcome, &outcome.StatusCode, &outcome.Message, &outcome.Total, &outcome.FetchedAt); err != nil {
+			log.Println("Error scanning commits fetch outcome row:", err)
+			return nil, err
+		}
+		outcomes = append(outcomes, &outcome)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Error iterating through commits fetch outcomes:", err)
+		return nil, err
+	}
+
+	return outcomes, nil
+}
+
+// GetCommitWatermark returns the newest stored commit of a branch of a
+// repository, or of the whole repository when branch is empty. An empty
+// watermark is returned when no commit is stored yet.
+func (cp *CommitPersistence) GetCommitWatermark(repositoryName, branch string) (*models.CommitWatermark, error) {
+	watermark := models.CommitWatermark{RepositoryName: repositoryName}
+	query := `
+        SELECT c.sha, c.author_date
+        FROM commits c
+        WHERE c.repository_name = $1
+          AND (CAST($2 AS TEXT) = '' OR EXISTS (SELECT 1 FROM commit_branches b WHERE b.repository_name = c.repository_name AND b.sha = c.sha AND b.branch = $2))
+        ORDER BY c.author_date DESC, c.id DESC
+        LIMIT 1
+    `
+	err := cp.db.QueryRow(query, repositoryName, branch).Scan(&watermark.SHA, &watermark.AuthorDate)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return &commitsFetchHistory, nil
+			return &watermark, nil
+		}
+		log.Println("Error getting commit watermark:", err)
+		return nil, err
+	}
+	return &watermark, nil
+}
+
+// GetCommitsWithoutStats returns the SHAs of the newest commits of a
+// repository whose details were not fetched yet, leaving out those whose
+// details cannot be fetched.
+func (cp *CommitPersistence) GetCommitsWithoutStats(repositoryName string, limit int) ([]string, error) {
+	query := `
+        SELECT c.sha
+        FROM commits c
+        LEFT JOIN commit_stats s ON s.repository_name = c.repository_name AND s.sha = c.sha
+        WHERE c.repository_name = $1 AND s.sha IS NULL
+          AND NOT EXISTS (SELECT 1 FROM commit_details_failures f WHERE f.repository_name = c.repository_name AND f.sha = c.sha)
+        ORDER BY c.author_date DESC
+        LIMIT $2
+    `
+	rows, err := cp.db.Query(query, repositoryName, limit)
+	if err != nil {
+		log.Println("Error querying commits without stats:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	shas := make([]string, 0)
+	for rows.Next() {
+		var sha string
+		if err := rows.Scan(&sha); err != nil {
+			log.Println("Error scanning commit sha row:", err)
+			return nil, err
 		}
-		log.Println("Error getting last commit fetch time:", err)
+		shas = append(shas, sha)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Error iterating through commits without stats:", err)
 		return nil, err
 	}
-	return &commitsFetchHistory, nil
+
+	return shas, nil
+}
+
+// SaveCommitDetails stores the stats of a commit and replaces its changed
+// files.
+func (cp *CommitPersistence) SaveCommitDetails(details models.CommitDetails) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	tx, err := cp.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Error starting commit details transaction:", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := `INSERT INTO commit_stats (repository_name, sha, additions, deletions, total) VALUES ($1, $2, $3, $4, $5)
+             ON CONFLICT (repository_name, sha) DO UPDATE SET additions = excluded.additions, deletions = excluded.deletions, total = excluded.total`
+	_, err = tx.ExecContext(ctx, stmt, details.RepositoryName, details.SHA, details.Stats.Additions, details.Stats.Deletions, details.Stats.Total)
+	if err != nil {
+		log.Println("Error saving commit stats:", err)
+		return err
+	}
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM commit_files WHERE repository_name = $1 AND sha = $2", details.RepositoryName, details.SHA)
+	if err != nil {
+		log.Println("Error deleting commit files:", err)
+		return err
+	}
+
+	for _, file := range details.Files {
+		_, err = tx.ExecContext(ctx, `INSERT INTO commit_files (repository_name, sha, path, status, additions, deletions) VALUES ($1, $2, $3, $4, $5, $6)`,
+			details.RepositoryName, details.SHA, file.Path, file.Status, file.Additions, file.Deletions)
+		if err != nil {
+			log.Println("Error inserting commit file:", err)
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SaveCommitDetailsFailure records that the details of a commit cannot be
+// fetched, e.g. as GitHub no longer knows it, so they are not asked for
+// again.
+func (cp *CommitPersistence) SaveCommitDetailsFailure(repoName, sha string, statusCode int, message string) error {
+	stmt := `INSERT INTO commit_details_failures (repository_name, sha, status_code, message, failed_at) VALUES ($1, $2, $3, $4, $5)
+             ON CONFLICT (repository_name, sha) DO UPDATE SET status_code = excluded.status_code, message = excluded.message,
+                 failed_at = excluded.failed_at`
+	_, err := cp.db.Exec(stmt, repoName, sha, statusCode, message, time.Now().UTC())
+	if err != nil {
+		log.Println("Error saving commit details failure:", err)
+		return err
+	}
+	return nil
+}
+
+// GetCommitFiles returns the files changed by a commit of a repository.
+func (cp *CommitPersistence) GetCommitFiles(repoName, sha string) ([]models.CommitFile, error) {
+	rows, err := cp.db.Query("SELECT path, status, additions, deletions FROM commit_files WHERE repository_name = $1 AND sha = $2 ORDER BY id", repoName, sha)
+	if err != nil {
+		log.Println("Error querying commit files:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	files := make([]models.CommitFile, 0)
+	for rows.Next() {
+		var file models.CommitFile
+		if err := rows.Scan(&file.Path, &file.Status, &file.Additions, &file.Deletions); err != nil {
+			log.Println("Error scanning commit file row:", err)
+			return nil, err
+		}
+		files = append(files, file)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Error iterating through commit files:", err)
+		return nil, err
+	}
+
+	return files, nil
+}
+
+// SaveCommitBranches records that the commits of a repository are reachable
+// from branch.
+func (cp *CommitPersistence) SaveCommitBranches(repoName, branch string, shas []string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	tx, err := cp.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Error starting commit branches transaction:", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, sha := range shas {
+		_, err = tx.ExecContext(ctx, `INSERT INTO commit_branches (repository_name, sha, branch) VALUES ($1, $2, $3) ON CONFLICT DO NOTHING`, repoName, sha, branch)
+		if err != nil {
+			log.Println("Error inserting commit branch:", err)
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetCommitBranches returns the branches a commit of a repository is
+// reachable from.
+func (cp *CommitPersistence) GetCommitBranches(repoName, sha string) ([]string, error) {
+	rows, err := cp.db.Query("SELECT branch FROM commit_branches WHERE repository_name = $1 AND sha = $2 ORDER BY branch", repoName, sha)
+	if err != nil {
+		log.Println("Error querying commit branches:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	branches := make([]string, 0)
+	for rows.Next() {
+		var branch string
+		if err := rows.Scan(&branch); err != nil {
+			log.Println("Error scanning commit branch row:", err)
+			return nil, err
+		}
+		branches = append(branches, branch)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Error iterating through commit branches:", err)
+		return nil, err
+	}
+
+	return branches, nil
+}
+
+// GetCommitParents returns the SHAs of the parents of a commit of a
+// repository, first parent first.
+func (cp *CommitPersistence) GetCommitParents(repoName, sha string) ([]string, error) {
+	rows, err := cp.db.Query("SELECT parent_sha FROM commit_parents WHERE repository_name = $1 AND sha = $2 ORDER BY position", repoName, sha)
+	if err != nil {
+		log.Println("Error querying commit parents:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	parents := make([]string, 0)
+	for rows.Next() {
+		var parent string
+		if err := rows.Scan(&parent); err != nil {
+			log.Println("Error scanning commit parent row:", err)
+			return nil, err
+		}
+		parents = append(parents, parent)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Error iterating through commit parents:", err)
+		return nil, err
+	}
+
+	return parents, nil
+}
+
+// GetCommitsBetween returns the newest limit stored commits of a repository
+// reachable from untilSHA but not from sinceSHA, newest first, like git log
+// since..until. sinceSHA may be empty to walk the whole history.
+func (cp *CommitPersistence) GetCommitsBetween(repoName, sinceSHA, untilSHA string, limit int) ([]*models.Commit, error) {
+	query := `
+        WITH RECURSIVE
+        included(sha) AS (
+            SELECT CAST($1 AS VARCHAR(255))
+            UNION
+            SELECT p.parent_sha FROM commit_parents p JOIN included i ON p.sha = i.sha WHERE p.repository_name = $2
+        ),
+        excluded(sha) AS (
+            SELECT CAST($3 AS VARCHAR(255))
+            UNION
+            SELECT p.parent_sha FROM commit_parents p JOIN excluded e ON p.sha = e.sha WHERE p.repository_name = $2
+        )
+        SELECT c.id, c.sha, c.url, c.message, c.author_name, c.author_date, c.created_at, c.updated_at, c.repository_name,
+               s.additions, s.deletions, s.total
+        FROM commits c
+        JOIN included i ON i.sha = c.sha
+        LEFT JOIN commit_stats s ON s.repository_name = c.repository_name AND s.sha = c.sha
+        WHERE c.repository_name = $2 AND c.sha NOT IN (SELECT sha FROM excluded)
+          AND ` + withoutExcludedBots + `
+        ORDER BY c.author_date DESC
+        LIMIT $4
+    `
+
+	rows, err := cp.db.Query(query, untilSHA, repoName, sinceSHA, limit)
+	if err != nil {
+		log.Println("Error querying commits between:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	commits := make([]*models.Commit, 0)
+	for rows.Next() {
+		var commit models.Commit
+		var additions, deletions, total sql.NullInt64
+		if err := rows.Scan(&commit.ID, &commit.SHA, &commit.URL, &commit.Message, &commit.AuthorName, &commit.AuthorDate, &commit.CreatedAt, &commit.UpdatedAt, &commit.RepositoryName, &additions, &deletions, &total); err != nil {
+			log.Println("Error scanning commit row:", err)
+			return nil, err
+		}
+		if total.Valid {
+			commit.Stats = &models.CommitStats{Additions: int(additions.Int64), Deletions: int(deletions.Int64), Total: int(total.Int64)}
+		}
+		commits = append(commits, &commit)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Error iterating through commits:", err)
+		return nil, err
+	}
+
+	return commits, nil
 }
@@ -1,6 +1,7 @@
 package db_test
 
 import (
+	"commits-manager-service/internal/constants"
 	"commits-manager-service/internal/constants/models"
 	"testing"
 	"time"
@@ -30,6 +31,7 @@ func TestInsertCommit(t *testing.T) {
 	repoName := uuid.New().String()
 	_, err := repositoryQueries.InsertRepository(models.Repository{
 		Name:            repoName,
+		FullName:        repoName,
 		Description:     "Test Repository",
 		URL:             "http://example.com/repo",
 		Language:        "Go",
@@ -43,7 +45,7 @@ func TestInsertCommit(t *testing.T) {
 	require.NoError(t, err)
 
 	commit:=createRandomCommit(t, repoName)
-	commitsQueries.DeleteCommit(commit.SHA)
+	commitsQueries.DeleteCommit(commit.RepositoryName, commit.SHA)
 	repositoryQueries.DeleteRepository(repoName)
 }
 
@@ -51,6 +53,7 @@ func TestGetCommitBySHA(t *testing.T) {
 	repoName := uuid.New().String()
 	_, err := repositoryQueries.InsertRepository(models.Repository{
 		Name:            repoName,
+		FullName:        repoName,
 		Description:     "Test Repository",
 		URL:             "http://example.com/repo",
 		Language:        "Go",
@@ -64,11 +67,11 @@ func TestGetCommitBySHA(t *testing.T) {
 	require.NoError(t, err)
 
 	commit := createRandomCommit(t, repoName)
-	retrievedCommit, err := commitsQueries.GetCommitBySHA(commit.SHA)
+	retrievedCommit, err := commitsQueries.GetCommitBySHA(commit.RepositoryName, commit.SHA)
 	require.NoError(t, err)
 	require.NotEmpty(t, retrievedCommit)
 	require.Equal(t, commit.SHA, retrievedCommit.SHA)
-	commitsQueries.DeleteCommit(commit.SHA)
+	commitsQueries.DeleteCommit(commit.RepositoryName, commit.SHA)
 	repositoryQueries.DeleteRepository(repoName)
 }
 
@@ -76,6 +79,7 @@ func TestUpdateCommit(t *testing.T) {
 	repoName := uuid.New().String()
 	_, err := repositoryQueries.InsertRepository(models.Repository{
 		Name:            repoName,
+		FullName:        repoName,
 		Description:     "Test Repository",
 		URL:             "http://example.com/repo",
 		Language:        "Go",
@@ -93,12 +97,12 @@ func TestUpdateCommit(t *testing.T) {
 	err = commitsQueries.UpdateCommit(commit)
 	require.NoError(t, err)
 
-	retrievedCommit, err := commitsQueries.GetCommitBySHA(commit.SHA)
+	retrievedCommit, err := commitsQueries.GetCommitBySHA(commit.RepositoryName, commit.SHA)
 	require.NoError(t, err)
 	require.NotEmpty(t, retrievedCommit)
 	require.Equal(t, "Updated commit message", retrievedCommit.Message)
 
-	commitsQueries.DeleteCommit(commit.SHA)
+	commitsQueries.DeleteCommit(commit.RepositoryName, commit.SHA)
 	repositoryQueries.DeleteRepository(repoName)
 }
 
@@ -106,6 +110,7 @@ func TestDeleteCommit(t *testing.T) {
 	repoName := uuid.New().String()
 	_, err := repositoryQueries.InsertRepository(models.Repository{
 		Name:            repoName,
+		FullName:        repoName,
 		Description:     "Test Repository",
 		URL:             "http://example.com/repo",
 		Language:        "Go",
@@ -119,10 +124,10 @@ func TestDeleteCommit(t *testing.T) {
 	require.NoError(t, err)
 
 	commit := createRandomCommit(t, repoName)
-	err = commitsQueries.DeleteCommit(commit.SHA)
+	err = commitsQueries.DeleteCommit(commit.RepositoryName, commit.SHA)
 	require.NoError(t, err)
 
-	retrievedCommit, err := commitsQueries.GetCommitBySHA(commit.SHA)
+	retrievedCommit, err := commitsQueries.GetCommitBySHA(commit.RepositoryName, commit.SHA)
 	require.Error(t, err)
 	require.Empty(t, retrievedCommit)
 
@@ -134,6 +139,7 @@ func TestGetAllCommits(t *testing.T) {
 	repoName := uuid.New().String()
 	_, err := repositoryQueries.InsertRepository(models.Repository{
 		Name:            repoName,
+		FullName:        repoName,
 		Description:     "Test Repository",
 		URL:             "http://example.com/repo",
 		Language:        "Go",
@@ -155,8 +161,8 @@ func TestGetAllCommits(t *testing.T) {
 	require.Len(t, commits, 2)
 	require.Equal(t, commit1.SHA, commits[0].SHA)
 	require.Equal(t, commit2.SHA, commits[1].SHA)
-	commitsQueries.DeleteCommit(commit1.SHA)
-	commitsQueries.DeleteCommit(commit2.SHA)
+	commitsQueries.DeleteCommit(commit1.RepositoryName, commit1.SHA)
+	commitsQueries.DeleteCommit(commit2.RepositoryName, commit2.SHA)
 	repositoryQueries.DeleteRepository(repoName)
 }
 
@@ -181,7 +187,7 @@ func TestGetAllCommits(t *testing.T) {
 // 	require.NoError(t, err)
 // 	require.NotEmpty(t, commits)
 // 	require.Equal(t, commit.RepositoryName, commits[0].RepositoryName)
-// 	commitsQueries.DeleteCommit(commit.SHA)
+// 	commitsQueries.DeleteCommit(commit.RepositoryName, commit.SHA)
 // 	repositoryQueries.DeleteRepository(repoName)
 // }
 
@@ -189,6 +195,7 @@ func TestGetTopCommitAuthors(t *testing.T) {
 	repoName := uuid.New().String()
 	_, err := repositoryQueries.InsertRepository(models.Repository{
 		Name:            repoName,
+		FullName:        repoName,
 		Description:     "Test Repository",
 		URL:             "http://example.com/repo",
 		Language:        "Go",
@@ -206,7 +213,7 @@ func TestGetTopCommitAuthors(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, authors)
 	require.Equal(t, commit.AuthorName, authors[0].Name)
-	commitsQueries.DeleteCommit(commit.SHA)
+	commitsQueries.DeleteCommit(commit.RepositoryName, commit.SHA)
 	repositoryQueries.DeleteRepository(repoName)
 }
 
@@ -214,6 +221,7 @@ func TestGetTopCommitAuthorsByRepo(t *testing.T) {
 	repoName := uuid.New().String()
 	_, err := repositoryQueries.InsertRepository(models.Repository{
 		Name:            repoName,
+		FullName:        repoName,
 		Description:     "Test Repository",
 		URL:             "http://example.com/repo",
 		Language:        "Go",
@@ -231,7 +239,355 @@ func TestGetTopCommitAuthorsByRepo(t *testing.T) {
 	require.NoError(t, err)
 	require.NotEmpty(t, authors)
 	require.Equal(t, commit.AuthorName, authors[0].Name)
-	commitsQueries.DeleteCommit(commit.SHA)
+	commitsQueries.DeleteCommit(commit.RepositoryName, commit.SHA)
 	repositoryQueries.DeleteRepository(repoName)
 
 }
+
+func TestGetCommitWatermark(t *testing.T) {
+	repo := createRandomRepository()
+	_, err := repositoryQueries.InsertRepository(repo)
+	require.NoError(t, err)
+
+	watermark, err := commitsQueries.GetCommitWatermark(repo.FullName, "")
+	require.NoError(t, err)
+	require.Empty(t, watermark.SHA)
+
+	now := time.Now().UTC()
+	older := createRandomCommit(t, repo.FullName)
+	older.AuthorDate = now.Add(-time.Hour)
+	require.NoError(t, commitsQueries.UpdateCommit(older))
+
+	newest := createRandomCommit(t, repo.FullName)
+	newest.AuthorDate = now
+	require.NoError(t, commitsQueries.UpdateCommit(newest))
+
+	watermark, err = commitsQueries.GetCommitWatermark(repo.FullName, "")
+	require.NoError(t, err)
+	require.Equal(t, newest.SHA, watermark.SHA)
+	require.True(t, newest.AuthorDate.Equal(watermark.AuthorDate))
+
+	commitsQueries.DeleteCommit(older.RepositoryName, older.SHA)
+	commitsQueries.DeleteCommit(newest.RepositoryName, newest.SHA)
+	repositoryQueries.DeleteRepository(repo.FullName)
+}
+
+func TestCommitBranches(t *testing.T) {
+	repo := createRandomRepository()
+	_, err := repositoryQueries.InsertRepository(repo)
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	base := createRandomCommit(t, repo.FullName)
+	base.AuthorDate = now.Add(-time.Hour)
+	require.NoError(t, commitsQueries.UpdateCommit(base))
+
+	feature := createRandomCommit(t, repo.FullName)
+	feature.AuthorDate = now
+	require.NoError(t, commitsQueries.UpdateCommit(feature))
+
+	require.NoError(t, commitsQueries.SaveCommitBranches(repo.FullName, "main", []string{base.SHA}))
+	require.NoError(t, commitsQueries.SaveCommitBranches(repo.FullName, "feature/x", []string{base.SHA, feature.SHA}))
+	// saving again is a no-op
+	require.NoError(t, commitsQueries.SaveCommitBranches(repo.FullName, "main", []string{base.SHA}))
+
+	watermark, err := commitsQueries.GetCommitWatermark(repo.FullName, "main")
+	require.NoError(t, err)
+	require.Equal(t, base.SHA, watermark.SHA)
+
+	watermark, err = commitsQueries.GetCommitWatermark(repo.FullName, "feature/x")
+	require.NoError(t, err)
+	require.Equal(t, feature.SHA, watermark.SHA)
+
+	watermark, err = commitsQueries.GetCommitWatermark(repo.FullName, "gone")
+	require.NoError(t, err)
+	require.Empty(t, watermark.SHA)
+
+	commits, err := commitsQueries.GetCommitsByRepoName(repo.FullName, "main", 10, 0, time.Time{}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	require.Equal(t, base.SHA, commits[0].SHA)
+
+	commits, err = commitsQueries.GetNewestCommitsByRepoName(repo.FullName, 1, time.Time{}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	require.Equal(t, feature.SHA, commits[0].SHA)
+
+	total, err := commitsQueries.GetTotalCommitsByRepoName(repo.FullName, "", time.Time{}, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	total, err = commitsQueries.GetTotalCommitsByRepoName(repo.FullName, "main", time.Time{}, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+
+	branches, err := commitsQueries.GetCommitBranches(base.RepositoryName, base.SHA)
+	require.NoError(t, err)
+	require.Equal(t, []string{"feature/x", "main"}, branches)
+
+	commitsQueries.DeleteCommit(base.RepositoryName, base.SHA)
+	commitsQueries.DeleteCommit(feature.RepositoryName, feature.SHA)
+	repositoryQueries.DeleteRepository(repo.FullName)
+}
+
+func TestCommitsFetchOutcomes(t *testing.T) {
+	repo := createRandomRepository()
+	_, err := repositoryQueries.InsertRepository(repo)
+	require.NoError(t, err)
+
+	now := time.Now().UTC()
+	err = commitsQueries.SaveCommitsFetchOutcome(models.CommitsFetchOutcome{
+		RepositoryName: repo.FullName,
+		Outcome:        constants.FETCH_OUTCOME_OK,
+		Total:          3,
+		FetchedAt:      now.Add(-time.Hour),
+	})
+	require.NoError(t, err)
+	err = commitsQueries.SaveCommitsFetchOutcome(models.CommitsFetchOutcome{
+		RepositoryName: repo.FullName,
+		Outcome:        constants.FETCH_OUTCOME_EMPTY,
+		StatusCode:     409,
+		Message:        "Git Repository is empty.",
+		FetchedAt:      now,
+	})
+	require.NoError(t, err)
+
+	outcomes, err := commitsQueries.GetCommitsFetchOutcomes(repo.FullName, 10)
+	require.NoError(t, err)
+	require.Len(t, outcomes, 2)
+	require.Equal(t, constants.FETCH_OUTCOME_EMPTY, outcomes[0].Outcome)
+	require.Equal(t, 409, outcomes[0].StatusCode)
+	require.Equal(t, constants.FETCH_OUTCOME_OK, outcomes[1].Outcome)
+	require.Equal(t, 3, outcomes[1].Total)
+
+	outcomes, err = commitsQueries.GetCommitsFetchOutcomes(repo.FullName, 1)
+	require.NoError(t, err)
+	require.Len(t, outcomes, 1)
+
+	repositoryQueries.DeleteRepository(repo.FullName)
+}
+
+func TestSaveCommitDetails(t *testing.T) {
+	repo := createRandomRepository()
+	_, err := repositoryQueries.InsertRepository(repo)
+	require.NoError(t, err)
+
+	commit := createRandomCommit(t, repo.FullName)
+
+	shas, err := commitsQueries.GetCommitsWithoutStats(repo.FullName, 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{commit.SHA}, shas)
+
+	details := models.CommitDetails{
+		RepositoryName: repo.FullName,
+		SHA:            commit.SHA,
+		Stats:          models.CommitStats{Additions: 12, Deletions: 3, Total: 15},
+		Files: []models.CommitFile{
+			{Path: "main.go", Status: "modified", Additions: 10, Deletions: 3},
+			{Path: "README.md", Status: "added", Additions: 2},
+		},
+	}
+	require.NoError(t, commitsQueries.SaveCommitDetails(details))
+
+	// saving again replaces the files
+	details.Files = details.Files[:1]
+	require.NoError(t, commitsQueries.SaveCommitDetails(details))
+
+	shas, err = commitsQueries.GetCommitsWithoutStats(repo.FullName, 10)
+	require.NoError(t, err)
+	require.Empty(t, shas)
+
+	stored, err := commitsQueries.GetCommitBySHA(commit.RepositoryName, commit.SHA)
+	require.NoError(t, err)
+	require.Equal(t, &details.Stats, stored.Stats)
+
+	commits, err := commitsQueries.GetCommitsByRepoName(repo.FullName, "", 10, 0, time.Time{}, time.Now())
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	require.Equal(t, &details.Stats, commits[0].Stats)
+
+	files, err := commitsQueries.GetCommitFiles(commit.RepositoryName, commit.SHA)
+	require.NoError(t, err)
+	require.Equal(t, details.Files, files)
+
+	commitsQueries.DeleteCommit(commit.RepositoryName, commit.SHA)
+	repositoryQueries.DeleteRepository(repo.FullName)
+}
+
+func TestInsertMissingCommits(t *testing.T) {
+	repo := createRandomRepository()
+	_, err := repositoryQueries.InsertRepository(repo)
+	require.NoError(t, err)
+
+	stored := createRandomCommit(t, repo.FullName)
+
+	// an archived copy of the stored commit does not replace it
+	archived := stored
+	archived.Message = "Archived message"
+	missing := models.Commit{
+		SHA:            uuid.New().String(),
+		URL:            "http://example.com/commit",
+		Message:        "Missing commit",
+		AuthorName:     "Author",
+		AuthorDate:     time.Now(),
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+		RepositoryName: repo.FullName,
+		Parents:        []string{stored.SHA},
+	}
+
+	inserted, err := commitsQueries.InsertMissingCommits([]models.Commit{archived, missing, missing})
+	require.NoError(t, err)
+	require.Equal(t, 1, inserted)
+
+	commit, err := commitsQueries.GetCommitBySHA(stored.RepositoryName, stored.SHA)
+	require.NoError(t, err)
+	require.Equal(t, stored.Message, commit.Message)
+
+	parents, err := commitsQueries.GetCommitParents(missing.RepositoryName, missing.SHA)
+	require.NoError(t, err)
+	require.Equal(t, []string{stored.SHA}, parents)
+
+	require.NoError(t, repositoryQueries.DeleteRepository(repo.FullName))
+}
+
+func TestSaveCommitDetailsFailure(t *testing.T) {
+	repo := createRandomRepository()
+	_, err := repositoryQueries.InsertRepository(repo)
+	require.NoError(t, err)
+	defer repositoryQueries.DeleteRepository(repo.FullName)
+
+	failed := createRandomCommit(t, repo.FullName)
+	other := createRandomCommit(t, repo.FullName)
+
+	require.NoError(t, commitsQueries.SaveCommitDetailsFailure(failed.RepositoryName, failed.SHA, 422, "No commit found for SHA"))
+	require.NoError(t, commitsQueries.SaveCommitDetailsFailure(failed.RepositoryName, failed.SHA, 404, "Not Found"))
+
+	// commits whose details cannot be fetched are not asked for again
+	shas, err := commitsQueries.GetCommitsWithoutStats(repo.FullName, 10)
+	require.NoError(t, err)
+	require.Equal(t, []string{other.SHA}, shas)
+}
+
+func TestExcludedBotCommits(t *testing.T) {
+	repo := createRandomRepository()
+	_, err := repositoryQueries.InsertRepository(repo)
+	require.NoError(t, err)
+	defer repositoryQueries.DeleteRepository(repo.FullName)
+	defer trackedRepositoriesQueries.DeleteTrackedRepository(repo.FullName)
+
+	now := time.Now().UTC()
+	human := models.Commit{SHA: repo.FullName + "-human", URL: "http://example.com/commit", Message: "Fix login",
+		AuthorName: "Jane", AuthorDate: now.Add(-time.Hour), RepositoryName: repo.FullName}
+	bot := models.Commit{SHA: repo.FullName + "-bot", URL: "http://example.com/commit", Message: "Bump deps",
+		AuthorName: "dependabot[bot]", AuthorDate: now, RepositoryName: repo.FullName, Bot: true,
+		Parents: []string{human.SHA}}
+	require.NoError(t, commitsQueries.SaveAllCommits([]models.Commit{human, bot}))
+	require.NoError(t, commitsQueries.SaveCommitBranches(repo.FullName, "main", []string{human.SHA, bot.SHA}))
+
+	since, until := now.Add(-24*time.Hour), now.Add(time.Hour)
+	commits, err := commitsQueries.GetCommitsByRepoName(repo.FullName, "main", 10, 0, since, until)
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+
+	_, err = trackedRepositoriesQueries.SaveTrackedRepository(models.TrackedRepository{
+		RepositoryName: repo.FullName,
+		Enabled:        true,
+		ExcludeBots:    true,
+	})
+	require.NoError(t, err)
+
+	// bot commits are left out of the listings
+	commits, err = commitsQueries.GetCommitsByRepoName(repo.FullName, "main", 10, 0, since, until)
+	require.NoError(t, err)
+	require.Len(t, commits, 1)
+	require.Equal(t, human.SHA, commits[0].SHA)
+
+	total, err := commitsQueries.GetTotalCommitsByRepoName(repo.FullName, "", since, until)
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+
+	authors, err := commitsQueries.GetTopCommitAuthorsByRepo(repo.FullName, 10)
+	require.NoError(t, err)
+	require.Len(t, authors, 1)
+	require.Equal(t, "Jane", authors[0].Name)
+
+	between, err := commitsQueries.GetCommitsBetween(repo.FullName, "", bot.SHA, 10)
+	require.NoError(t, err)
+	require.Len(t, between, 1)
+	require.Equal(t, human.SHA, between[0].SHA)
+
+	// but still move the watermark of their branch
+	watermark, err := commitsQueries.GetCommitWatermark(repo.FullName, "main")
+	require.NoError(t, err)
+	require.Equal(t, bot.SHA, watermark.SHA)
+
+	commitsQueries.DeleteCommit(human.RepositoryName, human.SHA)
+	commitsQueries.DeleteCommit(bot.RepositoryName, bot.SHA)
+}
+
+func TestCommitsSharedAcrossRepositories(t *testing.T) {
+	repo := createRandomRepository()
+	_, err := repositoryQueries.InsertRepository(repo)
+	require.NoError(t, err)
+	defer repositoryQueries.DeleteRepository(repo.FullName)
+	fork := createRandomRepository()
+	_, err = repositoryQueries.InsertRepository(fork)
+	require.NoError(t, err)
+	defer repositoryQueries.DeleteRepository(fork.FullName)
+
+	// a fork shares the commits of its parent
+	now := time.Now().UTC()
+	base := models.Commit{SHA: uuid.New().String(), URL: "http://example.com/commit", Message: "Initial commit",
+		AuthorName: "Jane", AuthorDate: now.Add(-time.Hour), RepositoryName: repo.FullName}
+	head := models.Commit{SHA: uuid.New().String(), URL: "http://example.com/commit", Message: "Fix login",
+		AuthorName: "Jane", AuthorDate: now, RepositoryName: repo.FullName, Parents: []string{base.SHA}}
+	require.NoError(t, commitsQueries.SaveAllCommits([]models.Commit{base, head}))
+	require.NoError(t, commitsQueries.SaveCommitBranches(repo.FullName, "main", []string{base.SHA, head.SHA}))
+
+	forkedBase := base
+	forkedBase.RepositoryName = fork.FullName
+	forkedBase.URL = "http://example.com/fork/commit"
+	require.NoError(t, commitsQueries.SaveAllCommits([]models.Commit{forkedBase}))
+	require.NoError(t, commitsQueries.SaveCommitBranches(fork.FullName, "main", []string{base.SHA}))
+	inserted, err := commitsQueries.InsertMissingCommits([]models.Commit{forkedBase, head})
+	require.NoError(t, err)
+	require.Zero(t, inserted)
+
+	require.NoError(t, commitsQueries.SaveCommitDetails(models.CommitDetails{
+		RepositoryName: fork.FullName,
+		SHA:            base.SHA,
+		Stats:          models.CommitStats{Additions: 1, Total: 1},
+	}))
+
+	// each repository keeps its own copy of the commit
+	commit, err := commitsQueries.GetCommitBySHA(repo.FullName, base.SHA)
+	require.NoError(t, err)
+	require.Equal(t, base.URL, commit.URL)
+	require.Nil(t, commit.Stats)
+	commit, err = commitsQueries.GetCommitBySHA(fork.FullName, base.SHA)
+	require.NoError(t, err)
+	require.Equal(t, forkedBase.URL, commit.URL)
+	require.NotNil(t, commit.Stats)
+
+	total, err := commitsQueries.GetTotalCommitsByRepoName(repo.FullName, "main", time.Time{}, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 2, total)
+	total, err = commitsQueries.GetTotalCommitsByRepoName(fork.FullName, "main", time.Time{}, now.Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+
+	watermark, err := commitsQueries.GetCommitWatermark(repo.FullName, "main")
+	require.NoError(t, err)
+	require.Equal(t, head.SHA, watermark.SHA)
+	watermark, err = commitsQueries.GetCommitWatermark(fork.FullName, "main")
+	require.NoError(t, err)
+	require.Equal(t, base.SHA, watermark.SHA)
+
+	parents, err := commitsQueries.GetCommitParents(fork.FullName, head.SHA)
+	require.NoError(t, err)
+	require.Empty(t, parents)
+
+	shas, err := commitsQueries.GetCommitsWithoutStats(repo.FullName, 10)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{base.SHA, head.SHA}, shas)
+}
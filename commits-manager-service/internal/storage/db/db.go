@@ -5,3 +5,6 @@ import (
 )
 
 const dbTimeout = time.Second * 3
+
+// bulkTimeout bounds the transactions loading many rows at once.
+const bulkTimeout = time.Minute
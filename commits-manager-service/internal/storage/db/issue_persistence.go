@@ -0,0 +1,258 @@
+package db
+
+import (
+	"commits-manager-service/internal/constants/models"
+	"context"
+	"database/sql"
+	"log"
+	"time"
+)
+
+type IssueRepository interface {
+	SaveIssues(issues []models.Issue) error
+	GetIssuesByRepoName(repoName, state, label string, limit, offset int) ([]*models.Issue, error)
+	GetTotalIssuesByRepoName(repoName, state, label string) (int, error)
+	GetIssuesWatermark(repositoryName string) (time.Time, error)
+	GetIssueCloseDurations(repoName string, startDate, endDate time.Time) ([]time.Duration, error)
+	GetOpenIssueCreationTimes(repoName string) ([]time.Time, error)
+}
+
+type IssuePersistence struct {
+	db *sql.DB
+}
+
+// NewIssuePersistence creates an instance of the IssuePersistence.
+func NewIssuePersistence(dbPool *sql.DB) IssueRepository {
+	return &IssuePersistence{db: dbPool}
+}
+
+// SaveIssues inserts or updates issues and replaces their labels and
+// assignees.
+func (ip *IssuePersistence) SaveIssues(issues []models.Issue) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	tx, err := ip.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Error starting issues transaction:", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := `INSERT INTO issues (repository_name, number, title, author, state, comments, url, created_at, updated_at, closed_at)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+             ON CONFLICT (repository_name, number) DO UPDATE SET
+                 title = excluded.title, author = excluded.author, state = excluded.state, comments = excluded.comments,
+                 url = excluded.url, updated_at = excluded.updated_at, closed_at = excluded.closed_at
+             RETURNING id`
+
+	for _, issue := range issues {
+		var id int64
+		err := tx.QueryRowContext(ctx, stmt, issue.RepositoryName, issue.Number, issue.Title, issue.Author, issue.State, issue.Comments, issue.URL,
+			issue.CreatedAt, issue.UpdatedAt, issue.ClosedAt).Scan(&id)
+		if err != nil {
+			log.Println("Error saving issue:", err)
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, "DELETE FROM issue_labels WHERE issue_id = $1", id)
+		if err != nil {
+			log.Println("Error deleting issue labels:", err)
+			return err
+		}
+		for _, label := range issue.Labels {
+			_, err = tx.ExecContext(ctx, `INSERT INTO issue_labels (issue_id, name) VALUES ($1, $2) ON CONFLICT DO NOTHING`, id, label)
+			if err != nil {
+				log.Println("Error inserting issue label:", err)
+				return err
+			}
+		}
+
+		_, err = tx.ExecContext(ctx, "DELETE FROM issue_assignees WHERE issue_id = $1", id)
+		if err != nil {
+			log.Println("Error deleting issue assignees:", err)
+			return err
+		}
+		for _, login := range issue.Assignees {
+			_, err = tx.ExecContext(ctx, `INSERT INTO issue_assignees (issue_id, login) VALUES ($1, $2) ON CONFLICT DO NOTHING`, id, login)
+			if err != nil {
+				log.Println("Error inserting issue assignee:", err)
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetIssuesByRepoName returns a page of the issues of a repository, newest
+// first, only those in state and carrying label when they are not empty.
+func (ip *IssuePersistence) GetIssuesByRepoName(repoName, state, label string, limit, offset int) ([]*models.Issue, error) {
+	query := `
+        SELECT i.id, i.repository_name, i.number, i.title, i.author, i.state, i.comments, i.url, i.created_at, i.updated_at, i.closed_at
+        FROM issues i
+        WHERE i.repository_name = $1 AND (CAST($2 AS TEXT) = '' OR i.state = $2)
+          AND (CAST($3 AS TEXT) = '' OR EXISTS (SELECT 1 FROM issue_labels l WHERE l.issue_id = i.id AND l.name = $3))
+        ORDER BY i.created_at DESC, i.number DESC
+        LIMIT $4 OFFSET $5
+    `
+	rows, err := ip.db.Query(query, repoName, state, label, limit, offset)
+	if err != nil {
+		log.Println("Error querying issues by repository name:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	issues := make([]*models.Issue, 0)
+	for rows.Next() {
+		var issue models.Issue
+		var closedAt sql.NullTime
+		if err := rows.Scan(&issue.ID, &issue.RepositoryName, &issue.Number, &issue.Title, &issue.Author, &issue.State, &issue.Comments, &issue.URL,
+			&issue.CreatedAt, &issue.UpdatedAt, &closedAt); err != nil {
+			log.Println("Error scanning issue row:", err)
+			return nil, err
+		}
+		if closedAt.Valid {
+			issue.ClosedAt = &closedAt.Time
+		}
+		issues = append(issues, &issue)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Error iterating through issues:", err)
+		return nil, err
+	}
+
+	for _, issue := range issues {
+		issue.Labels, err = ip.getIssueNames("SELECT name FROM issue_labels WHERE issue_id = $1 ORDER BY name", issue.ID)
+		if err != nil {
+			return nil, err
+		}
+		issue.Assignees, err = ip.getIssueNames("SELECT login FROM issue_assignees WHERE issue_id = $1 ORDER BY login", issue.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return issues, nil
+}
+
+// getIssueNames returns the labels or assignees of an issue.
+func (ip *IssuePersistence) getIssueNames(query string, issueID int64) ([]string, error) {
+	rows, err := ip.db.Query(query, issueID)
+	if err != nil {
+		log.Println("Error querying issue labels or assignees:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	names := make([]string, 0)
+	for rows.Next() {
+		var name string
+		if err := rows.Scan(&name); err != nil {
+			log.Println("Error scanning issue label or assignee row:", err)
+			return nil, err
+		}
+		names = append(names, name)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Error iterating through issue labels or assignees:", err)
+		return nil, err
+	}
+
+	return names, nil
+}
+
+func (ip *IssuePersistence) GetTotalIssuesByRepoName(repoName, state, label string) (int, error) {
+	query := `
+        SELECT COUNT(*)
+        FROM issues i
+        WHERE i.repository_name = $1 AND (CAST($2 AS TEXT) = '' OR i.state = $2)
+          AND (CAST($3 AS TEXT) = '' OR EXISTS (SELECT 1 FROM issue_labels l WHERE l.issue_id = i.id AND l.name = $3))
+    `
+	var count int
+	err := ip.db.QueryRow(query, repoName, state, label).Scan(&count)
+	if err != nil {
+		log.Println("Error querying total issues by repository name:", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetIssuesWatermark returns when the most recently updated stored issue of
+// a repository was updated, the zero time when none is stored.
+func (ip *IssuePersistence) GetIssuesWatermark(repositoryName string) (time.Time, error) {
+	var updatedAt time.Time
+	query := `SELECT updated_at FROM issues WHERE repository_name = $1 ORDER BY updated_at DESC LIMIT 1`
+	err := ip.db.QueryRow(query, repositoryName).Scan(&updatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return time.Time{}, nil
+		}
+		log.Println("Error getting issues watermark:", err)
+		return time.Time{}, err
+	}
+	return updatedAt, nil
+}
+
+// GetIssueCloseDurations returns how long each issue of a repository closed
+// between startDate and endDate was open.
+func (ip *IssuePersistence) GetIssueCloseDurations(repoName string, startDate, endDate time.Time) ([]time.Duration, error) {
+	query := `
+        SELECT created_at, closed_at
+        FROM issues
+        WHERE repository_name = $1 AND closed_at IS NOT NULL AND closed_at >= $2 AND closed_at <= $3
+    `
+	rows, err := ip.db.Query(query, repoName, startDate, endDate)
+	if err != nil {
+		log.Println("Error querying closed issues:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	durations := make([]time.Duration, 0)
+	for rows.Next() {
+		var createdAt, closedAt time.Time
+		if err := rows.Scan(&createdAt, &closedAt); err != nil {
+			log.Println("Error scanning closed issue row:", err)
+			return nil, err
+		}
+		durations = append(durations, closedAt.Sub(createdAt))
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Error iterating through closed issues:", err)
+		return nil, err
+	}
+
+	return durations, nil
+}
+
+// GetOpenIssueCreationTimes returns when each open issue of a repository was
+// opened.
+func (ip *IssuePersistence) GetOpenIssueCreationTimes(repoName string) ([]time.Time, error) {
+	rows, err := ip.db.Query("SELECT created_at FROM issues WHERE repository_name = $1 AND state = 'open'", repoName)
+	if err != nil {
+		log.Println("Error querying open issues:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	createdAt := make([]time.Time, 0)
+	for rows.Next() {
+		var t time.Time
+		if err := rows.Scan(&t); err != nil {
+			log.Println("Error scanning open issue row:", err)
+			return nil, err
+		}
+		createdAt = append(createdAt, t)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Error iterating through open issues:", err)
+		return nil, err
+	}
+
+	return createdAt, nil
+}
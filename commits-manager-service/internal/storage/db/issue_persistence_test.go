@@ -0,0 +1,82 @@
+package db_test
+
+import (
+	"commits-manager-service/internal/constants"
+	"commits-manager-service/internal/constants/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveIssues(t *testing.T) {
+	repo := createRandomRepository()
+	_, err := repositoryQueries.InsertRepository(repo)
+	require.NoError(t, err)
+
+	watermark, err := issuesQueries.GetIssuesWatermark(repo.FullName)
+	require.NoError(t, err)
+	require.True(t, watermark.IsZero())
+
+	now := time.Now().UTC().Truncate(time.Second)
+	open := models.Issue{
+		RepositoryName: repo.FullName,
+		Number:         1,
+		Title:          "Crash on start",
+		Author:         "octocat",
+		State:          constants.ISSUE_STATE_OPEN,
+		Labels:         []string{"bug", "p1"},
+		Assignees:      []string{"hubot"},
+		CreatedAt:      now.Add(-48 * time.Hour),
+		UpdatedAt:      now.Add(-time.Hour),
+	}
+	closedAt := now.Add(-2 * time.Hour)
+	closed := models.Issue{
+		RepositoryName: repo.FullName,
+		Number:         2,
+		Title:          "Typo in docs",
+		Author:         "hubot",
+		State:          constants.ISSUE_STATE_CLOSED,
+		Labels:         []string{"docs"},
+		CreatedAt:      now.Add(-12 * time.Hour),
+		UpdatedAt:      closedAt,
+		ClosedAt:       &closedAt,
+	}
+	require.NoError(t, issuesQueries.SaveIssues([]models.Issue{open, closed}))
+
+	// updating replaces the labels
+	open.Labels = []string{"bug"}
+	open.UpdatedAt = now
+	require.NoError(t, issuesQueries.SaveIssues([]models.Issue{open}))
+
+	watermark, err = issuesQueries.GetIssuesWatermark(repo.FullName)
+	require.NoError(t, err)
+	require.True(t, now.Equal(watermark))
+
+	issues, err := issuesQueries.GetIssuesByRepoName(repo.FullName, "", "", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, issues, 2)
+	require.Equal(t, 2, issues[0].Number)
+	require.Equal(t, []string{"bug"}, issues[1].Labels)
+	require.Equal(t, []string{"hubot"}, issues[1].Assignees)
+
+	issues, err = issuesQueries.GetIssuesByRepoName(repo.FullName, "", "docs", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, issues, 1)
+	require.Equal(t, 2, issues[0].Number)
+
+	total, err := issuesQueries.GetTotalIssuesByRepoName(repo.FullName, constants.ISSUE_STATE_OPEN, "")
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+
+	durations, err := issuesQueries.GetIssueCloseDurations(repo.FullName, time.Time{}, time.Now())
+	require.NoError(t, err)
+	require.Equal(t, []time.Duration{10 * time.Hour}, durations)
+
+	createdAt, err := issuesQueries.GetOpenIssueCreationTimes(repo.FullName)
+	require.NoError(t, err)
+	require.Len(t, createdAt, 1)
+	require.True(t, open.CreatedAt.Equal(createdAt[0]))
+
+	repositoryQueries.DeleteRepository(repo.FullName)
+}
@@ -11,6 +11,14 @@ import (
 
 var repositoryQueries db.GitReposRepository
 var commitsQueries db.CommitRepository
+var pullRequestsQueries db.PullRequestRepository
+var issuesQueries db.IssueRepository
+var releasesQueries db.ReleaseRepository
+var workflowRunsQueries db.WorkflowRunRepository
+var archiveImportsQueries db.ArchiveImportRepository
+var pollSchedulesQueries db.PollScheduleRepository
+var backfillJobsQueries db.BackfillJobRepository
+var trackedRepositoriesQueries db.TrackedRepositoryRepository
 
 func TestMain(m *testing.M) {
 
@@ -28,7 +36,12 @@ func TestMain(m *testing.M) {
 	CREATE TABLE repositories
 	(
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		name VARCHAR(255) UNIQUE,
+		name VARCHAR(255) NOT NULL,
+		owner VARCHAR(255) NOT NULL DEFAULT '',
+		full_name VARCHAR(255) UNIQUE NOT NULL,
+		owner_type VARCHAR(50) NOT NULL DEFAULT 'User',
+		provider VARCHAR(50) NOT NULL DEFAULT 'github',
+		sync_status VARCHAR(50) NOT NULL DEFAULT 'active',
 		description TEXT,
 		url VARCHAR(255) NOT NULL,
 		language VARCHAR(255),
@@ -37,13 +50,14 @@ func TestMain(m *testing.M) {
 		open_issues_count INT NOT NULL,
 		watchers_count INT NOT NULL,
 		created_at TIMESTAMP NOT NULL,
-		updated_at TIMESTAMP NOT NULL
+		updated_at TIMESTAMP NOT NULL,
+		pushed_at TIMESTAMP NOT NULL
 	);
 	
 	CREATE TABLE commits
 	(
 		id INTEGER PRIMARY KEY AUTOINCREMENT,
-		sha VARCHAR(255) UNIQUE,
+		sha VARCHAR(255) NOT NULL,
 		url VARCHAR(255) NOT NULL,
 		message TEXT NOT NULL,
 		author_name VARCHAR(255) NOT NULL,
@@ -51,7 +65,227 @@ func TestMain(m *testing.M) {
 		created_at TIMESTAMP NOT NULL,
 		updated_at TIMESTAMP NOT NULL,
 		repository_name VARCHAR(255) NOT NULL,
-		FOREIGN KEY (repository_name) REFERENCES repositories(name) ON DELETE CASCADE
+		bot BOOLEAN NOT NULL DEFAULT FALSE,
+		UNIQUE (repository_name, sha),
+		FOREIGN KEY (repository_name) REFERENCES repositories(full_name) ON DELETE CASCADE
+	);
+
+	CREATE TABLE commit_stats
+	(
+		repository_name VARCHAR(255) NOT NULL,
+		sha VARCHAR(255) NOT NULL,
+		additions INT NOT NULL,
+		deletions INT NOT NULL,
+		total INT NOT NULL,
+		PRIMARY KEY (repository_name, sha),
+		FOREIGN KEY (repository_name, sha) REFERENCES commits(repository_name, sha) ON DELETE CASCADE
+	);
+
+	CREATE TABLE commit_files
+	(
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		repository_name VARCHAR(255) NOT NULL,
+		sha VARCHAR(255) NOT NULL,
+		path TEXT NOT NULL,
+		status VARCHAR(50) NOT NULL,
+		additions INT NOT NULL,
+		deletions INT NOT NULL,
+		FOREIGN KEY (repository_name, sha) REFERENCES commits(repository_name, sha) ON DELETE CASCADE
+	);
+
+	CREATE TABLE commit_details_failures
+	(
+		repository_name VARCHAR(255) NOT NULL,
+		sha VARCHAR(255) NOT NULL,
+		status_code INT NOT NULL DEFAULT 0,
+		message TEXT NOT NULL DEFAULT '',
+		failed_at TIMESTAMP NOT NULL,
+		PRIMARY KEY (repository_name, sha),
+		FOREIGN KEY (repository_name, sha) REFERENCES commits(repository_name, sha) ON DELETE CASCADE
+	);
+
+	CREATE TABLE commit_branches
+	(
+		repository_name VARCHAR(255) NOT NULL,
+		sha VARCHAR(255) NOT NULL,
+		branch VARCHAR(255) NOT NULL,
+		PRIMARY KEY (repository_name, sha, branch),
+		FOREIGN KEY (repository_name, sha) REFERENCES commits(repository_name, sha) ON DELETE CASCADE
+	);
+
+	CREATE TABLE commit_parents
+	(
+		repository_name VARCHAR(255) NOT NULL,
+		sha VARCHAR(255) NOT NULL,
+		parent_sha VARCHAR(255) NOT NULL,
+		position INT NOT NULL DEFAULT 0,
+		PRIMARY KEY (repository_name, sha, parent_sha),
+		FOREIGN KEY (repository_name, sha) REFERENCES commits(repository_name, sha) ON DELETE CASCADE
+	);
+
+	CREATE TABLE tags
+	(
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		repository_name VARCHAR(255) NOT NULL,
+		name VARCHAR(255) NOT NULL,
+		sha VARCHAR(255) NOT NULL,
+		UNIQUE (repository_name, name),
+		FOREIGN KEY (repository_name) REFERENCES repositories(full_name) ON DELETE CASCADE
+	);
+
+	CREATE TABLE releases
+	(
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		repository_name VARCHAR(255) NOT NULL,
+		tag_name VARCHAR(255) NOT NULL,
+		name TEXT NOT NULL,
+		target_sha VARCHAR(255) NOT NULL DEFAULT '',
+		draft BOOLEAN NOT NULL DEFAULT FALSE,
+		prerelease BOOLEAN NOT NULL DEFAULT FALSE,
+		author VARCHAR(255) NOT NULL,
+		url TEXT NOT NULL,
+		created_at TIMESTAMP NOT NULL,
+		published_at TIMESTAMP,
+		UNIQUE (repository_name, tag_name),
+		FOREIGN KEY (repository_name) REFERENCES repositories(full_name) ON DELETE CASCADE
+	);
+
+	CREATE TABLE pull_requests
+	(
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		repository_name VARCHAR(255) NOT NULL,
+		number INT NOT NULL,
+		title TEXT NOT NULL,
+		author VARCHAR(255) NOT NULL,
+		state VARCHAR(50) NOT NULL,
+		head_ref VARCHAR(255) NOT NULL,
+		base_ref VARCHAR(255) NOT NULL,
+		url TEXT NOT NULL,
+		created_at TIMESTAMP NOT NULL,
+		updated_at TIMESTAMP NOT NULL,
+		merged_at TIMESTAMP,
+		closed_at TIMESTAMP,
+		UNIQUE (repository_name, number),
+		FOREIGN KEY (repository_name) REFERENCES repositories(full_name) ON DELETE CASCADE
+	);
+
+	CREATE TABLE pull_request_commits
+	(
+		pull_request_id INTEGER NOT NULL,
+		sha VARCHAR(255) NOT NULL,
+		PRIMARY KEY (pull_request_id, sha),
+		FOREIGN KEY (pull_request_id) REFERENCES pull_requests(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE issues
+	(
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		repository_name VARCHAR(255) NOT NULL,
+		number INT NOT NULL,
+		title TEXT NOT NULL,
+		author VARCHAR(255) NOT NULL,
+		state VARCHAR(50) NOT NULL,
+		comments INT NOT NULL DEFAULT 0,
+		url TEXT NOT NULL,
+		created_at TIMESTAMP NOT NULL,
+		updated_at TIMESTAMP NOT NULL,
+		closed_at TIMESTAMP,
+		UNIQUE (repository_name, number),
+		FOREIGN KEY (repository_name) REFERENCES repositories(full_name) ON DELETE CASCADE
+	);
+
+	CREATE TABLE issue_labels
+	(
+		issue_id INTEGER NOT NULL,
+		name VARCHAR(255) NOT NULL,
+		PRIMARY KEY (issue_id, name),
+		FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE issue_assignees
+	(
+		issue_id INTEGER NOT NULL,
+		login VARCHAR(255) NOT NULL,
+		PRIMARY KEY (issue_id, login),
+		FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+	);
+
+	CREATE TABLE workflow_runs
+	(
+		id BIGINT PRIMARY KEY,
+		repository_name VARCHAR(255) NOT NULL,
+		workflow_id BIGINT NOT NULL,
+		workflow_name VARCHAR(255) NOT NULL,
+		head_sha VARCHAR(255) NOT NULL,
+		head_branch VARCHAR(255) NOT NULL,
+		event VARCHAR(50) NOT NULL,
+		status VARCHAR(50) NOT NULL,
+		conclusion VARCHAR(50) NOT NULL,
+		run_attempt INT NOT NULL DEFAULT 1,
+		url TEXT NOT NULL,
+		duration_seconds INT NOT NULL DEFAULT 0,
+		created_at TIMESTAMP NOT NULL,
+		updated_at TIMESTAMP NOT NULL,
+		run_started_at TIMESTAMP NOT NULL,
+		FOREIGN KEY (repository_name) REFERENCES repositories(full_name) ON DELETE CASCADE
+	);
+
+	CREATE TABLE commits_fetch_outcomes
+	(
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		repository_name VARCHAR(255) NOT NULL,
+		outcome VARCHAR(50) NOT NULL,
+		status_code INT NOT NULL DEFAULT 0,
+		message TEXT NOT NULL DEFAULT '',
+		total INT NOT NULL DEFAULT 0,
+		fetched_at TIMESTAMP NOT NULL,
+		FOREIGN KEY (repository_name) REFERENCES repositories(full_name) ON DELETE CASCADE
+	);
+
+	CREATE TABLE archive_imports
+	(
+		file_name VARCHAR(255) PRIMARY KEY,
+		events INT NOT NULL,
+		repositories INT NOT NULL,
+		commits INT NOT NULL,
+		imported_at TIMESTAMP NOT NULL
+	);
+	CREATE TABLE poll_schedules
+	(
+		repository_name VARCHAR(255) PRIMARY KEY,
+		interval_seconds BIGINT NOT NULL DEFAULT 0,
+		empty_fetches INT NOT NULL DEFAULT 0,
+		next_poll_at TIMESTAMP,
+		updated_at TIMESTAMP NOT NULL,
+		FOREIGN KEY (repository_name) REFERENCES repositories(full_name) ON DELETE CASCADE
+	);
+	CREATE TABLE backfill_jobs
+	(
+		id INTEGER PRIMARY KEY AUTOINCREMENT,
+		repository_name VARCHAR(255) NOT NULL DEFAULT '',
+		start_date TIMESTAMP NOT NULL,
+		end_date TIMESTAMP NOT NULL,
+		priority INT NOT NULL DEFAULT 0,
+		status VARCHAR(50) NOT NULL,
+		repositories_total INT NOT NULL DEFAULT 0,
+		repositories_done INT NOT NULL DEFAULT 0,
+		commits INT NOT NULL DEFAULT 0,
+		error TEXT NOT NULL DEFAULT '',
+		created_at TIMESTAMP NOT NULL,
+		started_at TIMESTAMP,
+		finished_at TIMESTAMP,
+		updated_at TIMESTAMP NOT NULL
+	);
+	CREATE TABLE tracked_repositories
+	(
+		repository_name VARCHAR(255) PRIMARY KEY,
+		enabled BOOLEAN NOT NULL DEFAULT TRUE,
+		sync_start_date TIMESTAMP,
+		branches TEXT,
+		poll_interval_seconds BIGINT NOT NULL DEFAULT 0,
+		exclude_bots BOOLEAN NOT NULL DEFAULT FALSE,
+		created_at TIMESTAMP NOT NULL,
+		updated_at TIMESTAMP NOT NULL
 	);`
 	_, err = testDB.Exec(createTablesQuery)
 	if err != nil {
@@ -60,6 +294,14 @@ func TestMain(m *testing.M) {
 
 	repositoryQueries = db.NewRepositoryPersistence(testDB)
 	commitsQueries = db.NewCommitPersistence(testDB)
+	pullRequestsQueries = db.NewPullRequestPersistence(testDB)
+	issuesQueries = db.NewIssuePersistence(testDB)
+	releasesQueries = db.NewReleasePersistence(testDB)
+	workflowRunsQueries = db.NewWorkflowRunPersistence(testDB)
+	archiveImportsQueries = db.NewArchiveImportPersistence(testDB)
+	pollSchedulesQueries = db.NewPollSchedulePersistence(testDB)
+	backfillJobsQueries = db.NewBackfillJobPersistence(testDB)
+	trackedRepositoriesQueries = db.NewTrackedRepositoryPersistence(testDB)
 
 	os.Exit(m.Run())
 }
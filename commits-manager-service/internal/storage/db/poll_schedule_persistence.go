@@ -0,0 +1,133 @@
+package db
+
+import (
+	"commits-manager-service/internal/constants"
+	"commits-manager-service/internal/constants/models"
+	"context"
+	"database/sql"
+	"log"
+	"time"
+)
+
+type PollScheduleRepository interface {
+	GetPollSchedules(repoName string, commitsSince time.Time) ([]*models.PollSchedule, error)
+	SavePollSchedule(schedule models.PollSchedule) error
+	PinPollInterval(repoName string, pinnedIntervalSeconds int64, nextPollAt time.Time) error
+}
+
+type PollSchedulePersistence struct {
+	db *sql.DB
+}
+
+// NewPollSchedulePersistence creates an instance of the PollSchedulePersistence.
+func NewPollSchedulePersistence(dbPool *sql.DB) PollScheduleRepository {
+	return &PollSchedulePersistence{db: dbPool}
+}
+
+// GetPollSchedules returns the poll schedules of the repositories still
+// polled, or of the given one, with the number of their commits authored
+// since commitsSince. Repositories never polled have no next poll time.
+func (pp *PollSchedulePersistence) GetPollSchedules(repoName string, commitsSince time.Time) ([]*models.PollSchedule, error) {
+	query := `
+        SELECT r.full_name, r.pushed_at,
+               (SELECT COUNT(*) FROM commits c WHERE c.repository_name = r.full_name AND c.author_date >= $1),
+               COALESCE(s.interval_seconds, 0), COALESCE(t.poll_interval_seconds, 0), COALESCE(s.empty_fetches, 0), s.next_poll_at
+        FROM repositories r
+        LEFT JOIN poll_schedules s ON s.repository_name = r.full_name
+        LEFT JOIN tracked_repositories t ON t.repository_name = r.full_name
+        WHERE r.sync_status <> $2 AND (CAST($3 AS TEXT) = '' OR r.full_name = $3)
+        ORDER BY r.full_name
+    `
+	rows, err := pp.db.Query(query, commitsSince, constants.SYNC_STATUS_NOT_FOUND, repoName)
+	if err != nil {
+		log.Println("Error querying poll schedules:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	schedules := make([]*models.PollSchedule, 0)
+	for rows.Next() {
+		var schedule models.PollSchedule
+		var nextPollAt sql.NullTime
+		if err := rows.Scan(&schedule.RepositoryName, &schedule.PushedAt, &schedule.RecentCommits, &schedule.IntervalSeconds,
+			&schedule.PinnedIntervalSeconds, &schedule.EmptyFetches, &nextPollAt); err != nil {
+			log.Println("Error scanning poll schedule row:", err)
+			return nil, err
+		}
+		if nextPollAt.Valid {
+			schedule.NextPollAt = &nextPollAt.Time
+		}
+		schedules = append(schedules, &schedule)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Error iterating through poll schedules:", err)
+		return nil, err
+	}
+
+	return schedules, nil
+}
+
+// SavePollSchedule stores the interval, empty fetches and next poll time the
+// commits monitor set for a repository.
+func (pp *PollSchedulePersistence) SavePollSchedule(schedule models.PollSchedule) error {
+	stmt := `INSERT INTO poll_schedules (repository_name, interval_seconds, empty_fetches, next_poll_at, updated_at)
+             VALUES ($1, $2, $3, $4, $5)
+             ON CONFLICT (repository_name) DO UPDATE SET
+                 interval_seconds = excluded.interval_seconds, empty_fetches = excluded.empty_fetches,
+                 next_poll_at = excluded.next_poll_at, updated_at = excluded.updated_at`
+	_, err := pp.db.Exec(stmt, schedule.RepositoryName, schedule.IntervalSeconds, schedule.EmptyFetches,
+		schedule.NextPollAt, time.Now().UTC())
+	if err != nil {
+		log.Println("Error saving poll schedule:", err)
+		return err
+	}
+	return nil
+}
+
+// PinPollInterval pins the poll interval of a repository, or unpins it with
+// 0, and moves its next poll to nextPollAt. The pinned interval is the poll
+// interval of the tracking settings of the repository, which are created
+// with the defaults when it has none.
+func (pp *PollSchedulePersistence) PinPollInterval(repoName string, pinnedIntervalSeconds int64, nextPollAt time.Time) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	tx, err := pp.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Error starting poll interval transaction:", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now().UTC()
+	// unpinning a repository without settings leaves it without any
+	stmt := `INSERT INTO tracked_repositories (repository_name, poll_interval_seconds, created_at, updated_at)
+             VALUES ($1, $2, $3, $3)
+             ON CONFLICT (repository_name) DO UPDATE SET
+                 poll_interval_seconds = excluded.poll_interval_seconds, updated_at = excluded.updated_at`
+	args := []any{repoName, pinnedIntervalSeconds, now}
+	if pinnedIntervalSeconds == 0 {
+		stmt = `UPDATE tracked_repositories SET poll_interval_seconds = 0, updated_at = $1 WHERE repository_name = $2`
+		args = []any{now, repoName}
+	}
+	if _, err := tx.ExecContext(ctx, stmt, args...); err != nil {
+		log.Println("Error pinning poll interval:", err)
+		return err
+	}
+
+	stmt = `INSERT INTO poll_schedules (repository_name, next_poll_at, updated_at)
+            VALUES ($1, $2, $3)
+            ON CONFLICT (repository_name) DO UPDATE SET
+                next_poll_at = excluded.next_poll_at, updated_at = excluded.updated_at`
+	if _, err := tx.ExecContext(ctx, stmt, repoName, nextPollAt, now); err != nil {
+		log.Println("Error moving next poll:", err)
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		log.Println("Error committing poll interval:", err)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,93 @@
+package db_test
+
+import (
+	"commits-manager-service/internal/constants/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollSchedules(t *testing.T) {
+	repo := createRandomRepository()
+	repo.PushedAt = time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+	_, err := repositoryQueries.InsertRepository(repo)
+	require.NoError(t, err)
+	defer repositoryQueries.DeleteRepository(repo.FullName)
+	defer trackedRepositoriesQueries.DeleteTrackedRepository(repo.FullName)
+
+	createRandomCommit(t, repo.FullName)
+	createRandomCommit(t, repo.FullName)
+	since := time.Now().UTC().Add(-24 * time.Hour)
+
+	// never polled
+	schedules, err := pollSchedulesQueries.GetPollSchedules(repo.FullName, since)
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+	require.Equal(t, repo.FullName, schedules[0].RepositoryName)
+	require.True(t, repo.PushedAt.Equal(schedules[0].PushedAt))
+	require.Equal(t, 2, schedules[0].RecentCommits)
+	require.Nil(t, schedules[0].NextPollAt)
+
+	require.NoError(t, pollSchedulesQueries.PinPollInterval(repo.FullName, 300, time.Now().UTC()))
+
+	nextPollAt := time.Now().UTC().Add(time.Hour).Truncate(time.Second)
+	require.NoError(t, pollSchedulesQueries.SavePollSchedule(models.PollSchedule{
+		RepositoryName:  repo.FullName,
+		IntervalSeconds: 3600,
+		EmptyFetches:    2,
+		NextPollAt:      &nextPollAt,
+	}))
+
+	// saving the monitor's schedule keeps the pinned interval
+	schedules, err = pollSchedulesQueries.GetPollSchedules(repo.FullName, since)
+	require.NoError(t, err)
+	require.Len(t, schedules, 1)
+	require.Equal(t, int64(3600), schedules[0].IntervalSeconds)
+	require.Equal(t, int64(300), schedules[0].PinnedIntervalSeconds)
+	require.Equal(t, 2, schedules[0].EmptyFetches)
+	require.NotNil(t, schedules[0].NextPollAt)
+	require.True(t, nextPollAt.Equal(*schedules[0].NextPollAt))
+
+	// the pinned interval is the poll interval of the tracking settings
+	settings, err := trackedRepositoriesQueries.GetTrackedRepository(repo.FullName)
+	require.NoError(t, err)
+	require.NotNil(t, settings)
+	require.True(t, settings.Enabled)
+	require.Equal(t, int64(300), settings.PollIntervalSeconds)
+
+	_, err = trackedRepositoriesQueries.SaveTrackedRepository(models.TrackedRepository{
+		RepositoryName:      repo.FullName,
+		Enabled:             true,
+		PollIntervalSeconds: 900,
+	})
+	require.NoError(t, err)
+	schedules, err = pollSchedulesQueries.GetPollSchedules(repo.FullName, since)
+	require.NoError(t, err)
+	require.Equal(t, int64(900), schedules[0].PinnedIntervalSeconds)
+
+	require.NoError(t, pollSchedulesQueries.PinPollInterval(repo.FullName, 0, time.Now().UTC()))
+	settings, err = trackedRepositoriesQueries.GetTrackedRepository(repo.FullName)
+	require.NoError(t, err)
+	require.Zero(t, settings.PollIntervalSeconds)
+
+	_, err = trackedRepositoriesQueries.DeleteTrackedRepository(repo.FullName)
+	require.NoError(t, err)
+	schedules, err = pollSchedulesQueries.GetPollSchedules(repo.FullName, since)
+	require.NoError(t, err)
+	require.Zero(t, schedules[0].PinnedIntervalSeconds)
+
+	// unpinning a repository without settings does not create them
+	require.NoError(t, pollSchedulesQueries.PinPollInterval(repo.FullName, 0, time.Now().UTC()))
+	settings, err = trackedRepositoriesQueries.GetTrackedRepository(repo.FullName)
+	require.NoError(t, err)
+	require.Nil(t, settings)
+
+	schedules, err = pollSchedulesQueries.GetPollSchedules(repo.FullName, time.Now().UTC().Add(time.Hour))
+	require.NoError(t, err)
+	require.Equal(t, 0, schedules[0].RecentCommits)
+
+	schedules, err = pollSchedulesQueries.GetPollSchedules("", since)
+	require.NoError(t, err)
+	require.NotEmpty(t, schedules)
+}
@@ -0,0 +1,179 @@
+package db
+
+import (
+	"commits-manager-service/internal/constants/models"
+	"context"
+	"database/sql"
+	"log"
+	"time"
+)
+
+type PullRequestRepository interface {
+	SavePullRequests(pullRequests []models.PullRequest) error
+	GetPullRequestsByRepoName(repoName, state string, limit, offset int) ([]*models.PullRequest, error)
+	GetTotalPullRequestsByRepoName(repoName, state string) (int, error)
+	GetPullRequestsWatermark(repositoryName string) (time.Time, error)
+}
+
+type PullRequestPersistence struct {
+	db *sql.DB
+}
+
+// NewPullRequestPersistence creates an instance of the PullRequestPersistence.
+func NewPullRequestPersistence(dbPool *sql.DB) PullRequestRepository {
+	return &PullRequestPersistence{db: dbPool}
+}
+
+// SavePullRequests inserts or updates pull requests and replaces the SHAs of
+// their commits.
+func (pp *PullRequestPersistence) SavePullRequests(pullRequests []models.PullRequest) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	tx, err := pp.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Error starting pull requests transaction:", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := `INSERT INTO pull_requests (repository_name, number, title, author, state, head_ref, base_ref, url, created_at, updated_at, merged_at, closed_at)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+             ON CONFLICT (repository_name, number) DO UPDATE SET
+                 title = excluded.title, author = excluded.author, state = excluded.state,
+                 head_ref = excluded.head_ref, base_ref = excluded.base_ref, url = excluded.url,
+                 updated_at = excluded.updated_at, merged_at = excluded.merged_at, closed_at = excluded.closed_at
+             RETURNING id`
+
+	for _, pr := range pullRequests {
+		var id int64
+		err := tx.QueryRowContext(ctx, stmt, pr.RepositoryName, pr.Number, pr.Title, pr.Author, pr.State, pr.HeadRef, pr.BaseRef, pr.URL,
+			pr.CreatedAt, pr.UpdatedAt, pr.MergedAt, pr.ClosedAt).Scan(&id)
+		if err != nil {
+			log.Println("Error saving pull request:", err)
+			return err
+		}
+
+		_, err = tx.ExecContext(ctx, "DELETE FROM pull_request_commits WHERE pull_request_id = $1", id)
+		if err != nil {
+			log.Println("Error deleting pull request commits:", err)
+			return err
+		}
+		for _, sha := range pr.CommitSHAs {
+			_, err = tx.ExecContext(ctx, `INSERT INTO pull_request_commits (pull_request_id, sha) VALUES ($1, $2) ON CONFLICT DO NOTHING`, id, sha)
+			if err != nil {
+				log.Println("Error inserting pull request commit:", err)
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetPullRequestsByRepoName returns a page of the pull requests of a
+// repository, newest first, only those in state when it is not empty.
+func (pp *PullRequestPersistence) GetPullRequestsByRepoName(repoName, state string, limit, offset int) ([]*models.PullRequest, error) {
+	query := `
+        SELECT id, repository_name, number, title, author, state, head_ref, base_ref, url, created_at, updated_at, merged_at, closed_at
+        FROM pull_requests
+        WHERE repository_name = $1 AND (CAST($2 AS TEXT) = '' OR state = $2)
+        ORDER BY created_at DESC, number DESC
+        LIMIT $3 OFFSET $4
+    `
+	rows, err := pp.db.Query(query, repoName, state, limit, offset)
+	if err != nil {
+		log.Println("Error querying pull requests by repository name:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	pullRequests := make([]*models.PullRequest, 0)
+	for rows.Next() {
+		var pr models.PullRequest
+		var mergedAt, closedAt sql.NullTime
+		if err := rows.Scan(&pr.ID, &pr.RepositoryName, &pr.Number, &pr.Title, &pr.Author, &pr.State, &pr.HeadRef, &pr.BaseRef, &pr.URL,
+			&pr.CreatedAt, &pr.UpdatedAt, &mergedAt, &closedAt); err != nil {
+			log.Println("Error scanning pull request row:", err)
+			return nil, err
+		}
+		if mergedAt.Valid {
+			pr.MergedAt = &mergedAt.Time
+		}
+		if closedAt.Valid {
+			pr.ClosedAt = &closedAt.Time
+		}
+		pullRequests = append(pullRequests, &pr)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Error iterating through pull requests:", err)
+		return nil, err
+	}
+
+	for _, pr := range pullRequests {
+		pr.CommitSHAs, err = pp.getPullRequestCommits(pr.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pullRequests, nil
+}
+
+func (pp *PullRequestPersistence) getPullRequestCommits(pullRequestID int64) ([]string, error) {
+	rows, err := pp.db.Query("SELECT sha FROM pull_request_commits WHERE pull_request_id = $1", pullRequestID)
+	if err != nil {
+		log.Println("Error querying pull request commits:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	shas := make([]string, 0)
+	for rows.Next() {
+		var sha string
+		if err := rows.Scan(&sha); err != nil {
+			log.Println("Error scanning pull request commit row:", err)
+			return nil, err
+		}
+		shas = append(shas, sha)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Error iterating through pull request commits:", err)
+		return nil, err
+	}
+
+	return shas, nil
+}
+
+func (pp *PullRequestPersistence) GetTotalPullRequestsByRepoName(repoName, state string) (int, error) {
+	query := `
+        SELECT COUNT(*)
+        FROM pull_requests
+        WHERE repository_name = $1 AND (CAST($2 AS TEXT) = '' OR state = $2)
+    `
+	var count int
+	err := pp.db.QueryRow(query, repoName, state).Scan(&count)
+	if err != nil {
+		log.Println("Error querying total pull requests by repository name:", err)
+		return 0, err
+	}
+	return count, nil
+}
+
+// GetPullRequestsWatermark returns when the most recently updated stored pull
+// request of a repository was updated, the zero time when none is stored.
+func (pp *PullRequestPersistence) GetPullRequestsWatermark(repositoryName string) (time.Time, error) {
+	var updatedAt time.Time
+	query := `SELECT updated_at FROM pull_requests WHERE repository_name = $1 ORDER BY updated_at DESC LIMIT 1`
+	err := pp.db.QueryRow(query, repositoryName).Scan(&updatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return time.Time{}, nil
+		}
+		log.Println("Error getting pull requests watermark:", err)
+		return time.Time{}, err
+	}
+	return updatedAt, nil
+}
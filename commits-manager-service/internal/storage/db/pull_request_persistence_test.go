@@ -0,0 +1,78 @@
+package db_test
+
+import (
+	"commits-manager-service/internal/constants"
+	"commits-manager-service/internal/constants/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSavePullRequests(t *testing.T) {
+	repo := createRandomRepository()
+	_, err := repositoryQueries.InsertRepository(repo)
+	require.NoError(t, err)
+
+	watermark, err := pullRequestsQueries.GetPullRequestsWatermark(repo.FullName)
+	require.NoError(t, err)
+	require.True(t, watermark.IsZero())
+
+	now := time.Now().UTC().Truncate(time.Second)
+	open := models.PullRequest{
+		RepositoryName: repo.FullName,
+		Number:         1,
+		Title:          "Add pull requests",
+		Author:         "octocat",
+		State:          constants.PULL_REQUEST_STATE_OPEN,
+		HeadRef:        "feature/pulls",
+		BaseRef:        "main",
+		CreatedAt:      now.Add(-2 * time.Hour),
+		UpdatedAt:      now.Add(-time.Hour),
+		CommitSHAs:     []string{"1111", "2222"},
+	}
+	merged := models.PullRequest{
+		RepositoryName: repo.FullName,
+		Number:         2,
+		Title:          "Fix the build",
+		Author:         "hubot",
+		State:          constants.PULL_REQUEST_STATE_MERGED,
+		HeadRef:        "fix/build",
+		BaseRef:        "main",
+		CreatedAt:      now.Add(-time.Hour),
+		UpdatedAt:      now.Add(-time.Hour),
+		MergedAt:       &now,
+		ClosedAt:       &now,
+		CommitSHAs:     []string{"3333"},
+	}
+	require.NoError(t, pullRequestsQueries.SavePullRequests([]models.PullRequest{open, merged}))
+
+	// updating replaces the commits
+	open.UpdatedAt = now
+	open.CommitSHAs = []string{"1111", "2222", "4444"}
+	require.NoError(t, pullRequestsQueries.SavePullRequests([]models.PullRequest{open}))
+
+	watermark, err = pullRequestsQueries.GetPullRequestsWatermark(repo.FullName)
+	require.NoError(t, err)
+	require.True(t, now.Equal(watermark))
+
+	pullRequests, err := pullRequestsQueries.GetPullRequestsByRepoName(repo.FullName, "", 10, 0)
+	require.NoError(t, err)
+	require.Len(t, pullRequests, 2)
+	require.Equal(t, 2, pullRequests[0].Number)
+	require.True(t, now.Equal(*pullRequests[0].MergedAt))
+	require.Equal(t, 1, pullRequests[1].Number)
+	require.Nil(t, pullRequests[1].MergedAt)
+	require.ElementsMatch(t, open.CommitSHAs, pullRequests[1].CommitSHAs)
+
+	pullRequests, err = pullRequestsQueries.GetPullRequestsByRepoName(repo.FullName, constants.PULL_REQUEST_STATE_OPEN, 10, 0)
+	require.NoError(t, err)
+	require.Len(t, pullRequests, 1)
+	require.Equal(t, "feature/pulls", pullRequests[0].HeadRef)
+
+	total, err := pullRequestsQueries.GetTotalPullRequestsByRepoName(repo.FullName, constants.PULL_REQUEST_STATE_MERGED)
+	require.NoError(t, err)
+	require.Equal(t, 1, total)
+
+	repositoryQueries.DeleteRepository(repo.FullName)
+}
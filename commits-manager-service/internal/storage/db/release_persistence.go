@@ -0,0 +1,166 @@
+package db
+
+import (
+	"commits-manager-service/internal/constants/models"
+	"context"
+	"database/sql"
+	"log"
+	"time"
+)
+
+type ReleaseRepository interface {
+	SaveTags(tags []models.Tag) error
+	SaveReleases(releases []models.Release) error
+	GetReleasesByRepoName(repoName string) ([]*models.Release, error)
+	GetRelease(repoName, tagName string) (*models.Release, error)
+	GetPreviousRelease(repoName string, before time.Time, includePrereleases bool) (*models.Release, error)
+}
+
+type ReleasePersistence struct {
+	db *sql.DB
+}
+
+// NewReleasePersistence creates an instance of the ReleasePersistence.
+func NewReleasePersistence(dbPool *sql.DB) ReleaseRepository {
+	return &ReleasePersistence{db: dbPool}
+}
+
+// SaveTags inserts tags or moves them to the commit they point to now.
+func (rp *ReleasePersistence) SaveTags(tags []models.Tag) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	tx, err := rp.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Error starting tags transaction:", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := `INSERT INTO tags (repository_name, name, sha) VALUES ($1, $2, $3)
+             ON CONFLICT (repository_name, name) DO UPDATE SET sha = excluded.sha`
+
+	for _, tag := range tags {
+		_, err := tx.ExecContext(ctx, stmt, tag.RepositoryName, tag.Name, tag.SHA)
+		if err != nil {
+			log.Println("Error saving tag:", err)
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SaveReleases inserts or updates releases. A release whose target SHA is
+// not known keeps the one stored before.
+func (rp *ReleasePersistence) SaveReleases(releases []models.Release) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	tx, err := rp.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Error starting releases transaction:", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := `INSERT INTO releases (repository_name, tag_name, name, target_sha, draft, prerelease, author, url, created_at, published_at)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+             ON CONFLICT (repository_name, tag_name) DO UPDATE SET
+                 name = excluded.name, draft = excluded.draft, prerelease = excluded.prerelease, author = excluded.author,
+                 url = excluded.url, created_at = excluded.created_at, published_at = excluded.published_at,
+                 target_sha = CASE WHEN excluded.target_sha = '' THEN releases.target_sha ELSE excluded.target_sha END`
+
+	for _, release := range releases {
+		_, err := tx.ExecContext(ctx, stmt, release.RepositoryName, release.TagName, release.Name, release.TargetSHA, release.Draft, release.Prerelease,
+			release.Author, release.URL, release.CreatedAt, release.PublishedAt)
+		if err != nil {
+			log.Println("Error saving release:", err)
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+const releaseColumns = `id, repository_name, tag_name, name, target_sha, draft, prerelease, author, url, created_at, published_at`
+
+// GetReleasesByRepoName returns the releases of a repository, newest first.
+func (rp *ReleasePersistence) GetReleasesByRepoName(repoName string) ([]*models.Release, error) {
+	query := `SELECT ` + releaseColumns + `
+        FROM releases
+        WHERE repository_name = $1
+        ORDER BY COALESCE(published_at, created_at) DESC, id DESC`
+	rows, err := rp.db.Query(query, repoName)
+	if err != nil {
+		log.Println("Error querying releases by repository name:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	releases := make([]*models.Release, 0)
+	for rows.Next() {
+		release, err := scanRelease(rows)
+		if err != nil {
+			log.Println("Error scanning release row:", err)
+			return nil, err
+		}
+		releases = append(releases, release)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Error iterating through releases:", err)
+		return nil, err
+	}
+
+	return releases, nil
+}
+
+// GetRelease returns the release of a repository for a tag, sql.ErrNoRows
+// when there is none.
+func (rp *ReleasePersistence) GetRelease(repoName, tagName string) (*models.Release, error) {
+	query := `SELECT ` + releaseColumns + ` FROM releases WHERE repository_name = $1 AND tag_name = $2`
+	release, err := scanRelease(rp.db.QueryRow(query, repoName, tagName))
+	if err != nil {
+		if err != sql.ErrNoRows {
+			log.Println("Error getting release:", err)
+		}
+		return nil, err
+	}
+	return release, nil
+}
+
+// GetPreviousRelease returns the latest published release of a repository
+// released before the given time, nil when there is none. Prereleases are
+// only considered when includePrereleases is set.
+func (rp *ReleasePersistence) GetPreviousRelease(repoName string, before time.Time, includePrereleases bool) (*models.Release, error) {
+	query := `SELECT ` + releaseColumns + `
+        FROM releases
+        WHERE repository_name = $1 AND draft = FALSE AND COALESCE(published_at, created_at) < $2
+          AND ($3 OR prerelease = FALSE)
+        ORDER BY COALESCE(published_at, created_at) DESC, id DESC
+        LIMIT 1`
+	release, err := scanRelease(rp.db.QueryRow(query, repoName, before, includePrereleases))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		log.Println("Error getting previous release:", err)
+		return nil, err
+	}
+	return release, nil
+}
+
+// scanRelease reads a row selected with releaseColumns.
+func scanRelease(row interface{ Scan(dest ...any) error }) (*models.Release, error) {
+	var release models.Release
+	var publishedAt sql.NullTime
+	if err := row.Scan(&release.ID, &release.RepositoryName, &release.TagName, &release.Name, &release.TargetSHA, &release.Draft, &release.Prerelease,
+		&release.Author, &release.URL, &release.CreatedAt, &publishedAt); err != nil {
+		return nil, err
+	}
+	if publishedAt.Valid {
+		release.PublishedAt = &publishedAt.Time
+	}
+	return &release, nil
+}
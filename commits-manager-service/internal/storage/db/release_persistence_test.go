@@ -0,0 +1,119 @@
+package db_test
+
+import (
+	"commits-manager-service/internal/constants/models"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReleaseCommits(t *testing.T) {
+	repo := createRandomRepository()
+	_, err := repositoryQueries.InsertRepository(repo)
+	require.NoError(t, err)
+
+	// a - b - c - m (merge of c and d) - e
+	//      \- d -/
+	now := time.Now().UTC().Truncate(time.Second)
+	history := []struct {
+		sha     string
+		parents []string
+	}{
+		{"a", nil},
+		{"b", []string{"a"}},
+		{"c", []string{"b"}},
+		{"d", []string{"b"}},
+		{"m", []string{"c", "d"}},
+		{"e", []string{"m"}},
+	}
+	commits := make([]models.Commit, len(history))
+	for i, h := range history {
+		commits[i] = models.Commit{
+			SHA:            repo.FullName + "-" + h.sha,
+			URL:            "https://github.com/" + repo.FullName,
+			Message:        h.sha,
+			AuthorName:     "octocat",
+			AuthorDate:     now.Add(time.Duration(i) * time.Minute),
+			CreatedAt:      now,
+			UpdatedAt:      now,
+			RepositoryName: repo.FullName,
+		}
+		for _, parent := range h.parents {
+			commits[i].Parents = append(commits[i].Parents, repo.FullName+"-"+parent)
+		}
+	}
+	require.NoError(t, commitsQueries.SaveAllCommits(commits))
+	// saving again keeps the parents
+	require.NoError(t, commitsQueries.SaveAllCommits(commits))
+
+	parents, err := commitsQueries.GetCommitParents(repo.FullName, repo.FullName+"-m")
+	require.NoError(t, err)
+	require.Equal(t, []string{repo.FullName + "-c", repo.FullName + "-d"}, parents)
+
+	between, err := commitsQueries.GetCommitsBetween(repo.FullName, repo.FullName+"-b", repo.FullName+"-e", 10)
+	require.NoError(t, err)
+	messages := make([]string, len(between))
+	for i, commit := range between {
+		messages[i] = commit.Message
+	}
+	require.Equal(t, []string{"e", "m", "d", "c"}, messages)
+
+	// the limit keeps the newest
+	between, err = commitsQueries.GetCommitsBetween(repo.FullName, repo.FullName+"-b", repo.FullName+"-e", 2)
+	require.NoError(t, err)
+	require.Len(t, between, 2)
+	require.Equal(t, "e", between[0].Message)
+
+	between, err = commitsQueries.GetCommitsBetween(repo.FullName, "", repo.FullName+"-b", 10)
+	require.NoError(t, err)
+	require.Len(t, between, 2)
+
+	require.NoError(t, releasesQueries.SaveTags([]models.Tag{
+		{RepositoryName: repo.FullName, Name: "v1.0", SHA: repo.FullName + "-b"},
+		{RepositoryName: repo.FullName, Name: "v2.0", SHA: repo.FullName + "-e"},
+	}))
+
+	v1Published := now.Add(time.Hour)
+	rcPublished := now.Add(2 * time.Hour)
+	v2Published := now.Add(3 * time.Hour)
+	require.NoError(t, releasesQueries.SaveReleases([]models.Release{
+		{RepositoryName: repo.FullName, TagName: "v1.0", Name: "1.0", TargetSHA: repo.FullName + "-b", Author: "octocat", CreatedAt: now, PublishedAt: &v1Published},
+		{RepositoryName: repo.FullName, TagName: "v2.0-rc1", Name: "2.0 rc1", Prerelease: true, Author: "octocat", CreatedAt: now, PublishedAt: &rcPublished},
+		{RepositoryName: repo.FullName, TagName: "v2.0", Name: "2.0", TargetSHA: repo.FullName + "-e", Author: "octocat", CreatedAt: now, PublishedAt: &v2Published},
+		{RepositoryName: repo.FullName, TagName: "v3.0", Name: "3.0", Draft: true, Author: "octocat", CreatedAt: now.Add(4 * time.Hour)},
+	}))
+	// a release saved without a target keeps the stored one
+	require.NoError(t, releasesQueries.SaveReleases([]models.Release{
+		{RepositoryName: repo.FullName, TagName: "v2.0", Name: "2.0 final", Author: "octocat", CreatedAt: now, PublishedAt: &v2Published},
+	}))
+
+	releases, err := releasesQueries.GetReleasesByRepoName(repo.FullName)
+	require.NoError(t, err)
+	require.Len(t, releases, 4)
+	require.Equal(t, "v3.0", releases[0].TagName)
+	require.Nil(t, releases[0].PublishedAt)
+
+	release, err := releasesQueries.GetRelease(repo.FullName, "v2.0")
+	require.NoError(t, err)
+	require.Equal(t, "2.0 final", release.Name)
+	require.Equal(t, repo.FullName+"-e", release.TargetSHA)
+
+	previous, err := releasesQueries.GetPreviousRelease(repo.FullName, release.ReleasedAt(), false)
+	require.NoError(t, err)
+	require.Equal(t, "v1.0", previous.TagName)
+
+	previous, err = releasesQueries.GetPreviousRelease(repo.FullName, release.ReleasedAt(), true)
+	require.NoError(t, err)
+	require.Equal(t, "v2.0-rc1", previous.TagName)
+
+	previous, err = releasesQueries.GetPreviousRelease(repo.FullName, v1Published, false)
+	require.NoError(t, err)
+	require.Nil(t, previous)
+
+	_, err = releasesQueries.GetRelease(repo.FullName, "v9.9")
+	require.ErrorIs(t, err, sql.ErrNoRows)
+
+	repositoryQueries.DeleteRepository(repo.FullName)
+}
@@ -1,25 +1,30 @@
 package db
 
 import (
+	"commits-manager-service/internal/constants"
 	"commits-manager-service/internal/constants/models"
 	"context"
 	"database/sql"
 	"log"
+	"time"
 )
 
 type GitReposRepository interface {
 	GetAllRepositories(limit, offset int) ([]*models.Repository, error)
 	GetAllRepositoryNames() ([]string, error)
-	GetRepositoryByName(name string) (*models.Repository, error)
+	GetRepositoryByFullName(fullName string) (*models.Repository, error)
+	GetRepositoryFullNames(name string) ([]string, error)
 	UpdateRepository(repo models.Repository) error
-	DeleteRepository(name string) error
+	DeleteRepository(fullName string) error
 	InsertRepository(repo models.Repository) (string, error)
 	SaveAllRepositories(repos []models.Repository) error
-	RepositoryExists(name string) (bool, error)
+	InsertMissingRepositories(repos []models.Repository) (int, error)
+	RepositoryExists(fullName string) (bool, error)
 	GetTotalRepositories() (int, error)
+	UpdateRepositorySyncStatus(fullName, status string) error
 
 	SaveReposFetchHistory(metadata models.ReposFetchHistory) error
-	GetLastReposFetchHistory() (*models.ReposFetchHistory, error)
+	GetLastReposFetchHistory(owner string) (*models.ReposFetchHistory, error)
 }
 type RepositoryPersistence struct {
 	db *sql.DB
@@ -33,7 +38,7 @@ func NewRepositoryPersistence(dbPool *sql.DB) GitReposRepository {
 // GetAllRepositories returns all repositories from the database.
 func (rp *RepositoryPersistence) GetAllRepositories(limit, offset int) ([]*models.Repository, error) {
 	query := `
-        SELECT id, name, description, url, language, forks_count, stars_count, open_issues_count, watchers_count, created_at, updated_at
+        SELECT id, name, owner, full_name, owner_type, provider, sync_status, description, url, language, forks_count, stars_count, open_issues_count, watchers_count, created_at, updated_at, pushed_at
         FROM repositories
         ORDER BY created_at DESC
         LIMIT $1 OFFSET $2
@@ -48,7 +53,7 @@ func (rp *RepositoryPersistence) GetAllRepositories(limit, offset int) ([]*model
 	repositories := make([]*models.Repository, 0)
 	for rows.Next() {
 		var repo models.Repository
-		if err := rows.Scan(&repo.ID, &repo.Name, &repo.Description, &repo.URL, &repo.Language, &repo.ForksCount, &repo.StarsCount, &repo.OpenIssuesCount, &repo.WatchersCount, &repo.CreatedAt, &repo.UpdatedAt); err != nil {
+		if err := rows.Scan(&repo.ID, &repo.Name, &repo.Owner, &repo.FullName, &repo.OwnerType, &repo.Provider, &repo.SyncStatus, &repo.Description, &repo.URL, &repo.Language, &repo.ForksCount, &repo.StarsCount, &repo.OpenIssuesCount, &repo.WatchersCount, &repo.CreatedAt, &repo.UpdatedAt, &repo.PushedAt); err != nil {
 			log.Println("Error scanning repository row:", err)
 			return nil, err
 		}
@@ -63,9 +68,10 @@ func (rp *RepositoryPersistence) GetAllRepositories(limit, offset int) ([]*model
 	return repositories, nil
 }
 
-// GetAllRepositoryNames returns the names of all repositories in the database.
+// GetAllRepositoryNames returns the full names (owner/name) of the
+// repositories in the database, leaving out the ones gone from GitHub.
 func (rp *RepositoryPersistence) GetAllRepositoryNames() ([]string, error) {
-	rows, err := rp.db.Query("SELECT name FROM repositories")
+	rows, err := rp.db.Query("SELECT full_name FROM repositories WHERE sync_status <> $1", constants.SYNC_STATUS_NOT_FOUND)
 	if err != nil {
 		log.Println("Error querying repository names:", err)
 		return nil, err
@@ -90,22 +96,51 @@ func (rp *RepositoryPersistence) GetAllRepositoryNames() ([]string, error) {
 	return names, nil
 }
 
-// GetRepositoryByName returns a repository from the database by ID.
-func (rp *RepositoryPersistence) GetRepositoryByName(name string) (*models.Repository, error) {
+// GetRepositoryByFullName returns a repository from the database by its
+// full name.
+func (rp *RepositoryPersistence) GetRepositoryByFullName(fullName string) (*models.Repository, error) {
 	var repo models.Repository
-	err := rp.db.QueryRow("SELECT id, name, description, url, language, forks_count, stars_count, open_issues_count, watchers_count, created_at, updated_at FROM repositories WHERE name = $1", name).
-		Scan(&repo.ID, &repo.Name, &repo.Description, &repo.URL, &repo.Language, &repo.ForksCount, &repo.StarsCount, &repo.OpenIssuesCount, &repo.WatchersCount, &repo.CreatedAt, &repo.UpdatedAt)
+	err := rp.db.QueryRow("SELECT id, name, owner, full_name, owner_type, provider, sync_status, description, url, language, forks_count, stars_count, open_issues_count, watchers_count, created_at, updated_at, pushed_at FROM repositories WHERE full_name = $1", fullName).
+		Scan(&repo.ID, &repo.Name, &repo.Owner, &repo.FullName, &repo.OwnerType, &repo.Provider, &repo.SyncStatus, &repo.Description, &repo.URL, &repo.Language, &repo.ForksCount, &repo.StarsCount, &repo.OpenIssuesCount, &repo.WatchersCount, &repo.CreatedAt, &repo.UpdatedAt, &repo.PushedAt)
 	if err != nil {
-		log.Println("Error querying repository by ID:", err)
+		log.Println("Error querying repository by full name:", err)
 		return nil, err
 	}
 	return &repo, nil
 }
 
+// GetRepositoryFullNames returns the full names of the repositories with the
+// given name or full name.
+func (rp *RepositoryPersistence) GetRepositoryFullNames(name string) ([]string, error) {
+	rows, err := rp.db.Query("SELECT full_name FROM repositories WHERE full_name = $1 OR name = $1 ORDER BY full_name", name)
+	if err != nil {
+		log.Println("Error querying repository full names:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	fullNames := make([]string, 0)
+	for rows.Next() {
+		var fullName string
+		if err := rows.Scan(&fullName); err != nil {
+			log.Println("Error scanning repository full name row:", err)
+			return nil, err
+		}
+		fullNames = append(fullNames, fullName)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Error iterating through repository full names:", err)
+		return nil, err
+	}
+
+	return fullNames, nil
+}
+
 // UpdateRepository updates a repository in the database.
 func (rp *RepositoryPersistence) UpdateRepository(repo models.Repository) error {
-	_, err := rp.db.Exec("UPDATE repositories SET  description = $1, url = $2, language = $3, forks_count = $4, stars_count = $5, open_issues_count = $6, watchers_count = $7, created_at = $8, updated_at = $9 WHERE name = $10",
-		repo.Description, repo.URL, repo.Language, repo.ForksCount, repo.StarsCount, repo.OpenIssuesCount, repo.WatchersCount, repo.CreatedAt, repo.UpdatedAt, repo.Name)
+	_, err := rp.db.Exec("UPDATE repositories SET  name = $1, owner = $2, owner_type = $3, provider = $4, description = $5, url = $6, language = $7, forks_count = $8, stars_count = $9, open_issues_count = $10, watchers_count = $11, created_at = $12, updated_at = $13, pushed_at = $14 WHERE full_name = $15",
+		repo.Name, repo.Owner, repo.OwnerType, providerOf(repo), repo.Description, repo.URL, repo.Language, repo.ForksCount, repo.StarsCount, repo.OpenIssuesCount, repo.WatchersCount, repo.CreatedAt, repo.UpdatedAt, pushedAtOf(repo), repo.FullName)
 	if err != nil {
 		log.Println("Error updating repository:", err)
 		return err
@@ -113,9 +148,19 @@ func (rp *RepositoryPersistence) UpdateRepository(repo models.Repository) error
 	return nil
 }
 
+// UpdateRepositorySyncStatus sets the sync status of a repository.
+func (rp *RepositoryPersistence) UpdateRepositorySyncStatus(fullName, status string) error {
+	_, err := rp.db.Exec("UPDATE repositories SET sync_status = $1 WHERE full_name = $2", status, fullName)
+	if err != nil {
+		log.Println("Error updating repository sync status:", err)
+		return err
+	}
+	return nil
+}
+
 // DeleteRepository deletes a repository from the database.
-func (rp *RepositoryPersistence) DeleteRepository(name string) error {
-	_, err := rp.db.Exec("DELETE FROM repositories WHERE name = $1", name)
+func (rp *RepositoryPersistence) DeleteRepository(fullName string) error {
+	_, err := rp.db.Exec("DELETE FROM repositories WHERE full_name = $1", fullName)
 	if err != nil {
 		log.Println("Error deleting repository:", err)
 		return err
@@ -128,22 +173,22 @@ func (rp *RepositoryPersistence) InsertRepository(repo models.Repository) (strin
 	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
 	defer cancel()
 
-	stmt := `INSERT INTO repositories (name, description, url, language, forks_count, stars_count, open_issues_count, watchers_count, created_at, updated_at) 
-             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) returning name`
+	stmt := `INSERT INTO repositories (name, owner, full_name, owner_type, provider, description, url, language, forks_count, stars_count, open_issues_count, watchers_count, created_at, updated_at, pushed_at) 
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) returning full_name`
 
-	var name string
-	err := rp.db.QueryRowContext(ctx, stmt, repo.Name, repo.Description, repo.URL, repo.Language, repo.ForksCount, repo.StarsCount, repo.OpenIssuesCount, repo.WatchersCount, repo.CreatedAt, repo.UpdatedAt).Scan(&name)
+	var fullName string
+	err := rp.db.QueryRowContext(ctx, stmt, repo.Name, repo.Owner, repo.FullName, repo.OwnerType, providerOf(repo), repo.Description, repo.URL, repo.Language, repo.ForksCount, repo.StarsCount, repo.OpenIssuesCount, repo.WatchersCount, repo.CreatedAt, repo.UpdatedAt, pushedAtOf(repo)).Scan(&fullName)
 	if err != nil {
 		log.Println("Error inserting repository:", err)
 		return "", err
 	}
-	return name, nil
+	return fullName, nil
 }
 
 // SaveAllRepositories inserts or updates multiple repositories in the database.
 func (rp *RepositoryPersistence) SaveAllRepositories(repos []models.Repository) error {
 	for _, repo := range repos {
-		exists, err := rp.RepositoryExists(repo.Name)
+		exists, err := rp.RepositoryExists(repo.FullName)
 		if err != nil {
 			return err
 		}
@@ -160,18 +205,68 @@ func (rp *RepositoryPersistence) SaveAllRepositories(repos []models.Repository)
 	return nil
 }
 
+// InsertMissingRepositories inserts the repositories not stored yet and
+// keeps the stored ones as they are. It returns the number inserted.
+func (rp *RepositoryPersistence) InsertMissingRepositories(repos []models.Repository) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), bulkTimeout)
+	defer cancel()
+
+	tx, err := rp.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Error starting repositories transaction:", err)
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	stmt := `INSERT INTO repositories (name, owner, full_name, owner_type, provider, description, url, language, forks_count, stars_count, open_issues_count, watchers_count, created_at, updated_at, pushed_at)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+             ON CONFLICT (full_name) DO NOTHING`
+
+	var inserted int
+	for _, repo := range repos {
+		result, err := tx.ExecContext(ctx, stmt, repo.Name, repo.Owner, repo.FullName, repo.OwnerType, providerOf(repo), repo.Description, repo.URL, repo.Language,
+			repo.ForksCount, repo.StarsCount, repo.OpenIssuesCount, repo.WatchersCount, repo.CreatedAt, repo.UpdatedAt, pushedAtOf(repo))
+		if err != nil {
+			log.Println("Error inserting repository:", err)
+			return 0, err
+		}
+		affected, _ := result.RowsAffected()
+		inserted += int(affected)
+	}
+
+	return inserted, tx.Commit()
+}
+
+// providerOf returns the provider hosting a repository, GitHub unless it
+// says otherwise.
+func providerOf(repo models.Repository) string {
+	if repo.Provider == "" {
+		return constants.PROVIDER_GITHUB
+	}
+	return repo.Provider
+}
+
+// pushedAtOf returns when a repository was last pushed to, its last update
+// when the provider did not tell.
+func pushedAtOf(repo models.Repository) time.Time {
+	if repo.PushedAt.IsZero() {
+		return repo.UpdatedAt
+	}
+	return repo.PushedAt
+}
+
 // RepositoryExists checks if a repository exists in the database.
-func (rp *RepositoryPersistence) RepositoryExists(name string) (bool, error) {
+func (rp *RepositoryPersistence) RepositoryExists(fullName string) (bool, error) {
 	var exists bool
-	query := "SELECT EXISTS (SELECT 1 FROM repositories WHERE name = $1)"
-	err := rp.db.QueryRow(query, name).Scan(&exists)
+	query := "SELECT EXISTS (SELECT 1 FROM repositories WHERE full_name = $1)"
+	err := rp.db.QueryRow(query, fullName).Scan(&exists)
 	return exists, err
 }
 
 // SaveReposFetchHistory saves metadata for fetching repositories.
 func (rp *RepositoryPersistence) SaveReposFetchHistory(metadata models.ReposFetchHistory) error {
-	stmt := `INSERT INTO repos_fetch_history (total, last_page, fetched_at) VALUES ($1, $2, $3)`
-	_, err := rp.db.Exec(stmt, metadata.Total, metadata.LastPage, metadata.FetchedAt)
+	stmt := `INSERT INTO repos_fetch_history (owner, total, last_page, fetched_at) VALUES ($1, $2, $3, $4)`
+	_, err := rp.db.Exec(stmt, metadata.Owner, metadata.Total, metadata.LastPage, metadata.FetchedAt)
 	if err != nil {
 		log.Println("Error inserting fetch repos metadata:", err)
 		return err
@@ -179,14 +274,14 @@ func (rp *RepositoryPersistence) SaveReposFetchHistory(metadata models.ReposFetc
 	return nil
 }
 
-// GetLastReposFetchHistory returns the last repository fetch time.
-func (rp *RepositoryPersistence) GetLastReposFetchHistory() (*models.ReposFetchHistory, error) {
-	var reposFetchHistory models.ReposFetchHistory
+// GetLastReposFetchHistory returns the last repository fetch time of an owner.
+func (rp *RepositoryPersistence) GetLastReposFetchHistory(owner string) (*models.ReposFetchHistory, error) {
+	reposFetchHistory := models.ReposFetchHistory{Owner: owner}
 	query := `SELECT id, total, last_page, fetched_at 
 	          FROM repos_fetch_history 
-	          WHERE last_page = (SELECT MAX(last_page) FROM repos_fetch_history) 
+	          WHERE owner = $1 AND last_page = (SELECT MAX(last_page) FROM repos_fetch_history WHERE owner = $1) 
 	          ORDER BY fetched_at DESC LIMIT 1`
-	err := rp.db.QueryRow(query).Scan(
+	err := rp.db.QueryRow(query, owner).Scan(
 		&reposFetchHistory.ID, &reposFetchHistory.Total, &reposFetchHistory.LastPage, &reposFetchHistory.FetchedAt,
 	)
 	if err != nil {
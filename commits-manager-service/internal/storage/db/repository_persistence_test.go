@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	"commits-manager-service/internal/constants"
 	"commits-manager-service/internal/constants/models"
 	"github.com/google/uuid"
 
@@ -14,8 +15,12 @@ import (
 
 
 func createRandomRepository() models.Repository {
+	name := "test-repo-" + uuid.New().String()
 	return models.Repository{
-		Name:            "test-repo-" + uuid.New().String(),
+		Name:            name,
+		Owner:           "test-owner",
+		FullName:        "test-owner/" + name,
+		OwnerType:       "Organization",
 		Description:     "Test description",
 		URL:             "https://github.com/test/test-repo",
 		Language:        "Go",
@@ -33,14 +38,16 @@ func TestInsertRepository(t *testing.T) {
 
 	insertedName, err := repositoryQueries.InsertRepository(repo)
 	require.NoError(t, err)
-	require.Equal(t, repo.Name, insertedName)
+	require.Equal(t, repo.FullName, insertedName)
 
-	retrievedRepo, err := repositoryQueries.GetRepositoryByName(repo.Name)
+	retrievedRepo, err := repositoryQueries.GetRepositoryByFullName(repo.FullName)
 	require.NoError(t, err)
 	require.Equal(t, repo.Name, retrievedRepo.Name)
+	require.Equal(t, repo.Owner, retrievedRepo.Owner)
+	require.Equal(t, repo.OwnerType, retrievedRepo.OwnerType)
 	require.Equal(t, repo.Description, retrievedRepo.Description)
 
-	repositoryQueries.DeleteRepository(retrievedRepo.Name)
+	repositoryQueries.DeleteRepository(retrievedRepo.FullName)
 }
 
 func TestGetAllRepositories(t *testing.T) {
@@ -56,8 +63,8 @@ func TestGetAllRepositories(t *testing.T) {
 	require.NoError(t, err)
 	require.Len(t, repos, 2)
 
-	repositoryQueries.DeleteRepository(repo1.Name)
-	repositoryQueries.DeleteRepository(repo2.Name)
+	repositoryQueries.DeleteRepository(repo1.FullName)
+	repositoryQueries.DeleteRepository(repo2.FullName)
 
 }
 
@@ -71,10 +78,10 @@ func TestUpdateRepository(t *testing.T) {
 	err = repositoryQueries.UpdateRepository(repo)
 	require.NoError(t, err)
 
-	updatedRepo, err := repositoryQueries.GetRepositoryByName(repo.Name)
+	updatedRepo, err := repositoryQueries.GetRepositoryByFullName(repo.FullName)
 	require.NoError(t, err)
 	require.Equal(t, "Updated description", updatedRepo.Description)
-	repositoryQueries.DeleteRepository(repo.Name)
+	repositoryQueries.DeleteRepository(repo.FullName)
 }
 
 func TestDeleteRepository(t *testing.T) {
@@ -83,10 +90,10 @@ func TestDeleteRepository(t *testing.T) {
 	_, err := repositoryQueries.InsertRepository(repo)
 	require.NoError(t, err)
 
-	err = repositoryQueries.DeleteRepository(repo.Name)
+	err = repositoryQueries.DeleteRepository(repo.FullName)
 	require.NoError(t, err)
 
-	deletedRepo, err := repositoryQueries.GetRepositoryByName(repo.Name)
+	deletedRepo, err := repositoryQueries.GetRepositoryByFullName(repo.FullName)
 	require.Error(t, err)
 	require.Nil(t, deletedRepo)
 }
@@ -109,11 +116,110 @@ func TestSaveAllRepositories(t *testing.T) {
 	err = repositoryQueries.SaveAllRepositories([]models.Repository{repo1, repo2})
 	require.NoError(t, err)
 
-	updatedRepo1, err := repositoryQueries.GetRepositoryByName(repo1.Name)
+	updatedRepo1, err := repositoryQueries.GetRepositoryByFullName(repo1.FullName)
 	require.NoError(t, err)
 	require.Equal(t, "Updated description 1", updatedRepo1.Description)
 
-	updatedRepo2, err := repositoryQueries.GetRepositoryByName(repo2.Name)
+	updatedRepo2, err := repositoryQueries.GetRepositoryByFullName(repo2.FullName)
 	require.NoError(t, err)
 	require.Equal(t, "Updated description 2", updatedRepo2.Description)
 }
+
+func TestGetRepositoryFullNames(t *testing.T) {
+	repo1 := createRandomRepository()
+	repo2 := repo1
+	repo2.Owner = "other-owner"
+	repo2.FullName = "other-owner/" + repo1.Name
+
+	err := repositoryQueries.SaveAllRepositories([]models.Repository{repo1, repo2})
+	require.NoError(t, err)
+
+	fullNames, err := repositoryQueries.GetRepositoryFullNames(repo1.Name)
+	require.NoError(t, err)
+	require.Equal(t, []string{repo2.FullName, repo1.FullName}, fullNames)
+
+	fullNames, err = repositoryQueries.GetRepositoryFullNames(repo1.FullName)
+	require.NoError(t, err)
+	require.Equal(t, []string{repo1.FullName}, fullNames)
+
+	repositoryQueries.DeleteRepository(repo1.FullName)
+	repositoryQueries.DeleteRepository(repo2.FullName)
+}
+
+func TestUpdateRepositorySyncStatus(t *testing.T) {
+	repo := createRandomRepository()
+	_, err := repositoryQueries.InsertRepository(repo)
+	require.NoError(t, err)
+
+	stored, err := repositoryQueries.GetRepositoryByFullName(repo.FullName)
+	require.NoError(t, err)
+	require.Equal(t, constants.SYNC_STATUS_ACTIVE, stored.SyncStatus)
+
+	err = repositoryQueries.UpdateRepositorySyncStatus(repo.FullName, constants.SYNC_STATUS_NOT_FOUND)
+	require.NoError(t, err)
+
+	stored, err = repositoryQueries.GetRepositoryByFullName(repo.FullName)
+	require.NoError(t, err)
+	require.Equal(t, constants.SYNC_STATUS_NOT_FOUND, stored.SyncStatus)
+
+	names, err := repositoryQueries.GetAllRepositoryNames()
+	require.NoError(t, err)
+	require.NotContains(t, names, repo.FullName)
+
+	repositoryQueries.DeleteRepository(repo.FullName)
+}
+
+func TestRepositoryProvider(t *testing.T) {
+	repo1 := createRandomRepository()
+	repo2 := createRandomRepository()
+	repo2.Provider = constants.PROVIDER_GITLAB
+
+	err := repositoryQueries.SaveAllRepositories([]models.Repository{repo1, repo2})
+	require.NoError(t, err)
+
+	stored, err := repositoryQueries.GetRepositoryByFullName(repo1.FullName)
+	require.NoError(t, err)
+	require.Equal(t, constants.PROVIDER_GITHUB, stored.Provider)
+
+	stored, err = repositoryQueries.GetRepositoryByFullName(repo2.FullName)
+	require.NoError(t, err)
+	require.Equal(t, constants.PROVIDER_GITLAB, stored.Provider)
+
+	// updating a repository updates its provider
+	repo1.Provider = constants.PROVIDER_GITLAB
+	err = repositoryQueries.SaveAllRepositories([]models.Repository{repo1})
+	require.NoError(t, err)
+
+	stored, err = repositoryQueries.GetRepositoryByFullName(repo1.FullName)
+	require.NoError(t, err)
+	require.Equal(t, constants.PROVIDER_GITLAB, stored.Provider)
+
+	repositoryQueries.DeleteRepository(repo1.FullName)
+	repositoryQueries.DeleteRepository(repo2.FullName)
+}
+
+func TestInsertMissingRepositories(t *testing.T) {
+	stored := createRandomRepository()
+	_, err := repositoryQueries.InsertRepository(stored)
+	require.NoError(t, err)
+
+	// an archived copy of the stored repository does not replace it
+	archived := stored
+	archived.Description = "Archived description"
+	missing := createRandomRepository()
+
+	inserted, err := repositoryQueries.InsertMissingRepositories([]models.Repository{archived, missing})
+	require.NoError(t, err)
+	require.Equal(t, 1, inserted)
+
+	repo, err := repositoryQueries.GetRepositoryByFullName(stored.FullName)
+	require.NoError(t, err)
+	require.Equal(t, stored.Description, repo.Description)
+
+	exists, err := repositoryQueries.RepositoryExists(missing.FullName)
+	require.NoError(t, err)
+	require.True(t, exists)
+
+	require.NoError(t, repositoryQueries.DeleteRepository(stored.FullName))
+	require.NoError(t, repositoryQueries.DeleteRepository(missing.FullName))
+}
@@ -0,0 +1,139 @@
+package db
+
+import (
+	"commits-manager-service/internal/constants/models"
+	"database/sql"
+	"errors"
+	"log"
+	"strings"
+	"time"
+)
+
+type TrackedRepositoryRepository interface {
+	GetTrackedRepositories() ([]*models.TrackedRepository, error)
+	GetTrackedRepository(repoName string) (*models.TrackedRepository, error)
+	SaveTrackedRepository(settings models.TrackedRepository) (*models.TrackedRepository, error)
+	DeleteTrackedRepository(repoName string) (bool, error)
+}
+
+type TrackedRepositoryPersistence struct {
+	db *sql.DB
+}
+
+// NewTrackedRepositoryPersistence creates an instance of the TrackedRepositoryPersistence.
+func NewTrackedRepositoryPersistence(dbPool *sql.DB) TrackedRepositoryRepository {
+	return &TrackedRepositoryPersistence{db: dbPool}
+}
+
+const trackedRepositoryColumns = `repository_name, enabled, sync_start_date, branches, poll_interval_seconds, exclude_bots,
+        created_at, updated_at`
+
+// GetTrackedRepositories returns the settings of all repositories that have
+// some.
+func (tp *TrackedRepositoryPersistence) GetTrackedRepositories() ([]*models.TrackedRepository, error) {
+	query := `SELECT ` + trackedRepositoryColumns + ` FROM tracked_repositories ORDER BY repository_name`
+	rows, err := tp.db.Query(query)
+	if err != nil {
+		log.Println("Error querying tracked repositories:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	trackedRepositories := make([]*models.TrackedRepository, 0)
+	for rows.Next() {
+		settings, err := scanTrackedRepository(rows)
+		if err != nil {
+			log.Println("Error scanning tracked repository row:", err)
+			return nil, err
+		}
+		trackedRepositories = append(trackedRepositories, settings)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Error iterating through tracked repositories:", err)
+		return nil, err
+	}
+
+	return trackedRepositories, nil
+}
+
+// GetTrackedRepository returns the settings of a repository, or nil when it
+// has none.
+func (tp *TrackedRepositoryPersistence) GetTrackedRepository(repoName string) (*models.TrackedRepository, error) {
+	query := `SELECT ` + trackedRepositoryColumns + ` FROM tracked_repositories WHERE repository_name = $1`
+	settings, err := scanTrackedRepository(tp.db.QueryRow(query, repoName))
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Println("Error querying tracked repository:", err)
+		return nil, err
+	}
+	return settings, nil
+}
+
+// SaveTrackedRepository creates or replaces the settings of a repository and
+// returns them as stored.
+func (tp *TrackedRepositoryPersistence) SaveTrackedRepository(settings models.TrackedRepository) (*models.TrackedRepository, error) {
+	var syncStartDate *time.Time
+	if settings.SyncStartDate != nil {
+		date := settings.SyncStartDate.UTC()
+		syncStartDate = &date
+	}
+	// NULL keeps the configured branches, '' syncs the default branch only
+	var branches *string
+	if settings.Branches != nil {
+		joined := strings.Join(settings.Branches, ",")
+		branches = &joined
+	}
+	stmt := `INSERT INTO tracked_repositories (repository_name, enabled, sync_start_date, branches, poll_interval_seconds,
+                 exclude_bots, created_at, updated_at)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $7)
+             ON CONFLICT (repository_name) DO UPDATE SET
+                 enabled = excluded.enabled, sync_start_date = excluded.sync_start_date, branches = excluded.branches,
+                 poll_interval_seconds = excluded.poll_interval_seconds, exclude_bots = excluded.exclude_bots,
+                 updated_at = excluded.updated_at`
+	_, err := tp.db.Exec(stmt, settings.RepositoryName, settings.Enabled, syncStartDate,
+		branches, settings.PollIntervalSeconds, settings.ExcludeBots, time.Now().UTC())
+	if err != nil {
+		log.Println("Error saving tracked repository:", err)
+		return nil, err
+	}
+	return tp.GetTrackedRepository(settings.RepositoryName)
+}
+
+// DeleteTrackedRepository removes the settings of a repository, which falls
+// back to the defaults. It returns whether there were any.
+func (tp *TrackedRepositoryPersistence) DeleteTrackedRepository(repoName string) (bool, error) {
+	result, err := tp.db.Exec("DELETE FROM tracked_repositories WHERE repository_name = $1", repoName)
+	if err != nil {
+		log.Println("Error deleting tracked repository:", err)
+		return false, err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return deleted > 0, nil
+}
+
+func scanTrackedRepository(row rowScanner) (*models.TrackedRepository, error) {
+	var settings models.TrackedRepository
+	var syncStartDate sql.NullTime
+	var branches sql.NullString
+	err := row.Scan(&settings.RepositoryName, &settings.Enabled, &syncStartDate, &branches,
+		&settings.PollIntervalSeconds, &settings.ExcludeBots, &settings.CreatedAt, &settings.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	if syncStartDate.Valid {
+		settings.SyncStartDate = &syncStartDate.Time
+	}
+	if branches.Valid {
+		settings.Branches = make([]string, 0)
+	}
+	if branches.String != "" {
+		settings.Branches = strings.Split(branches.String, ",")
+	}
+	return &settings, nil
+}
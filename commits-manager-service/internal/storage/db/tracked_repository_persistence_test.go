@@ -0,0 +1,70 @@
+package db_test
+
+import (
+	"commits-manager-service/internal/constants/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTrackedRepositories(t *testing.T) {
+	repoName := "owner/tracked"
+	defer trackedRepositoriesQueries.DeleteTrackedRepository(repoName)
+
+	settings, err := trackedRepositoriesQueries.GetTrackedRepository(repoName)
+	require.NoError(t, err)
+	require.Nil(t, settings)
+
+	syncStartDate := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	settings, err = trackedRepositoriesQueries.SaveTrackedRepository(models.TrackedRepository{
+		RepositoryName:      repoName,
+		Enabled:             true,
+		SyncStartDate:       &syncStartDate,
+		Branches:            []string{"main", "release/*"},
+		PollIntervalSeconds: 1800,
+		ExcludeBots:         true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, repoName, settings.RepositoryName)
+	require.True(t, settings.Enabled)
+	require.NotNil(t, settings.SyncStartDate)
+	require.True(t, syncStartDate.Equal(*settings.SyncStartDate))
+	require.Equal(t, []string{"main", "release/*"}, settings.Branches)
+	require.Equal(t, int64(1800), settings.PollIntervalSeconds)
+	require.True(t, settings.ExcludeBots)
+	createdAt := settings.CreatedAt
+
+	// saving again replaces the settings and keeps the creation time
+	settings, err = trackedRepositoriesQueries.SaveTrackedRepository(models.TrackedRepository{
+		RepositoryName: repoName,
+	})
+	require.NoError(t, err)
+	require.False(t, settings.Enabled)
+	require.Nil(t, settings.SyncStartDate)
+	require.Nil(t, settings.Branches)
+	require.Equal(t, int64(0), settings.PollIntervalSeconds)
+	require.False(t, settings.ExcludeBots)
+	require.True(t, createdAt.Equal(settings.CreatedAt))
+
+	// no branches, unlike nil ones, sync the default branch only
+	settings, err = trackedRepositoriesQueries.SaveTrackedRepository(models.TrackedRepository{
+		RepositoryName: repoName,
+		Branches:       []string{},
+	})
+	require.NoError(t, err)
+	require.NotNil(t, settings.Branches)
+	require.Empty(t, settings.Branches)
+
+	trackedRepositories, err := trackedRepositoriesQueries.GetTrackedRepositories()
+	require.NoError(t, err)
+	require.NotEmpty(t, trackedRepositories)
+
+	deleted, err := trackedRepositoriesQueries.DeleteTrackedRepository(repoName)
+	require.NoError(t, err)
+	require.True(t, deleted)
+
+	deleted, err = trackedRepositoriesQueries.DeleteTrackedRepository(repoName)
+	require.NoError(t, err)
+	require.False(t, deleted)
+}
@@ -0,0 +1,138 @@
+package db
+
+import (
+	"commits-manager-service/internal/constants"
+	"commits-manager-service/internal/constants/models"
+	"context"
+	"database/sql"
+	"log"
+	"time"
+)
+
+type WorkflowRunRepository interface {
+	SaveWorkflowRuns(runs []models.WorkflowRun) error
+	GetWorkflowRunsWatermark(repositoryName string) (time.Time, error)
+	GetWorkflowRunsByHeadSHA(repoName, sha string) ([]*models.WorkflowRun, error)
+	GetCompletedWorkflowRuns(repoName string, startDate, endDate time.Time) ([]*models.WorkflowRun, error)
+}
+
+type WorkflowRunPersistence struct {
+	db *sql.DB
+}
+
+// NewWorkflowRunPersistence creates an instance of the WorkflowRunPersistence.
+func NewWorkflowRunPersistence(dbPool *sql.DB) WorkflowRunRepository {
+	return &WorkflowRunPersistence{db: dbPool}
+}
+
+// SaveWorkflowRuns inserts workflow runs or updates them as they progress and
+// are re-run.
+func (wp *WorkflowRunPersistence) SaveWorkflowRuns(runs []models.WorkflowRun) error {
+	ctx, cancel := context.WithTimeout(context.Background(), dbTimeout)
+	defer cancel()
+
+	tx, err := wp.db.BeginTx(ctx, nil)
+	if err != nil {
+		log.Println("Error starting workflow runs transaction:", err)
+		return err
+	}
+	defer tx.Rollback()
+
+	stmt := `INSERT INTO workflow_runs (id, repository_name, workflow_id, workflow_name, head_sha, head_branch, event, status, conclusion,
+                 run_attempt, url, duration_seconds, created_at, updated_at, run_started_at)
+             VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15)
+             ON CONFLICT (id) DO UPDATE SET
+                 workflow_name = excluded.workflow_name, status = excluded.status, conclusion = excluded.conclusion,
+                 run_attempt = excluded.run_attempt, duration_seconds = excluded.duration_seconds,
+                 updated_at = excluded.updated_at, run_started_at = excluded.run_started_at`
+
+	for _, run := range runs {
+		_, err := tx.ExecContext(ctx, stmt, run.ID, run.RepositoryName, run.WorkflowID, run.WorkflowName, run.HeadSHA, run.HeadBranch, run.Event,
+			run.Status, run.Conclusion, run.RunAttempt, run.URL, run.DurationSeconds, run.CreatedAt, run.UpdatedAt, run.RunStartedAt)
+		if err != nil {
+			log.Println("Error saving workflow run:", err)
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetWorkflowRunsWatermark returns when the oldest stored workflow run of a
+// repository that was not completed yet was created, or else when the newest
+// one was, the zero time when none is stored. Runs created more than
+// WORKFLOW_RUN_PENDING_HOURS ago do not hold the watermark back anymore.
+func (wp *WorkflowRunPersistence) GetWorkflowRunsWatermark(repositoryName string) (time.Time, error) {
+	pendingSince := time.Now().UTC().Add(-constants.WORKFLOW_RUN_PENDING_HOURS * time.Hour)
+	queries := []struct {
+		query string
+		args  []any
+	}{
+		{`SELECT created_at FROM workflow_runs WHERE repository_name = $1 AND status <> 'completed' AND created_at >= $2
+          ORDER BY created_at ASC LIMIT 1`, []any{repositoryName, pendingSince}},
+		{`SELECT created_at FROM workflow_runs WHERE repository_name = $1 ORDER BY created_at DESC LIMIT 1`, []any{repositoryName}},
+	}
+	for _, q := range queries {
+		var createdAt time.Time
+		err := wp.db.QueryRow(q.query, q.args...).Scan(&createdAt)
+		if err == sql.ErrNoRows {
+			continue
+		}
+		if err != nil {
+			log.Println("Error getting workflow runs watermark:", err)
+			return time.Time{}, err
+		}
+		return createdAt, nil
+	}
+	return time.Time{}, nil
+}
+
+const workflowRunColumns = `id, repository_name, workflow_id, workflow_name, head_sha, head_branch, event, status, conclusion,
+        run_attempt, url, duration_seconds, created_at, updated_at, run_started_at`
+
+// GetWorkflowRunsByHeadSHA returns the workflow runs of a commit, newest
+// first.
+func (wp *WorkflowRunPersistence) GetWorkflowRunsByHeadSHA(repoName, sha string) ([]*models.WorkflowRun, error) {
+	query := `SELECT ` + workflowRunColumns + `
+        FROM workflow_runs
+        WHERE repository_name = $1 AND head_sha = $2
+        ORDER BY created_at DESC, id DESC`
+	return wp.queryWorkflowRuns(query, repoName, sha)
+}
+
+// GetCompletedWorkflowRuns returns the completed workflow runs of a
+// repository created between startDate and endDate, oldest first.
+func (wp *WorkflowRunPersistence) GetCompletedWorkflowRuns(repoName string, startDate, endDate time.Time) ([]*models.WorkflowRun, error) {
+	query := `SELECT ` + workflowRunColumns + `
+        FROM workflow_runs
+        WHERE repository_name = $1 AND status = 'completed' AND created_at >= $2 AND created_at <= $3
+        ORDER BY created_at ASC, id ASC`
+	return wp.queryWorkflowRuns(query, repoName, startDate, endDate)
+}
+
+func (wp *WorkflowRunPersistence) queryWorkflowRuns(query string, args ...any) ([]*models.WorkflowRun, error) {
+	rows, err := wp.db.Query(query, args...)
+	if err != nil {
+		log.Println("Error querying workflow runs:", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	runs := make([]*models.WorkflowRun, 0)
+	for rows.Next() {
+		var run models.WorkflowRun
+		if err := rows.Scan(&run.ID, &run.RepositoryName, &run.WorkflowID, &run.WorkflowName, &run.HeadSHA, &run.HeadBranch, &run.Event,
+			&run.Status, &run.Conclusion, &run.RunAttempt, &run.URL, &run.DurationSeconds, &run.CreatedAt, &run.UpdatedAt, &run.RunStartedAt); err != nil {
+			log.Println("Error scanning workflow run row:", err)
+			return nil, err
+		}
+		runs = append(runs, &run)
+	}
+
+	if err := rows.Err(); err != nil {
+		log.Println("Error iterating through workflow runs:", err)
+		return nil, err
+	}
+
+	return runs, nil
+}
@@ -0,0 +1,78 @@
+package db_test
+
+import (
+	"commits-manager-service/internal/constants"
+	"commits-manager-service/internal/constants/models"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveWorkflowRuns(t *testing.T) {
+	repo := createRandomRepository()
+	_, err := repositoryQueries.InsertRepository(repo)
+	require.NoError(t, err)
+
+	watermark, err := workflowRunsQueries.GetWorkflowRunsWatermark(repo.FullName)
+	require.NoError(t, err)
+	require.True(t, watermark.IsZero())
+
+	now := time.Now().UTC().Truncate(time.Second)
+	newRun := func(id int64, sha, status, conclusion string, createdAt time.Time) models.WorkflowRun {
+		return models.WorkflowRun{
+			ID:             id,
+			RepositoryName: repo.FullName,
+			WorkflowID:     7,
+			WorkflowName:   "CI",
+			HeadSHA:        sha,
+			HeadBranch:     "main",
+			Event:          "push",
+			Status:         status,
+			Conclusion:     conclusion,
+			RunAttempt:     1,
+			CreatedAt:      createdAt,
+			UpdatedAt:      createdAt.Add(5 * time.Minute),
+			RunStartedAt:   createdAt,
+		}
+	}
+	passed := newRun(now.UnixNano(), "1111", constants.WORKFLOW_RUN_STATUS_COMPLETED, constants.WORKFLOW_RUN_CONCLUSION_SUCCESS, now.Add(-3*time.Hour))
+	passed.DurationSeconds = 300
+	running := newRun(now.UnixNano()+1, "2222", "in_progress", "", now.Add(-2*time.Hour))
+	latest := newRun(now.UnixNano()+2, "3333", constants.WORKFLOW_RUN_STATUS_COMPLETED, constants.WORKFLOW_RUN_CONCLUSION_FAILURE, now.Add(-time.Hour))
+	require.NoError(t, workflowRunsQueries.SaveWorkflowRuns([]models.WorkflowRun{passed, running, latest}))
+
+	// the oldest run in progress holds the watermark back
+	watermark, err = workflowRunsQueries.GetWorkflowRunsWatermark(repo.FullName)
+	require.NoError(t, err)
+	require.True(t, running.CreatedAt.Equal(watermark))
+
+	running.Status = constants.WORKFLOW_RUN_STATUS_COMPLETED
+	running.Conclusion = constants.WORKFLOW_RUN_CONCLUSION_SUCCESS
+	running.DurationSeconds = 600
+	require.NoError(t, workflowRunsQueries.SaveWorkflowRuns([]models.WorkflowRun{running}))
+
+	watermark, err = workflowRunsQueries.GetWorkflowRunsWatermark(repo.FullName)
+	require.NoError(t, err)
+	require.True(t, latest.CreatedAt.Equal(watermark))
+
+	// a run stuck queued for days does not hold the watermark back
+	stuck := newRun(now.UnixNano()+3, "4444", "queued", "", now.Add(-100*time.Hour))
+	require.NoError(t, workflowRunsQueries.SaveWorkflowRuns([]models.WorkflowRun{stuck}))
+	watermark, err = workflowRunsQueries.GetWorkflowRunsWatermark(repo.FullName)
+	require.NoError(t, err)
+	require.True(t, latest.CreatedAt.Equal(watermark))
+
+	runs, err := workflowRunsQueries.GetWorkflowRunsByHeadSHA(repo.FullName, "2222")
+	require.NoError(t, err)
+	require.Len(t, runs, 1)
+	require.Equal(t, constants.WORKFLOW_RUN_CONCLUSION_SUCCESS, runs[0].Conclusion)
+	require.Equal(t, 600, runs[0].DurationSeconds)
+
+	runs, err = workflowRunsQueries.GetCompletedWorkflowRuns(repo.FullName, now.Add(-150*time.Minute), now)
+	require.NoError(t, err)
+	require.Len(t, runs, 2)
+	require.Equal(t, "2222", runs[0].HeadSHA)
+
+	repositoryQueries.DeleteRepository(repo.FullName)
+}
@@ -3,23 +3,36 @@ package main
 import (
 	"commits-monitor-service/internal/constants"
 	"commits-monitor-service/internal/constants/models"
+	"commits-monitor-service/internal/http/grpc/client/actions"
+	"commits-monitor-service/internal/http/grpc/client/backfills"
 	"commits-monitor-service/internal/http/grpc/client/commits"
+	"commits-monitor-service/internal/http/grpc/client/issues"
+	"commits-monitor-service/internal/http/grpc/client/pulls"
 	"commits-monitor-service/internal/http/grpc/client/repos"
+	"commits-monitor-service/internal/http/webhook"
 	"commits-monitor-service/internal/pkg/githubrestclient"
+	"commits-monitor-service/internal/pkg/gitlabclient"
+	"commits-monitor-service/internal/pkg/localgitclient"
+	"encoding/json"
 	"fmt"
 	"math"
+	"net/http"
+	"strconv"
 	"time"
 
 	"commits-monitor-service/internal/services/commitsmonitorservice"
 
 	"log"
 	"os"
+	"strings"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
 const commitMangerUrl = "commits-manager-service:50001"
 
+const webhookPort = "80"
+
 func main() {
 	startDate := "1970-10-03T10:01:20Z"
 	_, err := time.Parse(constants.ISO_8601_TIME_LAYOUT, os.Getenv("START_DATE"))
@@ -44,17 +57,61 @@ func main() {
 	}
 	defer rabbitConn.Close()
 
-	githubRestClient := githubrestclient.NewGithubRestClient(&models.Config{
-		GithubToken:    os.Getenv("GITHUB_TOKEN"),
-		GithubUsername: os.Getenv("GITHUB_USERNAME"),
-		StartDate:      startDate,
-		EndDate:        endDate,
-	})
+	servers, err := parseServers(os.Getenv("GITHUB_SERVERS"))
+	if err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+
+	config := &models.Config{
+		GithubAPI:               os.Getenv("GITHUB_API"),
+		GithubAPIURL:            os.Getenv("GITHUB_API_URL"),
+		GithubGraphQLURL:        os.Getenv("GITHUB_GRAPHQL_URL"),
+		GithubCACert:            os.Getenv("GITHUB_CA_CERT"),
+		GithubServers:           servers,
+		GithubToken:             os.Getenv("GITHUB_TOKEN"),
+		GithubUsername:          os.Getenv("GITHUB_USERNAME"),
+		GithubTokens:            splitList(os.Getenv("GITHUB_TOKENS")),
+		GithubBranches:          splitList(os.Getenv("GITHUB_BRANCHES")),
+		StartDate:               startDate,
+		EndDate:                 endDate,
+		GithubAppID:             os.Getenv("GITHUB_APP_ID"),
+		GithubAppInstallationID: os.Getenv("GITHUB_APP_INSTALLATION_ID"),
+		GithubAppPrivateKey:     os.Getenv("GITHUB_APP_PRIVATE_KEY"),
+		GithubWebhookSecret:     os.Getenv("GITHUB_WEBHOOK_SECRET"),
+		GitlabAPIURL:            os.Getenv("GITLAB_API_URL"),
+		GitlabToken:             os.Getenv("GITLAB_TOKEN"),
+		GitlabCACert:            os.Getenv("GITLAB_CA_CERT"),
+		GitlabOwners:            parseOwnerLogins(os.Getenv("GITLAB_OWNERS")),
+		LocalReposDir:           os.Getenv("LOCAL_REPOS_DIR"),
+		FetchWorkers:            parseCount("FETCH_WORKERS"),
+		FetchWorkersPerOwner:    parseCount("FETCH_WORKERS_PER_OWNER"),
+		BackfillWorkers:         parseCount("BACKFILL_WORKERS"),
+		PollIntervalFloor:       parseInterval("POLL_INTERVAL_FLOOR"),
+		PollIntervalCeiling:     parseInterval("POLL_INTERVAL_CEILING"),
+	}
+
+	provider, err := newProvider(config)
+	if err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
 
 	commitMetaDataServiceClient := commits.NewCommitsMetaDataServiceClient(commitMangerUrl)
 	reposMetaDataServiceClient := repos.NewReposMetaDataServiceClient(commitMangerUrl)
-	commitsMonitorService := commitsmonitorservice.NewCommentMonitorService(githubRestClient,
-		*reposMetaDataServiceClient, *commitMetaDataServiceClient, rabbitConn)
+	pullsMetaDataServiceClient := pulls.NewPullsMetaDataServiceClient(commitMangerUrl)
+	issuesMetaDataServiceClient := issues.NewIssuesMetaDataServiceClient(commitMangerUrl)
+	actionsMetaDataServiceClient := actions.NewActionsMetaDataServiceClient(commitMangerUrl)
+	backfillsMetaDataServiceClient := backfills.NewBackfillsMetaDataServiceClient(commitMangerUrl)
+	commitsMonitorService := commitsmonitorservice.NewCommentMonitorService(config, provider,
+		*reposMetaDataServiceClient, *commitMetaDataServiceClient, *pullsMetaDataServiceClient,
+		*issuesMetaDataServiceClient, *actionsMetaDataServiceClient, *backfillsMetaDataServiceClient, rabbitConn)
+
+	// Push deliveries are ingested as they come, polling stays as the
+	// reconciliation fallback.
+	if config.GithubWebhookSecret != "" {
+		go serveWebhooks(webhook.NewReceiver(config.GithubWebhookSecret, &commitsMonitorService))
+	}
 
 	wait := make(chan bool)
 
@@ -62,12 +119,143 @@ func main() {
 	timer := time.After(60 * time.Second)
 	<-timer
 
-	go commitsMonitorService.ScheduleFetchingCommits(time.Hour * 1)
+	// Each repository is polled at its own interval, the ones due are
+	// looked up every minute.
+	go commitsMonitorService.ScheduleFetchingCommits(time.Minute * 1)
+
+	// Backfill jobs run one at a time on their own workers, queued ones
+	// are looked up every minute.
+	go commitsMonitorService.ScheduleBackfills(time.Minute * 1)
 
 	<-wait
 
 }
 
+// serveWebhooks receives the GitHub webhook deliveries on /webhooks/github.
+func serveWebhooks(receiver *webhook.Receiver) {
+	mux := http.NewServeMux()
+	mux.Handle("/webhooks/github", receiver)
+
+	log.Printf("CMOS: webhook receiver started at port :%s\n", webhookPort)
+	if err := http.ListenAndServe(":"+webhookPort, mux); err != nil {
+		log.Println("CMOS: webhook receiver stopped:", err)
+	}
+}
+
+// newProvider returns the GitHub client selected by GITHUB_API, routing
+// the owners of GITHUB_SERVERS to their own server and the owners of
+// GITLAB_OWNERS to GitLab and the owner directories of LOCAL_REPOS_DIR to
+// the repositories on disk.
+func newProvider(config *models.Config) (commitsmonitorservice.Provider, error) {
+	fetcher, err := newGithubClient(config)
+	if err != nil || len(config.GithubServers) == 0 && len(config.GitlabOwners) == 0 && config.LocalReposDir == "" {
+		return fetcher, err
+	}
+
+	serverFetcher := commitsmonitorservice.NewServerCommitsFetcher(fetcher)
+	for name, server := range config.GithubServers {
+		fetcher, err := newGithubClient(config.WithServer(server))
+		if err != nil {
+			return nil, fmt.Errorf("CMOS: github server %s: %w", name, err)
+		}
+		serverFetcher.AddServer(server.Owners, fetcher)
+	}
+	if len(config.GitlabOwners) > 0 {
+		gitlabClient, err := gitlabclient.NewGitlabClient(config)
+		if err != nil {
+			return nil, fmt.Errorf("CMOS: gitlab: %w", err)
+		}
+		serverFetcher.AddServer(config.GitlabOwners, gitlabClient)
+	}
+	if config.LocalReposDir != "" {
+		localClient, err := localgitclient.NewLocalGitClient(config)
+		if err != nil {
+			return nil, fmt.Errorf("CMOS: local repositories: %w", err)
+		}
+		owners, err := localClient.Owners()
+		if err != nil {
+			return nil, fmt.Errorf("CMOS: local repositories: %w", err)
+		}
+		serverFetcher.AddServer(owners, localClient)
+	}
+	return serverFetcher, nil
+}
+
+func newGithubClient(config *models.Config) (commitsmonitorservice.Provider, error) {
+	switch config.GithubAPI {
+	case constants.GITHUB_API_GRAPHQL:
+		return githubrestclient.NewGithubGraphQLClient(config)
+	case "", constants.GITHUB_API_REST:
+		return githubrestclient.NewGithubRestClient(config)
+	}
+	return nil, fmt.Errorf("CMOS: unknown GITHUB_API %q, expected %q or %q",
+		config.GithubAPI, constants.GITHUB_API_REST, constants.GITHUB_API_GRAPHQL)
+}
+
+// parseServers reads the JSON object of GitHub servers keyed by name.
+func parseServers(value string) (map[string]models.GithubServer, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var servers map[string]models.GithubServer
+	if err := json.Unmarshal([]byte(value), &servers); err != nil {
+		return nil, fmt.Errorf("CMOS: cannot parse GITHUB_SERVERS: %w", err)
+	}
+	return servers, nil
+}
+
+// parseOwnerLogins reads the logins of a comma separated list of owners, each
+// given as login or login:type.
+func parseOwnerLogins(value string) []string {
+	var logins []string
+	for _, item := range splitList(value) {
+		login, _, _ := strings.Cut(item, ":")
+		logins = append(logins, login)
+	}
+	return logins
+}
+
+// parseCount reads a positive count from the environment variable name, or
+// 0 to leave it to the default.
+func parseCount(name string) int {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0
+	}
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 0 {
+		log.Printf("Cannot parse %s %q, using the default\n", name, value)
+		return 0
+	}
+	return count
+}
+
+// parseInterval reads a duration such as 10m or 24h from the environment
+// variable name, or 0 to leave it to the default.
+func parseInterval(name string) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return 0
+	}
+	interval, err := time.ParseDuration(value)
+	if err != nil || interval < 0 {
+		log.Printf("Cannot parse %s %q, using the default\n", name, value)
+		return 0
+	}
+	return interval
+}
+
+// splitList splits a comma separated environment variable.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func connect() (*amqp.Connection, error) {
 	var counts int64
 	var backOff = 1 * time.Second
@@ -4,5 +4,32 @@ const  ISO_8601_TIME_LAYOUT = "2006-01-02T15:04:05Z"
 
 const COMMITS_EVENT="github.COMMITS"
 
+const REPOS_EVENT = "github.REPOS"
+
+const PULLS_EVENT = "github.PULLS"
+
+const ISSUES_EVENT = "github.ISSUES"
+
+const RELEASES_EVENT = "github.RELEASES"
+
+const ACTIONS_EVENT = "github.ACTIONS"
+
 const GITHUB_API_TOPIC = "github_api_topic"
 
+// GitHub APIs the commits can be fetched from, selected with GITHUB_API.
+const (
+	GITHUB_API_REST    = "rest"
+	GITHUB_API_GRAPHQL = "graphql"
+)
+
+// Outcomes of fetching the commits of a repository
+const (
+	FETCH_OUTCOME_OK           = "ok"
+	FETCH_OUTCOME_NOT_MODIFIED = "not_modified"
+	FETCH_OUTCOME_EMPTY        = "empty"
+	FETCH_OUTCOME_NOT_FOUND    = "not_found"
+	FETCH_OUTCOME_RATE_LIMITED = "rate_limited"
+	FETCH_OUTCOME_UNAUTHORIZED = "unauthorized"
+	FETCH_OUTCOME_SERVER_ERROR = "server_error"
+	FETCH_OUTCOME_ERROR        = "error"
+)
@@ -17,17 +17,6 @@ type Repository struct {
 	UpdatedAt       time.Time `json:"updated_at"`
 }
 
-type Commit struct {
-	SHA            string    `json:"sha"`
-	URL            string    `json:"url"`
-	Message        string    `json:"message"`
-	AuthorName     string    `json:"author_name"`
-	AuthorDate     time.Time `json:"author_date"`
-	RepositoryName string    `json:"repository_name"`
-	CreatedAt      time.Time `json:"created_at"`
-	UpdatedAt      time.Time `json:"updated_at"`
-}
-
 type CommitAuthor struct {
 	Name        string `json:"name"`
 	CommitCount int    `json:"commit_count"`
@@ -52,6 +41,120 @@ type Config struct {
 	GithubUsername string `json:"github_username"`
 	StartDate      string
 	EndDate        string
+
+	// GithubAPI selects the API commits are fetched from, "rest" (the
+	// default) or "graphql".
+	GithubAPI string
+
+	// GithubTokens are additional personal access tokens requests are
+	// rotated over.
+	GithubTokens []string `json:"-"`
+
+	// GitHub App authentication, rotated over together with the personal
+	// access tokens when GithubAppID is set. GithubAppPrivateKey holds
+	// either the PEM encoded key or the path of the key file.
+	GithubAppID             string `json:"github_app_id"`
+	GithubAppInstallationID string `json:"github_app_installation_id"`
+	GithubAppPrivateKey     string `json:"-"`
+
+	// GithubAPIURL is the root of the REST API, https://api.github.com
+	// unless a GitHub Enterprise Server such as https://ghe.corp/api/v3 is
+	// used.
+	GithubAPIURL string `json:"github_api_url"`
+
+	// GithubGraphQLURL is the GraphQL endpoint. When empty it is derived
+	// from GithubAPIURL.
+	GithubGraphQLURL string `json:"github_graphql_url"`
+
+	// GithubCACert holds a PEM encoded CA bundle, or the path of the bundle
+	// file, trusted in addition to the system roots.
+	GithubCACert string `json:"github_ca_cert"`
+
+	// GithubBranches are glob patterns of the branches whose commits are
+	// synced besides the default branch. When empty only the default branch
+	// is, "*" syncs all of them.
+	GithubBranches []string `json:"github_branches"`
+
+	// GithubServers are further GitHub instances keyed by name. The
+	// repositories of the owners listed by a server are fetched from it.
+	GithubServers map[string]GithubServer `json:"github_servers"`
+
+	// GithubWebhookSecret is the secret the webhook deliveries are signed
+	// with. The webhook receiver only runs when it is set.
+	GithubWebhookSecret string `json:"-"`
+
+	// GitLab instance the repositories of GitlabOwners are fetched from.
+	// GitlabAPIURL is the root of its REST API, https://gitlab.com/api/v4
+	// when empty, and GitlabCACert a CA bundle trusted like GithubCACert.
+	GitlabAPIURL string   `json:"gitlab_api_url"`
+	GitlabToken  string   `json:"-"`
+	GitlabCACert string   `json:"gitlab_ca_cert"`
+	GitlabOwners []string `json:"gitlab_owners"`
+
+	// LocalReposDir is a directory of git repositories on disk laid out as
+	// <owner>/<name>, bare (<name>.git) or working copies. The repositories
+	// of its owner directories are read from it.
+	LocalReposDir string `json:"local_repos_dir"`
+
+	// FetchWorkers bounds the repositories whose commits are fetched at once
+	// and FetchWorkersPerOwner those of a single owner.
+	FetchWorkers         int `json:"fetch_workers"`
+	FetchWorkersPerOwner int `json:"fetch_workers_per_owner"`
+
+	// BackfillWorkers bounds the repositories of a backfill job fetched at
+	// once, apart from the workers of regular polling.
+	BackfillWorkers int `json:"backfill_workers"`
+
+	// PollIntervalFloor and PollIntervalCeiling bound the interval each
+	// repository is polled at, unless it is pinned.
+	PollIntervalFloor   time.Duration `json:"poll_interval_floor"`
+	PollIntervalCeiling time.Duration `json:"poll_interval_ceiling"`
+}
+
+// GithubServer is a GitHub instance serving the repositories of some owners.
+// Tokens replace the default tokens and GitHub App when set; the other
+// settings fall back to the defaults when empty.
+type GithubServer struct {
+	Owners     []string `json:"owners"`
+	APIURL     string   `json:"api_url"`
+	GraphQLURL string   `json:"graphql_url"`
+	CACert     string   `json:"ca_cert"`
+	Token      string   `json:"token"`
+	Tokens     []string `json:"tokens"`
+}
+
+// WithServer returns a copy of the configuration talking to server.
+func (c *Config) WithServer(server GithubServer) *Config {
+	config := *c
+	config.GithubServers = nil
+	if server.APIURL != "" {
+		config.GithubAPIURL = server.APIURL
+		config.GithubGraphQLURL = server.GraphQLURL
+	}
+	if server.GraphQLURL != "" {
+		config.GithubGraphQLURL = server.GraphQLURL
+	}
+	if server.CACert != "" {
+		config.GithubCACert = server.CACert
+	}
+	if server.Token != "" || len(server.Tokens) > 0 {
+		config.GithubToken = server.Token
+		config.GithubTokens = server.Tokens
+		config.GithubAppID = ""
+		config.GithubAppInstallationID = ""
+		config.GithubAppPrivateKey = ""
+	}
+	return &config
+}
+
+// BranchResponse is a branch as listed by GET /repos/{owner}/{repo}/branches.
+type BranchResponse struct {
+	Name   string `json:"name"`
+	Commit struct {
+		Sha string `json:"sha"`
+		URL string `json:"url"`
+	} `json:"commit"`
+	Protected bool `json:"protected"`
 }
 
 type RepositoryReponse struct {
@@ -0,0 +1,186 @@
+package models
+
+import "time"
+
+// The types below are repositories as the monitor sees them whatever code
+// hosting service they are synced from. Every provider client converts the
+// responses of its service into them.
+
+// Branch is a branch of a repository and the SHA of its head commit.
+type Branch struct {
+	Name    string
+	HeadSha string
+}
+
+// Signature is who authored or committed a commit, and when. Login and Type
+// are those of the account of the hosting service the signature belongs
+// to, empty when it is not known; Type is "Bot" for bot accounts.
+type Signature struct {
+	Name  string
+	Email string
+	Date  time.Time
+	Login string
+	Type  string
+}
+
+// Commit is a commit of a repository. Stats and Files are only set for a
+// single commit fetched with its details.
+type Commit struct {
+	Sha string
+	// URL is the API URL of the commit, HTMLURL its web page.
+	URL       string
+	HTMLURL   string
+	Message   string
+	Author    Signature
+	Committer Signature
+	// Parents are the SHAs of the parent commits.
+	Parents []string
+	Stats   *CommitStats
+	Files   []CommitFile
+}
+
+// CommitStats are the lines a commit added and deleted.
+type CommitStats struct {
+	Additions int
+	Deletions int
+	Total     int
+}
+
+// CommitFile is a file changed by a commit. Status is "added", "removed",
+// "modified" or "renamed", in which case PreviousFilename is set.
+type CommitFile struct {
+	Sha              string
+	Filename         string
+	Status           string
+	Additions        int
+	Deletions        int
+	Changes          int
+	PreviousFilename string
+}
+
+// PullRequest is a pull or merge request. State is "open" or "closed",
+// merged ones have MergedAt set.
+type PullRequest struct {
+	Number         int
+	Title          string
+	State          string
+	Draft          bool
+	Author         string
+	HTMLURL        string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	ClosedAt       *time.Time
+	MergedAt       *time.Time
+	MergeCommitSha string
+	HeadRef        string
+	HeadSha        string
+	BaseRef        string
+	BaseSha        string
+}
+
+// Issue is an issue of a repository, never a pull request. State is "open"
+// or "closed".
+type Issue struct {
+	Number    int
+	Title     string
+	State     string
+	Author    string
+	Labels    []string
+	Assignees []string
+	Comments  int
+	HTMLURL   string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	ClosedAt  *time.Time
+}
+
+// Release is a release of a repository. TargetCommitish is the branch or
+// SHA the tag of the release was created from.
+type Release struct {
+	ID              int
+	TagName         string
+	Name            string
+	TargetCommitish string
+	Draft           bool
+	Prerelease      bool
+	Author          string
+	HTMLURL         string
+	CreatedAt       time.Time
+	PublishedAt     *time.Time
+}
+
+// Tag is a tag of a repository and the SHA of the commit it points to.
+type Tag struct {
+	Name string
+	Sha  string
+}
+
+// WorkflowRun is a CI run of a repository, a GitHub Actions workflow run or
+// a GitLab pipeline. Status is "queued", "in_progress" or "completed", in
+// which case Conclusion tells how it ended.
+type WorkflowRun struct {
+	ID           int64
+	Name         string
+	WorkflowID   int64
+	HeadBranch   string
+	HeadSha      string
+	Event        string
+	Status       string
+	Conclusion   string
+	RunAttempt   int
+	HTMLURL      string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+	RunStartedAt time.Time
+}
+
+// CommitsPage is one page of the commits of a branch.
+type CommitsPage struct {
+	Commits []Commit
+	// Next is the cursor of the next page, empty on the last page.
+	Next string
+	// NotModified is set when the provider reported no change since the
+	// page was last fetched and Commits were served from a cache.
+	NotModified bool
+}
+
+// PullRequestsPage is one page of the pull requests of a repository.
+type PullRequestsPage struct {
+	PullRequests []PullRequest
+	// Next is the cursor of the next page, empty on the last page.
+	Next string
+}
+
+// IssuesPage is one page of the issues of a repository.
+type IssuesPage struct {
+	Issues []Issue
+	// Next is the cursor of the next page, empty on the last page.
+	Next string
+}
+
+// ReleasesPage is one page of the releases of a repository.
+type ReleasesPage struct {
+	Releases []Release
+	// Next is the cursor of the next page, empty on the last page.
+	Next string
+	// NotModified is set when the provider reported no change since the
+	// page was last fetched and Releases were served from a cache.
+	NotModified bool
+}
+
+// TagsPage is one page of the tags of a repository.
+type TagsPage struct {
+	Tags []Tag
+	// Next is the cursor of the next page, empty on the last page.
+	Next string
+	// NotModified is set when the provider reported no change since the
+	// page was last fetched and Tags were served from a cache.
+	NotModified bool
+}
+
+// WorkflowRunsPage is one page of the workflow runs of a repository.
+type WorkflowRunsPage struct {
+	Runs []WorkflowRun
+	// Next is the cursor of the next page, empty on the last page.
+	Next string
+}
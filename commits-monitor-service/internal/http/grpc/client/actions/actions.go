@@ -0,0 +1,43 @@
+package actions
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	amds "commits-monitor-service/internal/http/grpc/protos/actions"
+)
+
+type ActionsMetaDataServiceClient struct {
+	ServiceUrl string
+}
+
+func NewActionsMetaDataServiceClient(serviceUrl string) *ActionsMetaDataServiceClient {
+	return &ActionsMetaDataServiceClient{
+		ServiceUrl: serviceUrl,
+	}
+}
+
+// GetWorkflowRunsWatermark returns the creation time from which the workflow
+// runs of the repository are fetched again, empty when none is stored.
+func (amdsc ActionsMetaDataServiceClient) GetWorkflowRunsWatermark(repoName string) (string, error) {
+	conn, err := grpc.NewClient(amdsc.ServiceUrl, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	c := amds.NewActionsServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	response, err := c.GetWorkflowRunsWatermark(ctx, &amds.WorkflowRunsWatermarkRequest{
+		RepositoryName: repoName,
+	})
+	if err != nil {
+		return "", err
+	}
+	return response.CreatedSince, nil
+}
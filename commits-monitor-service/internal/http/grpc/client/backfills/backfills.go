@@ -0,0 +1,41 @@
+package backfills
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	bmds "commits-monitor-service/internal/http/grpc/protos/backfills"
+)
+
+type BackfillsMetaDataServiceClient struct {
+	ServiceUrl string
+}
+
+func NewBackfillsMetaDataServiceClient(serviceUrl string) *BackfillsMetaDataServiceClient {
+	return &BackfillsMetaDataServiceClient{
+		ServiceUrl: serviceUrl,
+	}
+}
+
+// ClaimBackfillJob takes the next backfill job to run, nil when none is
+// queued.
+func (bmdsc BackfillsMetaDataServiceClient) ClaimBackfillJob() (*bmds.BackfillJob, error) {
+	conn, err := grpc.NewClient(bmdsc.ServiceUrl, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	c := bmds.NewBackfillsServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	response, err := c.ClaimBackfillJob(ctx, &bmds.ClaimBackfillJobRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return response.GetJob(), nil
+}
@@ -20,10 +20,13 @@ func NewCommitsMetaDataServiceClient(serviceUrl string) *CommitsMetaDataServiceC
 	}
 }
 
-func (rmdsc CommitsMetaDataServiceClient) GetCommitFetchHistory(repoName string) (*cmds.CommitFetchHistoryResponse, error) {
+// GetCommitWatermark returns the newest commit stored for a branch of the
+// repository, or for the whole repository when branch is empty. Both fields
+// are empty when nothing is stored yet.
+func (rmdsc CommitsMetaDataServiceClient) GetCommitWatermark(repoName string, branch string) (*cmds.CommitWatermarkResponse, error) {
 	conn, err := grpc.NewClient(rmdsc.ServiceUrl, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
-		return &cmds.CommitFetchHistoryResponse{}, err
+		return &cmds.CommitWatermarkResponse{}, err
 	}
 	defer conn.Close()
 
@@ -31,11 +34,35 @@ func (rmdsc CommitsMetaDataServiceClient) GetCommitFetchHistory(repoName string)
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 
-	response, err := c.GetCommitFetchHistory(ctx, &cmds.CommitFetchHistoryRequest{
+	response, err := c.GetCommitWatermark(ctx, &cmds.CommitWatermarkRequest{
 		RepositoryName: repoName,
+		Branch:         branch,
 	})
 	if err != nil {
-		return &cmds.CommitFetchHistoryResponse{}, err
+		return &cmds.CommitWatermarkResponse{}, err
 	}
 	return response, nil
 }
+
+// GetCommitsWithoutStats returns the SHAs of the newest stored commits of the
+// repository whose stats and changed files were not fetched yet.
+func (rmdsc CommitsMetaDataServiceClient) GetCommitsWithoutStats(repoName string, limit int32) ([]string, error) {
+	conn, err := grpc.NewClient(rmdsc.ServiceUrl, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return []string{}, err
+	}
+	defer conn.Close()
+
+	c := cmds.NewCommitsServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	response, err := c.GetCommitsWithoutStats(ctx, &cmds.CommitsWithoutStatsRequest{
+		RepositoryName: repoName,
+		Limit:          limit,
+	})
+	if err != nil {
+		return []string{}, err
+	}
+	return response.Shas, nil
+}
@@ -0,0 +1,43 @@
+package issues
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	imds "commits-monitor-service/internal/http/grpc/protos/issues"
+)
+
+type IssuesMetaDataServiceClient struct {
+	ServiceUrl string
+}
+
+func NewIssuesMetaDataServiceClient(serviceUrl string) *IssuesMetaDataServiceClient {
+	return &IssuesMetaDataServiceClient{
+		ServiceUrl: serviceUrl,
+	}
+}
+
+// GetIssuesWatermark returns when the most recently updated stored issue of
+// the repository was updated, empty when none is stored.
+func (imdsc IssuesMetaDataServiceClient) GetIssuesWatermark(repoName string) (string, error) {
+	conn, err := grpc.NewClient(imdsc.ServiceUrl, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	c := imds.NewIssuesServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	response, err := c.GetIssuesWatermark(ctx, &imds.IssuesWatermarkRequest{
+		RepositoryName: repoName,
+	})
+	if err != nil {
+		return "", err
+	}
+	return response.LastUpdatedAt, nil
+}
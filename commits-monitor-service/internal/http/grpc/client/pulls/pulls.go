@@ -0,0 +1,43 @@
+package pulls
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+
+	pmds "commits-monitor-service/internal/http/grpc/protos/pulls"
+)
+
+type PullsMetaDataServiceClient struct {
+	ServiceUrl string
+}
+
+func NewPullsMetaDataServiceClient(serviceUrl string) *PullsMetaDataServiceClient {
+	return &PullsMetaDataServiceClient{
+		ServiceUrl: serviceUrl,
+	}
+}
+
+// GetPullRequestsWatermark returns when the most recently updated stored pull
+// request of the repository was updated, empty when none is stored.
+func (pmdsc PullsMetaDataServiceClient) GetPullRequestsWatermark(repoName string) (string, error) {
+	conn, err := grpc.NewClient(pmdsc.ServiceUrl, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	c := pmds.NewPullsServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	response, err := c.GetPullRequestsWatermark(ctx, &pmds.PullRequestsWatermarkRequest{
+		RepositoryName: repoName,
+	})
+	if err != nil {
+		return "", err
+	}
+	return response.LastUpdatedAt, nil
+}
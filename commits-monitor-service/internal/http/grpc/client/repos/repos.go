@@ -55,3 +55,60 @@ func (rmdsc ReposMetaDataServiceClient) GetRepositoryNames() ([]string, error) {
 	}
 	return response.Repositories, nil
 }
+
+// GetPollSchedules returns the poll schedules of the repositories still
+// polled, counting their commits authored since recentCommitsSince (ISO 8601)
+// as recent.
+func (rmdsc ReposMetaDataServiceClient) GetPollSchedules(recentCommitsSince string) ([]*rmds.PollSchedule, error) {
+	conn, err := grpc.NewClient(rmdsc.ServiceUrl, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	c := rmds.NewRepositoriesServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	response, err := c.GetPollSchedules(ctx, &rmds.GetPollSchedulesRequest{RecentCommitsSince: recentCommitsSince})
+	if err != nil {
+		return nil, err
+	}
+	return response.Schedules, nil
+}
+
+// SavePollSchedule stores when a repository is polled next.
+func (rmdsc ReposMetaDataServiceClient) SavePollSchedule(schedule *rmds.SavePollScheduleRequest) error {
+	conn, err := grpc.NewClient(rmdsc.ServiceUrl, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	c := rmds.NewRepositoriesServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	_, err = c.SavePollSchedule(ctx, schedule)
+	return err
+}
+
+// GetTrackedRepositories returns the settings of the repositories that
+// override the configured defaults.
+func (rmdsc ReposMetaDataServiceClient) GetTrackedRepositories() ([]*rmds.TrackedRepository, error) {
+	conn, err := grpc.NewClient(rmdsc.ServiceUrl, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	c := rmds.NewRepositoriesServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	response, err := c.GetTrackedRepositories(ctx, &rmds.GetTrackedRepositoriesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return response.Repositories, nil
+}
@@ -0,0 +1,105 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.6.1
+// source: actions.proto
+
+package actions
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// ActionsServiceClient is the client API for ActionsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type ActionsServiceClient interface {
+	GetWorkflowRunsWatermark(ctx context.Context, in *WorkflowRunsWatermarkRequest, opts ...grpc.CallOption) (*WorkflowRunsWatermarkResponse, error)
+}
+
+type actionsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewActionsServiceClient(cc grpc.ClientConnInterface) ActionsServiceClient {
+	return &actionsServiceClient{cc}
+}
+
+func (c *actionsServiceClient) GetWorkflowRunsWatermark(ctx context.Context, in *WorkflowRunsWatermarkRequest, opts ...grpc.CallOption) (*WorkflowRunsWatermarkResponse, error) {
+	out := new(WorkflowRunsWatermarkResponse)
+	err := c.cc.Invoke(ctx, "/actions.ActionsService/GetWorkflowRunsWatermark", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ActionsServiceServer is the server API for ActionsService service.
+// All implementations must embed UnimplementedActionsServiceServer
+// for forward compatibility
+type ActionsServiceServer interface {
+	GetWorkflowRunsWatermark(context.Context, *WorkflowRunsWatermarkRequest) (*WorkflowRunsWatermarkResponse, error)
+	mustEmbedUnimplementedActionsServiceServer()
+}
+
+// UnimplementedActionsServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedActionsServiceServer struct {
+}
+
+func (UnimplementedActionsServiceServer) GetWorkflowRunsWatermark(context.Context, *WorkflowRunsWatermarkRequest) (*WorkflowRunsWatermarkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetWorkflowRunsWatermark not implemented")
+}
+func (UnimplementedActionsServiceServer) mustEmbedUnimplementedActionsServiceServer() {}
+
+// UnsafeActionsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to ActionsServiceServer will
+// result in compilation errors.
+type UnsafeActionsServiceServer interface {
+	mustEmbedUnimplementedActionsServiceServer()
+}
+
+func RegisterActionsServiceServer(s grpc.ServiceRegistrar, srv ActionsServiceServer) {
+	s.RegisterService(&ActionsService_ServiceDesc, srv)
+}
+
+func _ActionsService_GetWorkflowRunsWatermark_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(WorkflowRunsWatermarkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ActionsServiceServer).GetWorkflowRunsWatermark(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/actions.ActionsService/GetWorkflowRunsWatermark",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ActionsServiceServer).GetWorkflowRunsWatermark(ctx, req.(*WorkflowRunsWatermarkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// ActionsService_ServiceDesc is the grpc.ServiceDesc for ActionsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var ActionsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "actions.ActionsService",
+	HandlerType: (*ActionsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetWorkflowRunsWatermark",
+			Handler:    _ActionsService_GetWorkflowRunsWatermark_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "actions.proto",
+}
@@ -0,0 +1,311 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.6.1
+// source: backfills.proto
+
+package backfills
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ClaimBackfillJobRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *ClaimBackfillJobRequest) Reset() {
+	*x = ClaimBackfillJobRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backfills_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClaimBackfillJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimBackfillJobRequest) ProtoMessage() {}
+
+func (x *ClaimBackfillJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_backfills_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimBackfillJobRequest.ProtoReflect.Descriptor instead.
+func (*ClaimBackfillJobRequest) Descriptor() ([]byte, []int) {
+	return file_backfills_proto_rawDescGZIP(), []int{0}
+}
+
+type ClaimBackfillJobResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// the job to run, unset when none is queued
+	Job *BackfillJob `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+}
+
+func (x *ClaimBackfillJobResponse) Reset() {
+	*x = ClaimBackfillJobResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backfills_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ClaimBackfillJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ClaimBackfillJobResponse) ProtoMessage() {}
+
+func (x *ClaimBackfillJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_backfills_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ClaimBackfillJobResponse.ProtoReflect.Descriptor instead.
+func (*ClaimBackfillJobResponse) Descriptor() ([]byte, []int) {
+	return file_backfills_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ClaimBackfillJobResponse) GetJob() *BackfillJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+type BackfillJob struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id int64 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	// full name (owner/name) of the repository, empty for all repositories
+	RepositoryName string `protobuf:"bytes,2,opt,name=repositoryName,proto3" json:"repositoryName,omitempty"`
+	// commits authored between startDate and endDate are fetched
+	StartDate string `protobuf:"bytes,3,opt,name=startDate,proto3" json:"startDate,omitempty"`
+	EndDate   string `protobuf:"bytes,4,opt,name=endDate,proto3" json:"endDate,omitempty"`
+	Priority  int32  `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"`
+}
+
+func (x *BackfillJob) Reset() {
+	*x = BackfillJob{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_backfills_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BackfillJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BackfillJob) ProtoMessage() {}
+
+func (x *BackfillJob) ProtoReflect() protoreflect.Message {
+	mi := &file_backfills_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BackfillJob.ProtoReflect.Descriptor instead.
+func (*BackfillJob) Descriptor() ([]byte, []int) {
+	return file_backfills_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *BackfillJob) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *BackfillJob) GetRepositoryName() string {
+	if x != nil {
+		return x.RepositoryName
+	}
+	return ""
+}
+
+func (x *BackfillJob) GetStartDate() string {
+	if x != nil {
+		return x.StartDate
+	}
+	return ""
+}
+
+func (x *BackfillJob) GetEndDate() string {
+	if x != nil {
+		return x.EndDate
+	}
+	return ""
+}
+
+func (x *BackfillJob) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+var File_backfills_proto protoreflect.FileDescriptor
+
+var file_backfills_proto_rawDesc = []byte{
+	0x0a, 0x0f, 0x62, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x12, 0x09, 0x62, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x73, 0x22, 0x19, 0x0a, 0x17,
+	0x43, 0x6c, 0x61, 0x69, 0x6d, 0x42, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x4a, 0x6f, 0x62,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x22, 0x44, 0x0a, 0x18, 0x43, 0x6c, 0x61, 0x69, 0x6d,
+	0x42, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x4a, 0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x28, 0x0a, 0x03, 0x6a, 0x6f, 0x62, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x16, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x73, 0x2e, 0x42, 0x61, 0x63,
+	0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x4a, 0x6f, 0x62, 0x52, 0x03, 0x6a, 0x6f, 0x62, 0x22, 0x99, 0x01,
+	0x0a, 0x0b, 0x42, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x4a, 0x6f, 0x62, 0x12, 0x0e, 0x0a,
+	0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x02, 0x69, 0x64, 0x12, 0x26, 0x0a,
+	0x0e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72,
+	0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44, 0x61,
+	0x74, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x74, 0x61, 0x72, 0x74, 0x44,
+	0x61, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x6e, 0x64, 0x44, 0x61, 0x74, 0x65, 0x12, 0x1a, 0x0a,
+	0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x08, 0x70, 0x72, 0x69, 0x6f, 0x72, 0x69, 0x74, 0x79, 0x32, 0x6f, 0x0a, 0x10, 0x42, 0x61, 0x63,
+	0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x5b, 0x0a,
+	0x10, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x42, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x4a, 0x6f,
+	0x62, 0x12, 0x22, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x73, 0x2e, 0x43, 0x6c,
+	0x61, 0x69, 0x6d, 0x42, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x4a, 0x6f, 0x62, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x62, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c,
+	0x73, 0x2e, 0x43, 0x6c, 0x61, 0x69, 0x6d, 0x42, 0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x4a,
+	0x6f, 0x62, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0c, 0x5a, 0x0a, 0x2f, 0x62,
+	0x61, 0x63, 0x6b, 0x66, 0x69, 0x6c, 0x6c, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_backfills_proto_rawDescOnce sync.Once
+	file_backfills_proto_rawDescData = file_backfills_proto_rawDesc
+)
+
+func file_backfills_proto_rawDescGZIP() []byte {
+	file_backfills_proto_rawDescOnce.Do(func() {
+		file_backfills_proto_rawDescData = protoimpl.X.CompressGZIP(file_backfills_proto_rawDescData)
+	})
+	return file_backfills_proto_rawDescData
+}
+
+var file_backfills_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_backfills_proto_goTypes = []interface{}{
+	(*ClaimBackfillJobRequest)(nil),  // 0: backfills.ClaimBackfillJobRequest
+	(*ClaimBackfillJobResponse)(nil), // 1: backfills.ClaimBackfillJobResponse
+	(*BackfillJob)(nil),              // 2: backfills.BackfillJob
+}
+var file_backfills_proto_depIdxs = []int32{
+	2, // 0: backfills.ClaimBackfillJobResponse.job:type_name -> backfills.BackfillJob
+	0, // 1: backfills.BackfillsService.ClaimBackfillJob:input_type -> backfills.ClaimBackfillJobRequest
+	1, // 2: backfills.BackfillsService.ClaimBackfillJob:output_type -> backfills.ClaimBackfillJobResponse
+	2, // [2:3] is the sub-list for method output_type
+	1, // [1:2] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_backfills_proto_init() }
+func file_backfills_proto_init() {
+	if File_backfills_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_backfills_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClaimBackfillJobRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backfills_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ClaimBackfillJobResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_backfills_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BackfillJob); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_backfills_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   3,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_backfills_proto_goTypes,
+		DependencyIndexes: file_backfills_proto_depIdxs,
+		MessageInfos:      file_backfills_proto_msgTypes,
+	}.Build()
+	File_backfills_proto = out.File
+	file_backfills_proto_rawDesc = nil
+	file_backfills_proto_goTypes = nil
+	file_backfills_proto_depIdxs = nil
+}
@@ -0,0 +1,105 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.6.1
+// source: backfills.proto
+
+package backfills
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// BackfillsServiceClient is the client API for BackfillsService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type BackfillsServiceClient interface {
+	ClaimBackfillJob(ctx context.Context, in *ClaimBackfillJobRequest, opts ...grpc.CallOption) (*ClaimBackfillJobResponse, error)
+}
+
+type backfillsServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewBackfillsServiceClient(cc grpc.ClientConnInterface) BackfillsServiceClient {
+	return &backfillsServiceClient{cc}
+}
+
+func (c *backfillsServiceClient) ClaimBackfillJob(ctx context.Context, in *ClaimBackfillJobRequest, opts ...grpc.CallOption) (*ClaimBackfillJobResponse, error) {
+	out := new(ClaimBackfillJobResponse)
+	err := c.cc.Invoke(ctx, "/backfills.BackfillsService/ClaimBackfillJob", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// BackfillsServiceServer is the server API for BackfillsService service.
+// All implementations must embed UnimplementedBackfillsServiceServer
+// for forward compatibility
+type BackfillsServiceServer interface {
+	ClaimBackfillJob(context.Context, *ClaimBackfillJobRequest) (*ClaimBackfillJobResponse, error)
+	mustEmbedUnimplementedBackfillsServiceServer()
+}
+
+// UnimplementedBackfillsServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedBackfillsServiceServer struct {
+}
+
+func (UnimplementedBackfillsServiceServer) ClaimBackfillJob(context.Context, *ClaimBackfillJobRequest) (*ClaimBackfillJobResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ClaimBackfillJob not implemented")
+}
+func (UnimplementedBackfillsServiceServer) mustEmbedUnimplementedBackfillsServiceServer() {}
+
+// UnsafeBackfillsServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to BackfillsServiceServer will
+// result in compilation errors.
+type UnsafeBackfillsServiceServer interface {
+	mustEmbedUnimplementedBackfillsServiceServer()
+}
+
+func RegisterBackfillsServiceServer(s grpc.ServiceRegistrar, srv BackfillsServiceServer) {
+	s.RegisterService(&BackfillsService_ServiceDesc, srv)
+}
+
+func _BackfillsService_ClaimBackfillJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ClaimBackfillJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(BackfillsServiceServer).ClaimBackfillJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/backfills.BackfillsService/ClaimBackfillJob",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(BackfillsServiceServer).ClaimBackfillJob(ctx, req.(*ClaimBackfillJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// BackfillsService_ServiceDesc is the grpc.ServiceDesc for BackfillsService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var BackfillsService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "backfills.BackfillsService",
+	HandlerType: (*BackfillsServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ClaimBackfillJob",
+			Handler:    _BackfillsService_ClaimBackfillJob_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "backfills.proto",
+}
@@ -20,16 +20,19 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
-type CommitFetchHistoryRequest struct {
+type CommitWatermarkRequest struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
+	// full name (owner/name) of the repository
 	RepositoryName string `protobuf:"bytes,1,opt,name=repositoryName,proto3" json:"repositoryName,omitempty"`
+	// branch the commits belong to, empty for the whole repository
+	Branch string `protobuf:"bytes,2,opt,name=branch,proto3" json:"branch,omitempty"`
 }
 
-func (x *CommitFetchHistoryRequest) Reset() {
-	*x = CommitFetchHistoryRequest{}
+func (x *CommitWatermarkRequest) Reset() {
+	*x = CommitWatermarkRequest{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_commits_proto_msgTypes[0]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -37,13 +40,13 @@ func (x *CommitFetchHistoryRequest) Reset() {
 	}
 }
 
-func (x *CommitFetchHistoryRequest) String() string {
+func (x *CommitWatermarkRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CommitFetchHistoryRequest) ProtoMessage() {}
+func (*CommitWatermarkRequest) ProtoMessage() {}
 
-func (x *CommitFetchHistoryRequest) ProtoReflect() protoreflect.Message {
+func (x *CommitWatermarkRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_commits_proto_msgTypes[0]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -55,29 +58,36 @@ func (x *CommitFetchHistoryRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CommitFetchHistoryRequest.ProtoReflect.Descriptor instead.
-func (*CommitFetchHistoryRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CommitWatermarkRequest.ProtoReflect.Descriptor instead.
+func (*CommitWatermarkRequest) Descriptor() ([]byte, []int) {
 	return file_commits_proto_rawDescGZIP(), []int{0}
 }
 
-func (x *CommitFetchHistoryRequest) GetRepositoryName() string {
+func (x *CommitWatermarkRequest) GetRepositoryName() string {
 	if x != nil {
 		return x.RepositoryName
 	}
 	return ""
 }
 
-type CommitFetchHistoryResponse struct {
+func (x *CommitWatermarkRequest) GetBranch() string {
+	if x != nil {
+		return x.Branch
+	}
+	return ""
+}
+
+type CommitWatermarkResponse struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
 	unknownFields protoimpl.UnknownFields
 
-	LastFetchTime string `protobuf:"bytes,1,opt,name=lastFetchTime,proto3" json:"lastFetchTime,omitempty"`
-	LastPage      int32  `protobuf:"varint,2,opt,name=lastPage,proto3" json:"lastPage,omitempty"`
+	LastCommitSha  string `protobuf:"bytes,1,opt,name=lastCommitSha,proto3" json:"lastCommitSha,omitempty"`
+	LastCommitDate string `protobuf:"bytes,2,opt,name=lastCommitDate,proto3" json:"lastCommitDate,omitempty"`
 }
 
-func (x *CommitFetchHistoryResponse) Reset() {
-	*x = CommitFetchHistoryResponse{}
+func (x *CommitWatermarkResponse) Reset() {
+	*x = CommitWatermarkResponse{}
 	if protoimpl.UnsafeEnabled {
 		mi := &file_commits_proto_msgTypes[1]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -85,13 +95,13 @@ func (x *CommitFetchHistoryResponse) Reset() {
 	}
 }
 
-func (x *CommitFetchHistoryResponse) String() string {
+func (x *CommitWatermarkResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*CommitFetchHistoryResponse) ProtoMessage() {}
+func (*CommitWatermarkResponse) ProtoMessage() {}
 
-func (x *CommitFetchHistoryResponse) ProtoReflect() protoreflect.Message {
+func (x *CommitWatermarkResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_commits_proto_msgTypes[1]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -103,49 +113,169 @@ func (x *CommitFetchHistoryResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use CommitFetchHistoryResponse.ProtoReflect.Descriptor instead.
-func (*CommitFetchHistoryResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use CommitWatermarkResponse.ProtoReflect.Descriptor instead.
+func (*CommitWatermarkResponse) Descriptor() ([]byte, []int) {
 	return file_commits_proto_rawDescGZIP(), []int{1}
 }
 
-func (x *CommitFetchHistoryResponse) GetLastFetchTime() string {
+func (x *CommitWatermarkResponse) GetLastCommitSha() string {
 	if x != nil {
-		return x.LastFetchTime
+		return x.LastCommitSha
 	}
 	return ""
 }
 
-func (x *CommitFetchHistoryResponse) GetLastPage() int32 {
+func (x *CommitWatermarkResponse) GetLastCommitDate() string {
 	if x != nil {
-		return x.LastPage
+		return x.LastCommitDate
+	}
+	return ""
+}
+
+type CommitsWithoutStatsRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// full name (owner/name) of the repository
+	RepositoryName string `protobuf:"bytes,1,opt,name=repositoryName,proto3" json:"repositoryName,omitempty"`
+	Limit          int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+}
+
+func (x *CommitsWithoutStatsRequest) Reset() {
+	*x = CommitsWithoutStatsRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_commits_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommitsWithoutStatsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitsWithoutStatsRequest) ProtoMessage() {}
+
+func (x *CommitsWithoutStatsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_commits_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitsWithoutStatsRequest.ProtoReflect.Descriptor instead.
+func (*CommitsWithoutStatsRequest) Descriptor() ([]byte, []int) {
+	return file_commits_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CommitsWithoutStatsRequest) GetRepositoryName() string {
+	if x != nil {
+		return x.RepositoryName
+	}
+	return ""
+}
+
+func (x *CommitsWithoutStatsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
 	}
 	return 0
 }
 
+type CommitsWithoutStatsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// SHAs of the stored commits that have no stats yet, newest first
+	Shas []string `protobuf:"bytes,1,rep,name=shas,proto3" json:"shas,omitempty"`
+}
+
+func (x *CommitsWithoutStatsResponse) Reset() {
+	*x = CommitsWithoutStatsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_commits_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CommitsWithoutStatsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitsWithoutStatsResponse) ProtoMessage() {}
+
+func (x *CommitsWithoutStatsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_commits_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitsWithoutStatsResponse.ProtoReflect.Descriptor instead.
+func (*CommitsWithoutStatsResponse) Descriptor() ([]byte, []int) {
+	return file_commits_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CommitsWithoutStatsResponse) GetShas() []string {
+	if x != nil {
+		return x.Shas
+	}
+	return nil
+}
+
 var File_commits_proto protoreflect.FileDescriptor
 
 var file_commits_proto_rawDesc = []byte{
 	0x0a, 0x0d, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
-	0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x22, 0x43, 0x0a, 0x19, 0x43, 0x6f, 0x6d, 0x6d,
-	0x69, 0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65,
-	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74,
-	0x6f, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x72,
-	0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x5e, 0x0a,
-	0x1a, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x48, 0x69, 0x73, 0x74,
-	0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x6c,
-	0x61, 0x73, 0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x54, 0x69, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01,
-	0x28, 0x09, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x54, 0x69, 0x6d,
-	0x65, 0x12, 0x1a, 0x0a, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x50, 0x61, 0x67, 0x65, 0x18, 0x02, 0x20,
-	0x01, 0x28, 0x05, 0x52, 0x08, 0x6c, 0x61, 0x73, 0x74, 0x50, 0x61, 0x67, 0x65, 0x32, 0x72, 0x0a,
-	0x0e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12,
-	0x60, 0x0a, 0x15, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x46, 0x65, 0x74, 0x63,
-	0x68, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x12, 0x22, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x69,
-	0x74, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x46, 0x65, 0x74, 0x63, 0x68, 0x48, 0x69,
-	0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x23, 0x2e, 0x63,
-	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x46, 0x65, 0x74,
-	0x63, 0x68, 0x48, 0x69, 0x73, 0x74, 0x6f, 0x72, 0x79, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
-	0x65, 0x42, 0x0a, 0x5a, 0x08, 0x2f, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x07, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x22, 0x58, 0x0a, 0x16, 0x43, 0x6f, 0x6d, 0x6d,
+	0x69, 0x74, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79,
+	0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x70, 0x6f,
+	0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x62, 0x72,
+	0x61, 0x6e, 0x63, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x62, 0x72, 0x61, 0x6e,
+	0x63, 0x68, 0x22, 0x67, 0x0a, 0x17, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x57, 0x61, 0x74, 0x65,
+	0x72, 0x6d, 0x61, 0x72, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a,
+	0x0d, 0x6c, 0x61, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x53, 0x68, 0x61, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74,
+	0x53, 0x68, 0x61, 0x12, 0x26, 0x0a, 0x0e, 0x6c, 0x61, 0x73, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x44, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x6c, 0x61, 0x73,
+	0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x44, 0x61, 0x74, 0x65, 0x22, 0x5a, 0x0a, 0x1a, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x57, 0x69, 0x74, 0x68, 0x6f, 0x75, 0x74, 0x53, 0x74, 0x61,
+	0x74, 0x73, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x70,
+	0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x0e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x4e, 0x61, 0x6d,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05,
+	0x52, 0x05, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x22, 0x31, 0x0a, 0x1b, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x73, 0x57, 0x69, 0x74, 0x68, 0x6f, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x68, 0x61, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x73, 0x68, 0x61, 0x73, 0x32, 0xce, 0x01, 0x0a, 0x0e, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x57, 0x0a,
+	0x12, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d,
+	0x61, 0x72, 0x6b, 0x12, 0x1f, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x2e, 0x43, 0x6f,
+	0x6d, 0x6d, 0x69, 0x74, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x20, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x2e, 0x43,
+	0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x63, 0x0a, 0x16, 0x47, 0x65, 0x74, 0x43, 0x6f, 0x6d,
+	0x6d, 0x69, 0x74, 0x73, 0x57, 0x69, 0x74, 0x68, 0x6f, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73,
+	0x12, 0x23, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x2e, 0x43, 0x6f, 0x6d, 0x6d, 0x69,
+	0x74, 0x73, 0x57, 0x69, 0x74, 0x68, 0x6f, 0x75, 0x74, 0x53, 0x74, 0x61, 0x74, 0x73, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x24, 0x2e, 0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x2e,
+	0x43, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x57, 0x69, 0x74, 0x68, 0x6f, 0x75, 0x74, 0x53, 0x74,
+	0x61, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x42, 0x0a, 0x5a, 0x08, 0x2f,
+	0x63, 0x6f, 0x6d, 0x6d, 0x69, 0x74, 0x73, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
 }
 
 var (
@@ -160,16 +290,20 @@ func file_commits_proto_rawDescGZIP() []byte {
 	return file_commits_proto_rawDescData
 }
 
-var file_commits_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_commits_proto_msgTypes = make([]protoimpl.MessageInfo, 4)
 var file_commits_proto_goTypes = []interface{}{
-	(*CommitFetchHistoryRequest)(nil),  // 0: commits.CommitFetchHistoryRequest
-	(*CommitFetchHistoryResponse)(nil), // 1: commits.CommitFetchHistoryResponse
+	(*CommitWatermarkRequest)(nil),      // 0: commits.CommitWatermarkRequest
+	(*CommitWatermarkResponse)(nil),     // 1: commits.CommitWatermarkResponse
+	(*CommitsWithoutStatsRequest)(nil),  // 2: commits.CommitsWithoutStatsRequest
+	(*CommitsWithoutStatsResponse)(nil), // 3: commits.CommitsWithoutStatsResponse
 }
 var file_commits_proto_depIdxs = []int32{
-	0, // 0: commits.CommitsService.GetCommitFetchHistory:input_type -> commits.CommitFetchHistoryRequest
-	1, // 1: commits.CommitsService.GetCommitFetchHistory:output_type -> commits.CommitFetchHistoryResponse
-	1, // [1:2] is the sub-list for method output_type
-	0, // [0:1] is the sub-list for method input_type
+	0, // 0: commits.CommitsService.GetCommitWatermark:input_type -> commits.CommitWatermarkRequest
+	2, // 1: commits.CommitsService.GetCommitsWithoutStats:input_type -> commits.CommitsWithoutStatsRequest
+	1, // 2: commits.CommitsService.GetCommitWatermark:output_type -> commits.CommitWatermarkResponse
+	3, // 3: commits.CommitsService.GetCommitsWithoutStats:output_type -> commits.CommitsWithoutStatsResponse
+	2, // [2:4] is the sub-list for method output_type
+	0, // [0:2] is the sub-list for method input_type
 	0, // [0:0] is the sub-list for extension type_name
 	0, // [0:0] is the sub-list for extension extendee
 	0, // [0:0] is the sub-list for field type_name
@@ -182,7 +316,7 @@ func file_commits_proto_init() {
 	}
 	if !protoimpl.UnsafeEnabled {
 		file_commits_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CommitFetchHistoryRequest); i {
+			switch v := v.(*CommitWatermarkRequest); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -194,7 +328,31 @@ func file_commits_proto_init() {
 			}
 		}
 		file_commits_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*CommitFetchHistoryResponse); i {
+			switch v := v.(*CommitWatermarkResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_commits_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommitsWithoutStatsRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_commits_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CommitsWithoutStatsResponse); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -212,7 +370,7 @@ func file_commits_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_commits_proto_rawDesc,
 			NumEnums:      0,
-			NumMessages:   2,
+			NumMessages:   4,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -22,7 +22,8 @@ const _ = grpc.SupportPackageIsVersion7
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type CommitsServiceClient interface {
-	GetCommitFetchHistory(ctx context.Context, in *CommitFetchHistoryRequest, opts ...grpc.CallOption) (*CommitFetchHistoryResponse, error)
+	GetCommitWatermark(ctx context.Context, in *CommitWatermarkRequest, opts ...grpc.CallOption) (*CommitWatermarkResponse, error)
+	GetCommitsWithoutStats(ctx context.Context, in *CommitsWithoutStatsRequest, opts ...grpc.CallOption) (*CommitsWithoutStatsResponse, error)
 }
 
 type commitsServiceClient struct {
@@ -33,9 +34,18 @@ func NewCommitsServiceClient(cc grpc.ClientConnInterface) CommitsServiceClient {
 	return &commitsServiceClient{cc}
 }
 
-func (c *commitsServiceClient) GetCommitFetchHistory(ctx context.Context, in *CommitFetchHistoryRequest, opts ...grpc.CallOption) (*CommitFetchHistoryResponse, error) {
-	out := new(CommitFetchHistoryResponse)
-	err := c.cc.Invoke(ctx, "/commits.CommitsService/GetCommitFetchHistory", in, out, opts...)
+func (c *commitsServiceClient) GetCommitWatermark(ctx context.Context, in *CommitWatermarkRequest, opts ...grpc.CallOption) (*CommitWatermarkResponse, error) {
+	out := new(CommitWatermarkResponse)
+	err := c.cc.Invoke(ctx, "/commits.CommitsService/GetCommitWatermark", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *commitsServiceClient) GetCommitsWithoutStats(ctx context.Context, in *CommitsWithoutStatsRequest, opts ...grpc.CallOption) (*CommitsWithoutStatsResponse, error) {
+	out := new(CommitsWithoutStatsResponse)
+	err := c.cc.Invoke(ctx, "/commits.CommitsService/GetCommitsWithoutStats", in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -46,7 +56,8 @@ func (c *commitsServiceClient) GetCommitFetchHistory(ctx context.Context, in *Co
 // All implementations must embed UnimplementedCommitsServiceServer
 // for forward compatibility
 type CommitsServiceServer interface {
-	GetCommitFetchHistory(context.Context, *CommitFetchHistoryRequest) (*CommitFetchHistoryResponse, error)
+	GetCommitWatermark(context.Context, *CommitWatermarkRequest) (*CommitWatermarkResponse, error)
+	GetCommitsWithoutStats(context.Context, *CommitsWithoutStatsRequest) (*CommitsWithoutStatsResponse, error)
 	mustEmbedUnimplementedCommitsServiceServer()
 }
 
@@ -54,8 +65,11 @@ type CommitsServiceServer interface {
 type UnimplementedCommitsServiceServer struct {
 }
 
-func (UnimplementedCommitsServiceServer) GetCommitFetchHistory(context.Context, *CommitFetchHistoryRequest) (*CommitFetchHistoryResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetCommitFetchHistory not implemented")
+func (UnimplementedCommitsServiceServer) GetCommitWatermark(context.Context, *CommitWatermarkRequest) (*CommitWatermarkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommitWatermark not implemented")
+}
+func (UnimplementedCommitsServiceServer) GetCommitsWithoutStats(context.Context, *CommitsWithoutStatsRequest) (*CommitsWithoutStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCommitsWithoutStats not implemented")
 }
 func (UnimplementedCommitsServiceServer) mustEmbedUnimplementedCommitsServiceServer() {}
 
@@ -70,20 +84,38 @@ func RegisterCommitsServiceServer(s grpc.ServiceRegistrar, srv CommitsServiceSer
 	s.RegisterService(&CommitsService_ServiceDesc, srv)
 }
 
-func _CommitsService_GetCommitFetchHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CommitFetchHistoryRequest)
+func _CommitsService_GetCommitWatermark_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitWatermarkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(CommitsServiceServer).GetCommitWatermark(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/commits.CommitsService/GetCommitWatermark",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(CommitsServiceServer).GetCommitWatermark(ctx, req.(*CommitWatermarkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _CommitsService_GetCommitsWithoutStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitsWithoutStatsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(CommitsServiceServer).GetCommitFetchHistory(ctx, in)
+		return srv.(CommitsServiceServer).GetCommitsWithoutStats(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/commits.CommitsService/GetCommitFetchHistory",
+		FullMethod: "/commits.CommitsService/GetCommitsWithoutStats",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(CommitsServiceServer).GetCommitFetchHistory(ctx, req.(*CommitFetchHistoryRequest))
+		return srv.(CommitsServiceServer).GetCommitsWithoutStats(ctx, req.(*CommitsWithoutStatsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -96,8 +128,12 @@ var CommitsService_ServiceDesc = grpc.ServiceDesc{
 	HandlerType: (*CommitsServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "GetCommitFetchHistory",
-			Handler:    _CommitsService_GetCommitFetchHistory_Handler,
+			MethodName: "GetCommitWatermark",
+			Handler:    _CommitsService_GetCommitWatermark_Handler,
+		},
+		{
+			MethodName: "GetCommitsWithoutStats",
+			Handler:    _CommitsService_GetCommitsWithoutStats_Handler,
 		},
 	},
 	Streams:  []grpc.StreamDesc{},
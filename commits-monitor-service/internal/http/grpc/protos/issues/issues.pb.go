@@ -0,0 +1,219 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.28.1
+// 	protoc        v3.6.1
+// source: issues.proto
+
+package issues
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type IssuesWatermarkRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// full name (owner/name) of the repository
+	RepositoryName string `protobuf:"bytes,1,opt,name=repositoryName,proto3" json:"repositoryName,omitempty"`
+}
+
+func (x *IssuesWatermarkRequest) Reset() {
+	*x = IssuesWatermarkRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_issues_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IssuesWatermarkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssuesWatermarkRequest) ProtoMessage() {}
+
+func (x *IssuesWatermarkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_issues_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssuesWatermarkRequest.ProtoReflect.Descriptor instead.
+func (*IssuesWatermarkRequest) Descriptor() ([]byte, []int) {
+	return file_issues_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *IssuesWatermarkRequest) GetRepositoryName() string {
+	if x != nil {
+		return x.RepositoryName
+	}
+	return ""
+}
+
+type IssuesWatermarkResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// when the most recently updated stored issue was updated, empty when
+	// none is stored
+	LastUpdatedAt string `protobuf:"bytes,1,opt,name=lastUpdatedAt,proto3" json:"lastUpdatedAt,omitempty"`
+}
+
+func (x *IssuesWatermarkResponse) Reset() {
+	*x = IssuesWatermarkResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_issues_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *IssuesWatermarkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssuesWatermarkResponse) ProtoMessage() {}
+
+func (x *IssuesWatermarkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_issues_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssuesWatermarkResponse.ProtoReflect.Descriptor instead.
+func (*IssuesWatermarkResponse) Descriptor() ([]byte, []int) {
+	return file_issues_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *IssuesWatermarkResponse) GetLastUpdatedAt() string {
+	if x != nil {
+		return x.LastUpdatedAt
+	}
+	return ""
+}
+
+var File_issues_proto protoreflect.FileDescriptor
+
+var file_issues_proto_rawDesc = []byte{
+	0x0a, 0x0c, 0x69, 0x73, 0x73, 0x75, 0x65, 0x73, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x06,
+	0x69, 0x73, 0x73, 0x75, 0x65, 0x73, 0x22, 0x40, 0x0a, 0x16, 0x49, 0x73, 0x73, 0x75, 0x65, 0x73,
+	0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x26, 0x0a, 0x0e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69, 0x74, 0x6f, 0x72, 0x79, 0x4e, 0x61,
+	0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0e, 0x72, 0x65, 0x70, 0x6f, 0x73, 0x69,
+	0x74, 0x6f, 0x72, 0x79, 0x4e, 0x61, 0x6d, 0x65, 0x22, 0x3f, 0x0a, 0x17, 0x49, 0x73, 0x73, 0x75,
+	0x65, 0x73, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x24, 0x0a, 0x0d, 0x6c, 0x61, 0x73, 0x74, 0x55, 0x70, 0x64, 0x61, 0x74,
+	0x65, 0x64, 0x41, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0d, 0x6c, 0x61, 0x73, 0x74,
+	0x55, 0x70, 0x64, 0x61, 0x74, 0x65, 0x64, 0x41, 0x74, 0x32, 0x66, 0x0a, 0x0d, 0x49, 0x73, 0x73,
+	0x75, 0x65, 0x73, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x55, 0x0a, 0x12, 0x47, 0x65,
+	0x74, 0x49, 0x73, 0x73, 0x75, 0x65, 0x73, 0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b,
+	0x12, 0x1e, 0x2e, 0x69, 0x73, 0x73, 0x75, 0x65, 0x73, 0x2e, 0x49, 0x73, 0x73, 0x75, 0x65, 0x73,
+	0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1f, 0x2e, 0x69, 0x73, 0x73, 0x75, 0x65, 0x73, 0x2e, 0x49, 0x73, 0x73, 0x75, 0x65, 0x73,
+	0x57, 0x61, 0x74, 0x65, 0x72, 0x6d, 0x61, 0x72, 0x6b, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x42, 0x09, 0x5a, 0x07, 0x2f, 0x69, 0x73, 0x73, 0x75, 0x65, 0x73, 0x62, 0x06, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_issues_proto_rawDescOnce sync.Once
+	file_issues_proto_rawDescData = file_issues_proto_rawDesc
+)
+
+func file_issues_proto_rawDescGZIP() []byte {
+	file_issues_proto_rawDescOnce.Do(func() {
+		file_issues_proto_rawDescData = protoimpl.X.CompressGZIP(file_issues_proto_rawDescData)
+	})
+	return file_issues_proto_rawDescData
+}
+
+var file_issues_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_issues_proto_goTypes = []interface{}{
+	(*IssuesWatermarkRequest)(nil),  // 0: issues.IssuesWatermarkRequest
+	(*IssuesWatermarkResponse)(nil), // 1: issues.IssuesWatermarkResponse
+}
+var file_issues_proto_depIdxs = []int32{
+	0, // 0: issues.IssuesService.GetIssuesWatermark:input_type -> issues.IssuesWatermarkRequest
+	1, // 1: issues.IssuesService.GetIssuesWatermark:output_type -> issues.IssuesWatermarkResponse
+	1, // [1:2] is the sub-list for method output_type
+	0, // [0:1] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_issues_proto_init() }
+func file_issues_proto_init() {
+	if File_issues_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_issues_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IssuesWatermarkRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_issues_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*IssuesWatermarkResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_issues_proto_rawDesc,
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_issues_proto_goTypes,
+		DependencyIndexes: file_issues_proto_depIdxs,
+		MessageInfos:      file_issues_proto_msgTypes,
+	}.Build()
+	File_issues_proto = out.File
+	file_issues_proto_rawDesc = nil
+	file_issues_proto_goTypes = nil
+	file_issues_proto_depIdxs = nil
+}
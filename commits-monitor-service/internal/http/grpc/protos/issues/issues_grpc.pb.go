@@ -0,0 +1,105 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.2.0
+// - protoc             v3.6.1
+// source: issues.proto
+
+package issues
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.32.0 or later.
+const _ = grpc.SupportPackageIsVersion7
+
+// IssuesServiceClient is the client API for IssuesService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type IssuesServiceClient interface {
+	GetIssuesWatermark(ctx context.Context, in *IssuesWatermarkRequest, opts ...grpc.CallOption) (*IssuesWatermarkResponse, error)
+}
+
+type issuesServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIssuesServiceClient(cc grpc.ClientConnInterface) IssuesServiceClient {
+	return &issuesServiceClient{cc}
+}
+
+func (c *issuesServiceClient) GetIssuesWatermark(ctx context.Context, in *IssuesWatermarkRequest, opts ...grpc.CallOption) (*IssuesWatermarkResponse, error) {
+	out := new(IssuesWatermarkResponse)
+	err := c.cc.Invoke(ctx, "/issues.IssuesService/GetIssuesWatermark", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IssuesServiceServer is the server API for IssuesService service.
+// All implementations must embed UnimplementedIssuesServiceServer
+// for forward compatibility
+type IssuesServiceServer interface {
+	GetIssuesWatermark(context.Context, *IssuesWatermarkRequest) (*IssuesWatermarkResponse, error)
+	mustEmbedUnimplementedIssuesServiceServer()
+}
+
+// UnimplementedIssuesServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedIssuesServiceServer struct {
+}
+
+func (UnimplementedIssuesServiceServer) GetIssuesWatermark(context.Context, *IssuesWatermarkRequest) (*IssuesWatermarkResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIssuesWatermark not implemented")
+}
+func (UnimplementedIssuesServiceServer) mustEmbedUnimplementedIssuesServiceServer() {}
+
+// UnsafeIssuesServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to IssuesServiceServer will
+// result in compilation errors.
+type UnsafeIssuesServiceServer interface {
+	mustEmbedUnimplementedIssuesServiceServer()
+}
+
+func RegisterIssuesServiceServer(s grpc.ServiceRegistrar, srv IssuesServiceServer) {
+	s.RegisterService(&IssuesService_ServiceDesc, srv)
+}
+
+func _IssuesService_GetIssuesWatermark_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IssuesWatermarkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IssuesServiceServer).GetIssuesWatermark(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/issues.IssuesService/GetIssuesWatermark",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IssuesServiceServer).GetIssuesWatermark(ctx, req.(*IssuesWatermarkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// IssuesService_ServiceDesc is the grpc.ServiceDesc for IssuesService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var IssuesService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "issues.IssuesService",
+	HandlerType: (*IssuesServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetIssuesWatermark",
+			Handler:    _IssuesService_GetIssuesWatermark_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "issues.proto",
+}
@@ -0,0 +1,179 @@
+package webhook
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxPayloadSize is the largest payload GitHub delivers.
+const maxPayloadSize = 25 << 20
+
+// EventHandler turns the webhook deliveries into events. It is implemented
+// by the commits monitor service.
+type EventHandler interface {
+	HandlePush(push models.PushEvent) error
+	HandleRepository(repository models.RepositoryEvent) error
+	HandleCreate(ref models.RefEvent) error
+	HandleDelete(ref models.RefEvent) error
+}
+
+// Receiver accepts GitHub webhook deliveries signed with the secret. Every
+// delivery is handled once, redeliveries with a known X-GitHub-Delivery are
+// acknowledged without being handled again.
+type Receiver struct {
+	secret     []byte
+	handler    EventHandler
+	deliveries *deliveries
+}
+
+// NewReceiver creates a Receiver of deliveries signed with secret.
+func NewReceiver(secret string, handler EventHandler) *Receiver {
+	return &Receiver{
+		secret:     []byte(secret),
+		handler:    handler,
+		deliveries: newDeliveries(10000, 24*time.Hour),
+	}
+}
+
+func (rc *Receiver) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, maxPayloadSize))
+	if err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if !rc.verify(r.Header.Get("X-Hub-Signature-256"), body) {
+		log.Println("CMOS: webhook delivery with an invalid signature rejected")
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	delivery := r.Header.Get("X-GitHub-Delivery")
+	if delivery == "" {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if !rc.deliveries.claim(delivery) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	eventName := r.Header.Get("X-GitHub-Event")
+	err = rc.handle(eventName, body)
+	if err != nil {
+		// a failed delivery is handled again when GitHub redelivers it
+		rc.deliveries.release(delivery)
+		log.Printf("CMOS: error handling %s webhook delivery %s\n", eventName, delivery)
+		log.Println("CMOS: err:", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// verify reports whether signature is the sha256 HMAC of body with the
+// secret, as sent by GitHub in X-Hub-Signature-256.
+func (rc *Receiver) verify(signature string, body []byte) bool {
+	hexDigest, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	digest, err := hex.DecodeString(hexDigest)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, rc.secret)
+	mac.Write(body)
+	return hmac.Equal(digest, mac.Sum(nil))
+}
+
+// handle decodes a delivery and passes it to the handler. Events other than
+// push, repository, create and delete are ignored.
+func (rc *Receiver) handle(eventName string, body []byte) error {
+	switch eventName {
+	case "push":
+		var push models.PushEvent
+		if err := json.Unmarshal(body, &push); err != nil {
+			return err
+		}
+		return rc.handler.HandlePush(push)
+	case "repository":
+		var repository models.RepositoryEvent
+		if err := json.Unmarshal(body, &repository); err != nil {
+			return err
+		}
+		return rc.handler.HandleRepository(repository)
+	case "create", "delete":
+		var ref models.RefEvent
+		if err := json.Unmarshal(body, &ref); err != nil {
+			return err
+		}
+		if eventName == "create" {
+			return rc.handler.HandleCreate(ref)
+		}
+		return rc.handler.HandleDelete(ref)
+	default:
+		return nil
+	}
+}
+
+// deliveries remembers the IDs of the deliveries handled for a while, up to
+// a maximum count, dropping the oldest first.
+type deliveries struct {
+	mu    sync.Mutex
+	max   int
+	ttl   time.Duration
+	at    map[string]time.Time
+	order []string
+}
+
+func newDeliveries(max int, ttl time.Duration) *deliveries {
+	return &deliveries{max: max, ttl: ttl, at: make(map[string]time.Time)}
+}
+
+// claim records a delivery as handled. It returns false when it already was.
+func (d *deliveries) claim(id string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	now := time.Now()
+	for len(d.order) > 0 && (len(d.order) >= d.max || now.Sub(d.at[d.order[0]]) >= d.ttl) {
+		delete(d.at, d.order[0])
+		d.order = d.order[1:]
+	}
+
+	if _, ok := d.at[id]; ok {
+		return false
+	}
+	d.at[id] = now
+	d.order = append(d.order, id)
+	return true
+}
+
+// release forgets a delivery that could not be handled.
+func (d *deliveries) release(id string) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	delete(d.at, id)
+	for i, claimed := range d.order {
+		if claimed == id {
+			d.order = append(d.order[:i], d.order[i+1:]...)
+			break
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package webhook
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type recordingHandler struct {
+	pushes       []models.PushEvent
+	repositories []models.RepositoryEvent
+	created      []models.RefEvent
+	err          error
+}
+
+func (h *recordingHandler) HandlePush(push models.PushEvent) error {
+	h.pushes = append(h.pushes, push)
+	return h.err
+}
+
+func (h *recordingHandler) HandleRepository(repository models.RepositoryEvent) error {
+	h.repositories = append(h.repositories, repository)
+	return h.err
+}
+
+func (h *recordingHandler) HandleCreate(ref models.RefEvent) error {
+	h.created = append(h.created, ref)
+	return h.err
+}
+
+func (h *recordingHandler) HandleDelete(ref models.RefEvent) error {
+	return h.err
+}
+
+func deliver(receiver *Receiver, event, delivery, secret, body string) int {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+
+	r := httptest.NewRequest(http.MethodPost, "/webhooks/github", strings.NewReader(body))
+	r.Header.Set("X-GitHub-Event", event)
+	r.Header.Set("X-GitHub-Delivery", delivery)
+	r.Header.Set("X-Hub-Signature-256", "sha256="+hex.EncodeToString(mac.Sum(nil)))
+	w := httptest.NewRecorder()
+	receiver.ServeHTTP(w, r)
+	return w.Code
+}
+
+func TestReceiver(t *testing.T) {
+	handler := &recordingHandler{}
+	receiver := NewReceiver("s3cret", handler)
+
+	push := `{
+	  "ref": "refs/heads/main",
+	  "before": "1111",
+	  "after": "2222",
+	  "repository": {"full_name": "chromium/chromium", "default_branch": "main", "created_at": 1722592800},
+	  "commits": [{"id": "2222", "message": "Fix", "timestamp": "2024-08-02T12:00:00+02:00", "author": {"name": "Octo Cat"}}]
+	}`
+	require.Equal(t, http.StatusUnauthorized, deliver(receiver, "push", "a", "wrong", push))
+	require.Empty(t, handler.pushes)
+
+	require.Equal(t, http.StatusAccepted, deliver(receiver, "push", "a", "s3cret", push))
+	require.Len(t, handler.pushes, 1)
+	require.Equal(t, "chromium/chromium", handler.pushes[0].Repository.FullName)
+	require.Equal(t, "Octo Cat", handler.pushes[0].Commits[0].Author.Name)
+
+	// redeliveries are acknowledged once handled
+	require.Equal(t, http.StatusOK, deliver(receiver, "push", "a", "s3cret", push))
+	require.Len(t, handler.pushes, 1)
+
+	require.Equal(t, http.StatusAccepted, deliver(receiver, "repository", "b", "s3cret",
+		`{"action": "renamed", "repository": {"full_name": "chromium/chrome"}, "changes": {"repository": {"name": {"from": "chromium"}}}}`))
+	require.Equal(t, "chromium", handler.repositories[0].Changes.Repository.Name.From)
+
+	require.Equal(t, http.StatusAccepted, deliver(receiver, "create", "c", "s3cret",
+		`{"ref": "release/2.3", "ref_type": "branch", "repository": {"full_name": "chromium/chromium"}}`))
+	require.Equal(t, "branch", handler.created[0].RefType)
+
+	require.Equal(t, http.StatusAccepted, deliver(receiver, "star", "d", "s3cret", `{}`))
+
+	// failed deliveries are handled again when redelivered
+	handler.err = errors.New("rabbitmq is down")
+	require.Equal(t, http.StatusInternalServerError, deliver(receiver, "push", "e", "s3cret", push))
+	handler.err = nil
+	require.Equal(t, http.StatusAccepted, deliver(receiver, "push", "e", "s3cret", push))
+	require.Len(t, handler.pushes, 3)
+}
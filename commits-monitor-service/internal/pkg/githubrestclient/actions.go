@@ -0,0 +1,131 @@
+package githubrestclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxListedWorkflowRuns is the number of workflow runs GitHub lists at most
+// when they are filtered by creation time.
+const maxListedWorkflowRuns = 1000
+
+// maxWorkflowRunRangeSplits caps the requests spent narrowing the creation
+// range of a workflow runs walk.
+const maxWorkflowRunRangeSplits = 20
+
+// FetchWorkflowRuns fetches a page of the workflow runs of a repository
+// created at or after since, oldest first. GitHub lists runs newest first and
+// at most 1000 of them, so the first call narrows the creation range down to
+// no more runs than that and the pages of the range are walked from the last
+// one. Runs created after the range are left to the next walk. The cursor
+// holds the next page and the end of the range, empty for the first page.
+func (gp GithubRestClient) FetchWorkflowRuns(repositoryName string, since string, cursor string) (models.WorkflowRunsPage, error) {
+	var page int
+	var until string
+	if cursor == "" {
+		var err error
+		page, until, err = gp.workflowRunsRange(repositoryName, since)
+		if err != nil {
+			return models.WorkflowRunsPage{}, err
+		}
+	} else {
+		pageNumber, end, _ := strings.Cut(cursor, "@")
+		page, _ = strconv.Atoi(pageNumber)
+		until = end
+	}
+	if page < 1 {
+		return models.WorkflowRunsPage{}, nil
+	}
+
+	runs, _, err := gp.listWorkflowRuns(repositoryName, since, until, page, 100)
+	if err != nil {
+		return models.WorkflowRunsPage{}, err
+	}
+	for i, j := 0, len(runs)-1; i < j; i, j = i+1, j-1 {
+		runs[i], runs[j] = runs[j], runs[i]
+	}
+
+	var next string
+	if page > 1 {
+		next = fmt.Sprintf("%d@%s", page-1, until)
+	}
+	runsPage := models.WorkflowRunsPage{Runs: make([]models.WorkflowRun, 0, len(runs)), Next: next}
+	for _, run := range runs {
+		runsPage.Runs = append(runsPage.Runs, workflowRunOf(run))
+	}
+	return runsPage, nil
+}
+
+// workflowRunsRange returns the end of the creation range of a workflow runs
+// walk starting at since and its last page. The range ends now unless more
+// runs were created since then than GitHub lists, in which case its end is
+// moved back until they fit.
+func (gp GithubRestClient) workflowRunsRange(repositoryName string, since string) (int, string, error) {
+	low, _ := time.Parse(time.RFC3339, since)
+	high := time.Now().UTC().Truncate(time.Second)
+	until := high.Format(time.RFC3339)
+	_, total, err := gp.listWorkflowRuns(repositoryName, since, until, 1, 1)
+	if err != nil {
+		return 0, "", err
+	}
+
+	for split := 0; total > maxListedWorkflowRuns && split < maxWorkflowRunRangeSplits; split++ {
+		middle := low.Add(high.Sub(low) / 2).Truncate(time.Second)
+		_, count, err := gp.listWorkflowRuns(repositoryName, since, middle.Format(time.RFC3339), 1, 1)
+		if err != nil {
+			return 0, "", err
+		}
+		switch {
+		case count > maxListedWorkflowRuns:
+			high, until, total = middle, middle.Format(time.RFC3339), count
+		case count > 0:
+			until, total = middle.Format(time.RFC3339), count
+		default:
+			low = middle
+		}
+	}
+	if total > maxListedWorkflowRuns {
+		log.Printf("CMOS: repo <%s> more than %d workflow runs created up to %s, older ones are skipped\n",
+			repositoryName, maxListedWorkflowRuns, until)
+		total = maxListedWorkflowRuns
+	}
+	return (total + 99) / 100, until, nil
+}
+
+// listWorkflowRuns lists a page of the workflow runs of a repository created
+// between since and until, newest first, with their total count.
+func (gp GithubRestClient) listWorkflowRuns(repositoryName string, since string, until string, page int, perPage int) ([]models.WorkflowRunResponse, int, error) {
+	created := "<=" + until
+	if since != "" {
+		created = since + ".." + until
+	}
+	queryParams := map[string]string{
+		"created":  created,
+		"per_page": strconv.Itoa(perPage),
+		"page":     strconv.Itoa(page),
+	}
+
+	response, err := gp.get(buildURI(gp.baseURL, fmt.Sprintf("/repos/%s/actions/runs", repositoryName), queryParams))
+	if err != nil {
+		return nil, 0, err
+	}
+	if response.statusCode != http.StatusOK {
+		return nil, 0, newAPIError(response.statusCode, response.header, response.body)
+	}
+
+	var listed struct {
+		TotalCount   int                          `json:"total_count"`
+		WorkflowRuns []models.WorkflowRunResponse `json:"workflow_runs"`
+	}
+	if err := json.Unmarshal(response.body, &listed); err != nil {
+		log.Println("CMOS: Error unmarshalling response body:", err)
+		return nil, 0, err
+	}
+	return listed.WorkflowRuns, listed.TotalCount, nil
+}
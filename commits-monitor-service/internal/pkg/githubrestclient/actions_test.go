@@ -0,0 +1,112 @@
+package githubrestclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// workflowRunsServer serves runs like GitHub: filtered by creation time,
+// newest first, with their total count.
+func workflowRunsServer(t *testing.T, runs []models.WorkflowRunResponse) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/chromium/chromium/actions/runs" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		since, until, ok := strings.Cut(r.URL.Query().Get("created"), "..")
+		require.True(t, ok)
+		from, err := time.Parse(time.RFC3339, since)
+		require.NoError(t, err)
+		to, err := time.Parse(time.RFC3339, until)
+		require.NoError(t, err)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+
+		var matching []models.WorkflowRunResponse
+		for i := len(runs) - 1; i >= 0; i-- {
+			if !runs[i].CreatedAt.Before(from) && !runs[i].CreatedAt.After(to) {
+				matching = append(matching, runs[i])
+			}
+		}
+		var listed []models.WorkflowRunResponse
+		if start := (page - 1) * perPage; start < len(matching) && start < maxListedWorkflowRuns {
+			listed = matching[start:min(start+perPage, len(matching))]
+		}
+		json.NewEncoder(w).Encode(map[string]any{"total_count": len(matching), "workflow_runs": listed})
+	}))
+}
+
+func TestFetchWorkflowRuns(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.True(t, strings.HasPrefix(r.URL.Query().Get("created"), "2024-08-01T00:00:00Z.."))
+		w.Write([]byte(`{"total_count": 2, "workflow_runs": [{
+		  "id": 2,
+		  "name": "CI",
+		  "workflow_id": 7,
+		  "head_branch": "main",
+		  "head_sha": "2222",
+		  "event": "push",
+		  "status": "in_progress",
+		  "conclusion": null,
+		  "run_attempt": 1,
+		  "created_at": "2024-08-02T10:00:00Z",
+		  "updated_at": "2024-08-02T10:05:00Z",
+		  "run_started_at": "2024-08-02T10:00:30Z"
+		}, {"id": 1, "name": "CI", "head_sha": "1111", "status": "completed", "conclusion": "failure",
+		  "created_at": "2024-08-01T10:00:00Z"}]}`))
+	}))
+	defer server.Close()
+
+	client, err := NewGithubRestClient(&models.Config{GithubAPIURL: server.URL})
+	require.NoError(t, err)
+
+	// oldest first
+	page, err := client.FetchWorkflowRuns("chromium/chromium", "2024-08-01T00:00:00Z", "")
+	require.NoError(t, err)
+	require.Empty(t, page.Next)
+	require.Len(t, page.Runs, 2)
+	require.Equal(t, "failure", page.Runs[0].Conclusion)
+	require.Equal(t, "in_progress", page.Runs[1].Status)
+	require.Empty(t, page.Runs[1].Conclusion)
+	require.Equal(t, int64(7), page.Runs[1].WorkflowID)
+}
+
+func TestFetchWorkflowRunsNarrowsTheRange(t *testing.T) {
+	start := time.Date(2024, 8, 1, 0, 0, 0, 0, time.UTC)
+	runs := make([]models.WorkflowRunResponse, 2500)
+	for i := range runs {
+		runs[i] = models.WorkflowRunResponse{ID: int64(i + 1), CreatedAt: start.Add(time.Duration(i) * time.Minute)}
+	}
+	server := workflowRunsServer(t, runs)
+	defer server.Close()
+
+	client, err := NewGithubRestClient(&models.Config{GithubAPIURL: server.URL})
+	require.NoError(t, err)
+
+	var fetched []models.WorkflowRun
+	var cursor string
+	for {
+		page, err := client.FetchWorkflowRuns("chromium/chromium", start.Format(time.RFC3339), cursor)
+		require.NoError(t, err)
+		fetched = append(fetched, page.Runs...)
+		if page.Next == "" {
+			break
+		}
+		cursor = page.Next
+	}
+
+	// the oldest runs, oldest first, none skipped
+	require.NotEmpty(t, fetched)
+	require.LessOrEqual(t, len(fetched), maxListedWorkflowRuns)
+	for i, run := range fetched {
+		require.Equal(t, int64(i+1), run.ID)
+	}
+}
@@ -0,0 +1,110 @@
+package githubrestclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// newTokenEndpointStub serves installation tokens that expire after the
+// given lifetime and verifies the app JWT of every request.
+func newTokenEndpointStub(t *testing.T, key *rsa.PrivateKey, lifetime time.Duration) (*httptest.Server, *int) {
+	var issued int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "/app/installations/99/access_tokens", r.URL.Path)
+
+		jwt := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		parts := strings.Split(jwt, ".")
+		require.Len(t, parts, 3)
+
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		require.NoError(t, err)
+		digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+		require.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+
+		claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+		var claims struct {
+			Iss string `json:"iss"`
+			Iat int64  `json:"iat"`
+			Exp int64  `json:"exp"`
+		}
+		require.NoError(t, json.Unmarshal(claimsJSON, &claims))
+		require.Equal(t, "42", claims.Iss)
+		require.Less(t, claims.Exp-claims.Iat, int64(10*time.Minute/time.Second)+1)
+
+		issued++
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]any{
+			"token":      fmt.Sprintf("ghs_%d", issued),
+			"expires_at": time.Now().Add(lifetime).UTC().Format(time.RFC3339),
+		})
+	}))
+	return server, &issued
+}
+
+func TestAppTokenSource(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+	server, issued := newTokenEndpointStub(t, key, time.Hour)
+	defer server.Close()
+
+	tokens, err := newAppTokenSource(&models.Config{
+		GithubAppID:             "42",
+		GithubAppInstallationID: "99",
+		GithubAppPrivateKey:     string(keyPEM),
+	}, server.URL, server.Client())
+	require.NoError(t, err)
+
+	token, err := tokens.token()
+	require.NoError(t, err)
+	require.Equal(t, "ghs_1", token)
+
+	// the token is reused until it gets close to its expiry
+	token, err = tokens.token()
+	require.NoError(t, err)
+	require.Equal(t, "ghs_1", token)
+	require.Equal(t, 1, *issued)
+}
+
+func TestAppTokenSourceRefreshesBeforeExpiry(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: keyDER})
+
+	server, issued := newTokenEndpointStub(t, key, installationTokenRefreshMargin/2)
+	defer server.Close()
+
+	tokens, err := newAppTokenSource(&models.Config{
+		GithubAppID:             "42",
+		GithubAppInstallationID: "99",
+		GithubAppPrivateKey:     string(keyPEM),
+	}, server.URL, server.Client())
+	require.NoError(t, err)
+
+	first, err := tokens.token()
+	require.NoError(t, err)
+	second, err := tokens.token()
+	require.NoError(t, err)
+	require.NotEqual(t, first, second)
+	require.Equal(t, 2, *issued)
+}
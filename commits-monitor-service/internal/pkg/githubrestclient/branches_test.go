@@ -0,0 +1,53 @@
+package githubrestclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchBranches(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/chromium/chromium":
+			w.Write([]byte(`{"full_name": "chromium/chromium", "default_branch": "main"}`))
+		case "/repos/chromium/chromium/branches":
+			if r.URL.Query().Get("page") == "2" {
+				w.Write([]byte(`[{"name": "release/1.0", "commit": {"sha": "3333"}, "protected": true}]`))
+				return
+			}
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/chromium/chromium/branches?per_page=100&page=2>; rel="next"`, server.URL))
+			w.Write([]byte(`[{"name": "main", "commit": {"sha": "1111"}}, {"name": "feature/x", "commit": {"sha": "2222"}}]`))
+		case "/repos/chromium/chromium/commits":
+			require.Equal(t, "release/1.0", r.URL.Query().Get("sha"))
+			w.Write([]byte(`[]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewGithubRestClient(&models.Config{GithubAPIURL: server.URL})
+	require.NoError(t, err)
+
+	defaultBranch, err := client.FetchDefaultBranch("chromium/chromium")
+	require.NoError(t, err)
+	require.Equal(t, "main", defaultBranch)
+
+	branches, err := client.FetchBranches("chromium/chromium")
+	require.NoError(t, err)
+	require.Len(t, branches, 3)
+	require.Equal(t, "feature/x", branches[1].Name)
+	require.Equal(t, "2222", branches[1].HeadSha)
+
+	_, err = client.FetchCommits("chromium/chromium", "release/1.0", "", "", 100, "")
+	require.NoError(t, err)
+
+	_, err = client.FetchBranches("chromium/gone")
+	require.ErrorIs(t, err, ErrNotFound)
+}
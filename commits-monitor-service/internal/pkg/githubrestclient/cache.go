@@ -0,0 +1,52 @@
+package githubrestclient
+
+import (
+	"sync"
+)
+
+// maxCacheEntries bounds the number of responses kept for revalidation.
+const maxCacheEntries = 10000
+
+// cachedResponse is a GitHub response kept to revalidate with a
+// conditional request on the next poll.
+type cachedResponse struct {
+	etag         string
+	lastModified string
+	link         string
+	body         []byte
+}
+
+// responseCache keeps the validators and bodies of successful responses
+// keyed by request URL. Unchanged pages come back as 304, which GitHub
+// does not count against the rate limit.
+type responseCache struct {
+	mu      sync.Mutex
+	entries map[string]cachedResponse
+}
+
+func newResponseCache() *responseCache {
+	return &responseCache{entries: make(map[string]cachedResponse)}
+}
+
+func (c *responseCache) get(key string) (cachedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	entry, ok := c.entries[key]
+	return entry, ok
+}
+
+func (c *responseCache) set(key string, entry cachedResponse) {
+	if entry.etag == "" && entry.lastModified == "" {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= maxCacheEntries {
+		for k := range c.entries {
+			delete(c.entries, k)
+			break
+		}
+	}
+	c.entries[key] = entry
+}
@@ -0,0 +1,45 @@
+package githubrestclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConditionalGet(t *testing.T) {
+	const etag = `"abc"`
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(`[{"sha":"1"}]`))
+	}))
+	defer server.Close()
+
+	client, err := NewGithubRestClient(&models.Config{GithubToken: "token"})
+	require.NoError(t, err)
+
+	response, err := client.get(server.URL + "/repos/o/r/commits")
+	require.NoError(t, err)
+	require.False(t, response.notModified)
+	require.Equal(t, `[{"sha":"1"}]`, string(response.body))
+
+	response, err = client.get(server.URL + "/repos/o/r/commits")
+	require.NoError(t, err)
+	require.True(t, response.notModified)
+	require.Equal(t, http.StatusOK, response.statusCode)
+	require.Equal(t, `[{"sha":"1"}]`, string(response.body))
+
+	// other URLs are not revalidated with the cached validators
+	response, err = client.get(server.URL + "/repos/o/other/commits")
+	require.NoError(t, err)
+	require.False(t, response.notModified)
+	require.Equal(t, 3, requests)
+}
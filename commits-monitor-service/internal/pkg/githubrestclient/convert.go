@@ -0,0 +1,141 @@
+package githubrestclient
+
+import "commits-monitor-service/internal/constants/models"
+
+// The functions below map GitHub responses to the types of the provider
+// interface.
+
+// branchOf maps a listed branch.
+func branchOf(response models.BranchResponse) models.Branch {
+	return models.Branch{Name: response.Name, HeadSha: response.Commit.Sha}
+}
+
+// commitOf maps a listed or single commit.
+func commitOf(response models.CommitResponse) models.Commit {
+	commit := models.Commit{
+		Sha:     response.Sha,
+		URL:     response.URL,
+		HTMLURL: response.HTMLURL,
+		Message: response.Commit.Message,
+		Author: models.Signature{
+			Name:  response.Commit.Author.Name,
+			Email: response.Commit.Author.Email,
+			Date:  response.Commit.Author.Date,
+			Login: response.Author.Login,
+			Type:  response.Author.Type,
+		},
+		Committer: models.Signature{
+			Name:  response.Commit.Committer.Name,
+			Email: response.Commit.Committer.Email,
+			Date:  response.Commit.Committer.Date,
+			Login: response.Committer.Login,
+			Type:  response.Committer.Type,
+		},
+	}
+	for _, parent := range response.Parents {
+		commit.Parents = append(commit.Parents, parent.Sha)
+	}
+	if response.Stats != nil {
+		commit.Stats = &models.CommitStats{
+			Additions: response.Stats.Additions,
+			Deletions: response.Stats.Deletions,
+			Total:     response.Stats.Total,
+		}
+	}
+	for _, file := range response.Files {
+		commit.Files = append(commit.Files, models.CommitFile{
+			Sha:              file.Sha,
+			Filename:         file.Filename,
+			Status:           file.Status,
+			Additions:        file.Additions,
+			Deletions:        file.Deletions,
+			Changes:          file.Changes,
+			PreviousFilename: file.PreviousFilename,
+		})
+	}
+	return commit
+}
+
+func commitsOf(responses []models.CommitResponse) []models.Commit {
+	commits := make([]models.Commit, 0, len(responses))
+	for _, response := range responses {
+		commits = append(commits, commitOf(response))
+	}
+	return commits
+}
+
+func pullRequestOf(response models.PullRequestResponse) models.PullRequest {
+	return models.PullRequest{
+		Number:         response.Number,
+		Title:          response.Title,
+		State:          response.State,
+		Draft:          response.Draft,
+		Author:         response.User.Login,
+		HTMLURL:        response.HTMLURL,
+		CreatedAt:      response.CreatedAt,
+		UpdatedAt:      response.UpdatedAt,
+		ClosedAt:       response.ClosedAt,
+		MergedAt:       response.MergedAt,
+		MergeCommitSha: response.MergeCommitSha,
+		HeadRef:        response.Head.Ref,
+		HeadSha:        response.Head.Sha,
+		BaseRef:        response.Base.Ref,
+		BaseSha:        response.Base.Sha,
+	}
+}
+
+func issueOf(response models.IssueResponse) models.Issue {
+	issue := models.Issue{
+		Number:    response.Number,
+		Title:     response.Title,
+		State:     response.State,
+		Author:    response.User.Login,
+		Labels:    make([]string, 0, len(response.Labels)),
+		Assignees: make([]string, 0, len(response.Assignees)),
+		Comments:  response.Comments,
+		HTMLURL:   response.HTMLURL,
+		CreatedAt: response.CreatedAt,
+		UpdatedAt: response.UpdatedAt,
+		ClosedAt:  response.ClosedAt,
+	}
+	for _, label := range response.Labels {
+		issue.Labels = append(issue.Labels, label.Name)
+	}
+	for _, assignee := range response.Assignees {
+		issue.Assignees = append(issue.Assignees, assignee.Login)
+	}
+	return issue
+}
+
+func releaseOf(response models.ReleaseResponse) models.Release {
+	return models.Release{
+		ID:              response.ID,
+		TagName:         response.TagName,
+		Name:            response.Name,
+		TargetCommitish: response.TargetCommitish,
+		Draft:           response.Draft,
+		Prerelease:      response.Prerelease,
+		Author:          response.Author.Login,
+		HTMLURL:         response.HTMLURL,
+		CreatedAt:       response.CreatedAt,
+		PublishedAt:     response.PublishedAt,
+	}
+}
+
+func workflowRunOf(response models.WorkflowRunResponse) models.WorkflowRun {
+	return models.WorkflowRun{
+		ID:           response.ID,
+		Name:         response.Name,
+		WorkflowID:   response.WorkflowID,
+		HeadBranch:   response.HeadBranch,
+		HeadSha:      response.HeadSha,
+		Event:        response.Event,
+		Status:       response.Status,
+		Conclusion:   response.Conclusion,
+		RunAttempt:   response.RunAttempt,
+		HTMLURL:      response.HTMLURL,
+		CreatedAt:    response.CreatedAt,
+		UpdatedAt:    response.UpdatedAt,
+		RunStartedAt: response.RunStartedAt,
+	}
+}
@@ -0,0 +1,44 @@
+package githubrestclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchCommitDetailsFollowsFilePages(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/repos/chromium/chromium/commits/2222", r.URL.Path)
+		if r.URL.Query().Get("page") == "2" {
+			w.Write([]byte(`{"sha": "2222", "files": [{"filename": "b.go", "status": "added", "additions": 4}]}`))
+			return
+		}
+		w.Header().Set("Link", fmt.Sprintf(`<%s/repos/chromium/chromium/commits/2222?page=2>; rel="next"`, server.URL))
+		w.Write([]byte(`{
+		  "sha": "2222",
+		  "commit": {"message": "Fix the build"},
+		  "stats": {"total": 9, "additions": 6, "deletions": 3},
+		  "files": [{"filename": "a.go", "status": "modified", "additions": 2, "deletions": 3, "patch": "@@"}]
+		}`))
+	}))
+	defer server.Close()
+
+	client, err := NewGithubRestClient(&models.Config{GithubAPIURL: server.URL})
+	require.NoError(t, err)
+
+	commit, err := client.FetchCommitDetails("chromium/chromium", "2222")
+	require.NoError(t, err)
+	require.Equal(t, "Fix the build", commit.Message)
+	require.Equal(t, 9, commit.Stats.Total)
+	require.Equal(t, 6, commit.Stats.Additions)
+	require.Len(t, commit.Files, 2)
+	require.Equal(t, "a.go", commit.Files[0].Filename)
+	require.Equal(t, 3, commit.Files[0].Deletions)
+	require.Equal(t, "b.go", commit.Files[1].Filename)
+	require.Equal(t, "added", commit.Files[1].Status)
+}
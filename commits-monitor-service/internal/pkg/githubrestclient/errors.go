@@ -0,0 +1,93 @@
+package githubrestclient
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Kinds of failed GitHub requests, matched with errors.Is.
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrEmptyRepository = errors.New("repository is empty")
+	ErrRateLimited     = errors.New("rate limited")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrServer          = errors.New("server error")
+)
+
+// APIError is a GitHub response with an unexpected status.
+type APIError struct {
+	StatusCode int
+	Message    string
+	// RetryAt is when a rate limited request may be sent again, zero when
+	// GitHub did not say.
+	RetryAt time.Time
+
+	kind error
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("CMOS: github responded with status %d", e.StatusCode)
+	}
+	return fmt.Sprintf("CMOS: github responded with status %d: %s", e.StatusCode, e.Message)
+}
+
+func (e *APIError) Unwrap() error {
+	return e.kind
+}
+
+// newAPIError classifies a response that is neither 200 nor 304.
+func newAPIError(statusCode int, header http.Header, body []byte) *APIError {
+	var message struct {
+		Message string `json:"message"`
+	}
+	json.Unmarshal(body, &message)
+
+	apiErr := &APIError{StatusCode: statusCode, Message: message.Message}
+	switch {
+	case statusCode == http.StatusNotFound, statusCode == http.StatusGone:
+		apiErr.kind = ErrNotFound
+	case statusCode == http.StatusConflict && strings.Contains(strings.ToLower(message.Message), "empty"):
+		apiErr.kind = ErrEmptyRepository
+	case statusCode == http.StatusTooManyRequests, isRateLimited(statusCode, header, message.Message):
+		apiErr.kind = ErrRateLimited
+		apiErr.RetryAt = retryAt(header, time.Now())
+	case statusCode == http.StatusUnauthorized, statusCode == http.StatusForbidden:
+		apiErr.kind = ErrUnauthorized
+	case statusCode >= http.StatusInternalServerError:
+		apiErr.kind = ErrServer
+	}
+	return apiErr
+}
+
+// NewAPIError classifies a failed response of another provider like a GitHub
+// one, so that its failures are matched with the same Err kinds.
+func NewAPIError(statusCode int, header http.Header, body []byte) *APIError {
+	return newAPIError(statusCode, header, body)
+}
+
+func isRateLimited(statusCode int, header http.Header, message string) bool {
+	if statusCode != http.StatusForbidden {
+		return false
+	}
+	return header.Get("X-RateLimit-Remaining") == "0" ||
+		header.Get("Retry-After") != "" ||
+		strings.Contains(strings.ToLower(message), "rate limit")
+}
+
+// retryAt reads when a rate limited request may be retried from the
+// Retry-After or X-RateLimit-Reset header.
+func retryAt(header http.Header, now time.Time) time.Time {
+	if d, ok := parseRetryAfter(header.Get("Retry-After"), now); ok {
+		return now.Add(d)
+	}
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		return time.Unix(reset, 0)
+	}
+	return time.Time{}
+}
@@ -0,0 +1,68 @@
+package githubrestclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAPIError(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+
+	tests := []struct {
+		statusCode int
+		header     http.Header
+		body       string
+		kind       error
+	}{
+		{http.StatusNotFound, http.Header{}, `{"message": "Not Found"}`, ErrNotFound},
+		{http.StatusConflict, http.Header{}, `{"message": "Git Repository is empty."}`, ErrEmptyRepository},
+		{http.StatusForbidden, rateLimitHeader(5000, 0, reset), `{"message": "API rate limit exceeded"}`, ErrRateLimited},
+		{http.StatusTooManyRequests, http.Header{}, ``, ErrRateLimited},
+		{http.StatusUnauthorized, http.Header{}, `{"message": "Bad credentials"}`, ErrUnauthorized},
+		{http.StatusForbidden, http.Header{}, `{"message": "Resource not accessible"}`, ErrUnauthorized},
+		{http.StatusBadGateway, http.Header{}, `<html>`, ErrServer},
+	}
+	for _, test := range tests {
+		err := newAPIError(test.statusCode, test.header, []byte(test.body))
+		require.ErrorIs(t, err, test.kind, test.body)
+	}
+
+	err := newAPIError(http.StatusForbidden, rateLimitHeader(5000, 0, reset), nil)
+	require.True(t, err.RetryAt.Equal(reset))
+
+	err = newAPIError(http.StatusUnprocessableEntity, http.Header{}, []byte(`{"message": "Validation Failed"}`))
+	require.Nil(t, errors.Unwrap(err))
+	require.Equal(t, "CMOS: github responded with status 422: Validation Failed", err.Error())
+}
+
+func TestFetchCommitsStatusErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/chromium/empty/commits":
+			w.WriteHeader(http.StatusConflict)
+			w.Write([]byte(`{"message": "Git Repository is empty."}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message": "Not Found"}`))
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewGithubRestClient(&models.Config{GithubAPIURL: server.URL})
+	require.NoError(t, err)
+
+	_, err = client.FetchCommits("chromium/empty", "", "", "", 100, "")
+	require.ErrorIs(t, err, ErrEmptyRepository)
+
+	_, err = client.FetchCommits("chromium/gone", "", "", "", 100, "")
+	require.ErrorIs(t, err, ErrNotFound)
+	var apiErr *APIError
+	require.ErrorAs(t, err, &apiErr)
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+}
@@ -8,22 +8,53 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"strconv"
+	"strings"
 )
 
 type GithubRestClient struct {
-	Config *models.Config
+	Config     *models.Config
+	baseURL    string
+	httpClient *http.Client
+	pool       *tokenPool
+	cache      *responseCache
 }
 
-func NewGithubRestClient(Config *models.Config) GithubRestClient {
-	return GithubRestClient{Config: Config}
+func NewGithubRestClient(Config *models.Config) (GithubRestClient, error) {
+	httpClient, err := newHTTPClient(Config)
+	if err != nil {
+		return GithubRestClient{}, err
+	}
+
+	baseURL := apiURL(Config)
+	pool, err := newTokenPool(Config, baseURL, httpClient)
+	if err != nil {
+		return GithubRestClient{}, err
+	}
+
+	return GithubRestClient{
+		Config:     Config,
+		baseURL:    baseURL,
+		httpClient: httpClient,
+		pool:       pool,
+		cache:      newResponseCache(),
+	}, nil
+}
+
+// RateLimit returns the request budget left on the configured tokens.
+func (gp GithubRestClient) RateLimit() RateLimit {
+	return gp.pool.rateLimit()
 }
 
-const baseURL = "https://api.github.com"
+// TokenUsage returns the budget and usage of every configured token.
+func (gp GithubRestClient) TokenUsage() []TokenUsage {
+	return gp.pool.usage()
+}
 
 // buildURI constructs the URL with query parameters.
 func buildURI(base string, path string, queryParams map[string]string) string {
 	u, _ := url.Parse(base)
-	u.Path = path
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
 	q := u.Query()
 	for key, value := range queryParams {
 		q.Set(key, value)
@@ -32,44 +63,210 @@ func buildURI(base string, path string, queryParams map[string]string) string {
 	return u.String()
 }
 
-func (gp GithubRestClient) FetchCommits(repositoryName string, perPage, page int32) ([]models.CommitResponse, error) {
-	path := fmt.Sprintf("/repos/%s/%s/commits", gp.Config.GithubUsername, repositoryName)
+// FetchCommits fetches a page of the commits of a branch of a repository,
+// given by its full name (owner/name), newest first, committed between since
+// and until, either of them may be empty. An empty branch stands for the
+// default branch. The cursor is the page number returned as Next by the previous
+// call, empty for the first page. Failed requests return an *APIError
+// matching one of the Err kinds.
+func (gp GithubRestClient) FetchCommits(repositoryName string, branch string, since string, until string, perPage int32, cursor string) (models.CommitsPage, error) {
+	path := fmt.Sprintf("/repos/%s/commits", repositoryName)
 	queryParams := map[string]string{}
+	if branch != "" {
+		queryParams["sha"] = branch
+	}
 
+	page := cursor
+	if page == "" {
+		page = "1"
+	}
 	queryParams["per_page"] = fmt.Sprintf("%d", perPage)
-	queryParams["page"] = fmt.Sprintf("%d", page)
-	queryParams["since"] = gp.Config.StartDate
-	queryParams["until"] = gp.Config.EndDate
+	queryParams["page"] = page
+	queryParams["since"] = since
+	if until != "" {
+		queryParams["until"] = until
+	}
+
+	fetchRepoUrl := buildURI(gp.baseURL, path, queryParams)
+
+	response, err := gp.get(fetchRepoUrl)
+	if err != nil {
+		return models.CommitsPage{}, err
+	}
+	if response.statusCode != http.StatusOK {
+		return models.CommitsPage{}, newAPIError(response.statusCode, response.header, response.body)
+	}
+
+	var commits []models.CommitResponse
+	err = json.Unmarshal(response.body, &commits)
+	if err != nil {
+		log.Println("CMOS: Error unmarshalling response body:", err)
+		return models.CommitsPage{}, err
+	}
 
-	fetchRepoUrl := buildURI(baseURL, path, queryParams)
+	var next string
+	if pages := paginationOf(response.link); pages.next != 0 {
+		next = strconv.Itoa(pages.next)
+	}
+	return models.CommitsPage{
+		Commits:     commitsOf(commits),
+		Next:        next,
+		NotModified: response.notModified,
+	}, nil
+}
 
-	request, err := http.NewRequest(http.MethodGet, fetchRepoUrl, nil)
+// FetchDefaultBranch returns the name of the default branch of a repository.
+func (gp GithubRestClient) FetchDefaultBranch(repositoryName string) (string, error) {
+	response, err := gp.get(buildURI(gp.baseURL, fmt.Sprintf("/repos/%s", repositoryName), nil))
 	if err != nil {
-		return nil, err
+		return "", err
+	}
+	if response.statusCode != http.StatusOK {
+		return "", newAPIError(response.statusCode, response.header, response.body)
+	}
+
+	var repository models.RepositoryReponse
+	if err := json.Unmarshal(response.body, &repository); err != nil {
+		log.Println("CMOS: Error unmarshalling response body:", err)
+		return "", err
+	}
+	return repository.DefaultBranch, nil
+}
+
+// FetchBranches lists all branches of a repository.
+func (gp GithubRestClient) FetchBranches(repositoryName string) ([]models.Branch, error) {
+	path := fmt.Sprintf("/repos/%s/branches", repositoryName)
+
+	var branches []models.Branch
+	for page := 1; page != 0; {
+		queryParams := map[string]string{
+			"per_page": "100",
+			"page":     strconv.Itoa(page),
+		}
+
+		response, err := gp.get(buildURI(gp.baseURL, path, queryParams))
+		if err != nil {
+			return nil, err
+		}
+		if response.statusCode != http.StatusOK {
+			return nil, newAPIError(response.statusCode, response.header, response.body)
+		}
+
+		var branchesPage []models.BranchResponse
+		if err := json.Unmarshal(response.body, &branchesPage); err != nil {
+			log.Println("CMOS: Error unmarshalling response body:", err)
+			return nil, err
+		}
+		for _, branch := range branchesPage {
+			branches = append(branches, branchOf(branch))
+		}
+
+		page = paginationOf(response.link).next
+	}
+	return branches, nil
+}
+
+// FetchCommitDetails fetches a single commit of a repository, given by its
+// full name, with its stats and changed files. The files of large commits
+// are spread over several pages, which are all fetched.
+func (gp GithubRestClient) FetchCommitDetails(repositoryName string, sha string) (models.Commit, error) {
+	path := fmt.Sprintf("/repos/%s/commits/%s", repositoryName, sha)
+
+	var commit models.CommitResponse
+	for page := 1; page != 0; {
+		queryParams := map[string]string{}
+		if page > 1 {
+			queryParams["page"] = strconv.Itoa(page)
+		}
+
+		response, err := gp.get(buildURI(gp.baseURL, path, queryParams))
+		if err != nil {
+			return models.Commit{}, err
+		}
+		if response.statusCode != http.StatusOK {
+			return models.Commit{}, newAPIError(response.statusCode, response.header, response.body)
+		}
+
+		var commitPage models.CommitResponse
+		if err := json.Unmarshal(response.body, &commitPage); err != nil {
+			log.Println("CMOS: Error unmarshalling response body:", err)
+			return models.Commit{}, err
+		}
+		if page == 1 {
+			commit = commitPage
+		} else {
+			commit.Files = append(commit.Files, commitPage.Files...)
+		}
+
+		page = paginationOf(response.link).next
+	}
+	return commitOf(commit), nil
+}
+
+// apiResponse is a GitHub response with its body already read.
+type apiResponse struct {
+	statusCode  int
+	header      http.Header
+	link        string
+	body        []byte
+	notModified bool
+}
+
+// get sends a conditional GET request. When GitHub answers 304 the body of
+// the cached response is returned and notModified is set.
+func (gp GithubRestClient) get(requestUrl string) (apiResponse, error) {
+	request, err := http.NewRequest(http.MethodGet, requestUrl, nil)
+	if err != nil {
+		return apiResponse{}, err
 	}
 
 	request.Header.Add("Accept", "application/vnd.github+json")
-	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", gp.Config.GithubToken))
 	request.Header.Add("X-GitHub-Api-Version", "2022-11-28")
 
-	response, err := http.DefaultClient.Do(request)
+	cached, isCached := gp.cache.get(requestUrl)
+	if isCached {
+		if cached.etag != "" {
+			request.Header.Add("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			request.Header.Add("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	response, err := gp.pool.do(request)
 	if err != nil {
-		return nil, err
+		return apiResponse{}, err
 	}
 	defer response.Body.Close()
 
+	if response.StatusCode == http.StatusNotModified && isCached {
+		return apiResponse{
+			statusCode:  http.StatusOK,
+			link:        cached.link,
+			body:        cached.body,
+			notModified: true,
+		}, nil
+	}
+
 	bodyBytes, err := io.ReadAll(response.Body)
 	if err != nil {
 		log.Println("CMOS: Error reading response body:", err)
-		return nil, err
+		return apiResponse{}, err
 	}
 
-	var commits []models.CommitResponse
-	err = json.Unmarshal(bodyBytes, &commits)
-	if err != nil {
-		log.Println("CMOS: Error unmarshalling response body:", err)
-		return nil, err
+	if response.StatusCode == http.StatusOK {
+		gp.cache.set(requestUrl, cachedResponse{
+			etag:         response.Header.Get("ETag"),
+			lastModified: response.Header.Get("Last-Modified"),
+			link:         response.Header.Get("Link"),
+			body:         bodyBytes,
+		})
 	}
 
-	return commits, nil
+	return apiResponse{
+		statusCode: response.StatusCode,
+		header:     response.Header,
+		link:       response.Header.Get("Link"),
+		body:       bodyBytes,
+	}, nil
 }
@@ -0,0 +1,511 @@
+package githubrestclient
+
+import (
+	"bytes"
+	"commits-monitor-service/internal/constants/models"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxBatchSize is the number of repositories queried together. GitHub
+	// limits a query to 500,000 nodes, far above 100 commits per repository.
+	maxBatchSize = 20
+
+	// batchWindow is how long a batch waits for more repositories before
+	// the query is sent.
+	batchWindow = 50 * time.Millisecond
+
+	// maxHistoryPageSize is the largest page GitHub returns for a history.
+	maxHistoryPageSize = 100
+)
+
+// GithubGraphQLClient fetches commits through the GitHub GraphQL API. Calls
+// made concurrently for different repositories are coalesced into a single
+// query with one aliased history per repository.
+type GithubGraphQLClient struct {
+	Config     *models.Config
+	baseURL    string
+	endpoint   string
+	httpClient *http.Client
+	pool       *tokenPool
+
+	// rest fetches the details of single commits, which GraphQL does not
+	// list the changed files of.
+	rest GithubRestClient
+
+	mu      sync.Mutex
+	pending []*historyRequest
+	timer   *time.Timer
+}
+
+func NewGithubGraphQLClient(Config *models.Config) (*GithubGraphQLClient, error) {
+	httpClient, err := newHTTPClient(Config)
+	if err != nil {
+		return nil, err
+	}
+
+	// GraphQL has its own budget, so the tokens are tracked separately from
+	// the REST client.
+	baseURL := apiURL(Config)
+	pool, err := newTokenPool(Config, baseURL, httpClient)
+	if err != nil {
+		return nil, err
+	}
+
+	rest, err := NewGithubRestClient(Config)
+	if err != nil {
+		return nil, err
+	}
+
+	return &GithubGraphQLClient{
+		rest:       rest,
+		Config:     Config,
+		baseURL:    baseURL,
+		endpoint:   graphQLEndpoint(Config),
+		httpClient: httpClient,
+		pool:       pool,
+	}, nil
+}
+
+// RateLimit returns the GraphQL budget left on the configured tokens.
+func (gq *GithubGraphQLClient) RateLimit() RateLimit {
+	return gq.pool.rateLimit()
+}
+
+// FetchDefaultBranch returns the name of the default branch of a repository
+// through the REST API.
+func (gq *GithubGraphQLClient) FetchDefaultBranch(repositoryName string) (string, error) {
+	return gq.rest.FetchDefaultBranch(repositoryName)
+}
+
+// FetchBranches lists all branches of a repository through the REST API.
+func (gq *GithubGraphQLClient) FetchBranches(repositoryName string) ([]models.Branch, error) {
+	return gq.rest.FetchBranches(repositoryName)
+}
+
+// FetchCommitDetails fetches a single commit with its stats and changed
+// files through the REST API.
+func (gq *GithubGraphQLClient) FetchCommitDetails(repositoryName string, sha string) (models.Commit, error) {
+	return gq.rest.FetchCommitDetails(repositoryName, sha)
+}
+
+// FetchPullRequests fetches a page of the pull requests of a repository
+// through the REST API.
+func (gq *GithubGraphQLClient) FetchPullRequests(repositoryName string, since string, cursor string) (models.PullRequestsPage, error) {
+	return gq.rest.FetchPullRequests(repositoryName, since, cursor)
+}
+
+// FetchPullRequestCommits returns the SHAs of the commits of a pull request
+// through the REST API.
+func (gq *GithubGraphQLClient) FetchPullRequestCommits(repositoryName string, number int) ([]string, error) {
+	return gq.rest.FetchPullRequestCommits(repositoryName, number)
+}
+
+// FetchIssues fetches a page of the issues of a repository through the REST
+// API.
+func (gq *GithubGraphQLClient) FetchIssues(repositoryName string, since string, cursor string) (models.IssuesPage, error) {
+	return gq.rest.FetchIssues(repositoryName, since, cursor)
+}
+
+// FetchReleases fetches a page of the releases of a repository through the
+// REST API.
+func (gq *GithubGraphQLClient) FetchReleases(repositoryName string, cursor string) (models.ReleasesPage, error) {
+	return gq.rest.FetchReleases(repositoryName, cursor)
+}
+
+// FetchTags fetches a page of the tags of a repository through the REST API.
+func (gq *GithubGraphQLClient) FetchTags(repositoryName string, cursor string) (models.TagsPage, error) {
+	return gq.rest.FetchTags(repositoryName, cursor)
+}
+
+// FetchWorkflowRuns fetches a page of the workflow runs of a repository
+// through the REST API.
+func (gq *GithubGraphQLClient) FetchWorkflowRuns(repositoryName string, since string, cursor string) (models.WorkflowRunsPage, error) {
+	return gq.rest.FetchWorkflowRuns(repositoryName, since, cursor)
+}
+
+// TokenUsage returns the GraphQL budget and usage of every configured token.
+func (gq *GithubGraphQLClient) TokenUsage() []TokenUsage {
+	return gq.pool.usage()
+}
+
+// historyRequest is a page of a repository history waiting to be queried.
+type historyRequest struct {
+	repositoryName string
+	branch         string
+	since          string
+	until          string
+	perPage        int32
+	cursor         string
+	result         chan historyResult
+}
+
+type historyResult struct {
+	page models.CommitsPage
+	err  error
+}
+
+// FetchCommits fetches a page of the history of a branch of a repository,
+// given by its full name (owner/name), newest first, committed between since
+// and until, either of them may be empty. An empty branch stands for the
+// default branch. The cursor is the one returned as Next by the previous
+// call, empty for the first page.
+func (gq *GithubGraphQLClient) FetchCommits(repositoryName string, branch string, since string, until string, perPage int32, cursor string) (models.CommitsPage, error) {
+	request := &historyRequest{
+		repositoryName: repositoryName,
+		branch:         branch,
+		since:          since,
+		until:          until,
+		perPage:        min(perPage, maxHistoryPageSize),
+		cursor:         cursor,
+		result:         make(chan historyResult, 1),
+	}
+
+	gq.mu.Lock()
+	gq.pending = append(gq.pending, request)
+	switch {
+	case len(gq.pending) >= maxBatchSize:
+		batch := gq.takePending()
+		go gq.query(batch)
+	case len(gq.pending) == 1:
+		gq.timer = time.AfterFunc(batchWindow, gq.flush)
+	}
+	gq.mu.Unlock()
+
+	result := <-request.result
+	return result.page, result.err
+}
+
+// takePending removes the pending requests. gq.mu must be held.
+func (gq *GithubGraphQLClient) takePending() []*historyRequest {
+	batch := gq.pending
+	gq.pending = nil
+	if gq.timer != nil {
+		gq.timer.Stop()
+		gq.timer = nil
+	}
+	return batch
+}
+
+func (gq *GithubGraphQLClient) flush() {
+	gq.mu.Lock()
+	batch := gq.takePending()
+	gq.mu.Unlock()
+
+	if len(batch) > 0 {
+		gq.query(batch)
+	}
+}
+
+// query sends one query for the batch and hands every request its page.
+func (gq *GithubGraphQLClient) query(batch []*historyRequest) {
+	data, errs, err := gq.post(historyQuery(batch))
+	if err != nil {
+		for _, request := range batch {
+			request.result <- historyResult{err: err}
+		}
+		return
+	}
+
+	for i, request := range batch {
+		alias := historyAlias(i)
+		if err, ok := errs[alias]; ok {
+			request.result <- historyResult{err: err}
+			continue
+		}
+
+		var repository *graphQLRepository
+		if raw, ok := data[alias]; ok {
+			if err := json.Unmarshal(raw, &repository); err != nil {
+				request.result <- historyResult{err: err}
+				continue
+			}
+		}
+		switch {
+		case repository == nil:
+			request.result <- historyResult{err: fmt.Errorf("CMOS: graphql: %s: %w", request.repositoryName, ErrNotFound)}
+			continue
+		case repository.Branch == nil && request.branch != "":
+			request.result <- historyResult{err: fmt.Errorf("CMOS: graphql: %s: branch %s: %w", request.repositoryName, request.branch, ErrNotFound)}
+			continue
+		case repository.Branch == nil:
+			request.result <- historyResult{err: fmt.Errorf("CMOS: graphql: %s: %w", request.repositoryName, ErrEmptyRepository)}
+			continue
+		}
+		request.result <- historyResult{page: repository.commitsPage(gq.baseURL, request.repositoryName)}
+	}
+}
+
+// graphQLQuery is the body of a GraphQL request.
+type graphQLQuery struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+func historyAlias(i int) string {
+	return fmt.Sprintf("r%d", i)
+}
+
+// historyQuery builds a query with one aliased repository history per
+// request. Values are passed as variables so names need no escaping.
+func historyQuery(batch []*historyRequest) graphQLQuery {
+	variables := map[string]any{}
+	var declarations []string
+
+	var fields strings.Builder
+	for i, request := range batch {
+		alias := historyAlias(i)
+		owner, name, _ := strings.Cut(request.repositoryName, "/")
+		variables[alias+"owner"] = owner
+		variables[alias+"name"] = name
+		variables[alias+"first"] = request.perPage
+		if request.since != "" {
+			variables[alias+"since"] = request.since
+		}
+		if request.until != "" {
+			variables[alias+"until"] = request.until
+		}
+		if request.cursor != "" {
+			variables[alias+"after"] = request.cursor
+		}
+		declarations = append(declarations,
+			fmt.Sprintf("$%sowner: String!", alias),
+			fmt.Sprintf("$%sname: String!", alias),
+			fmt.Sprintf("$%sfirst: Int!", alias),
+			fmt.Sprintf("$%ssince: GitTimestamp", alias),
+			fmt.Sprintf("$%suntil: GitTimestamp", alias),
+			fmt.Sprintf("$%safter: String", alias),
+		)
+		ref := "defaultBranchRef"
+		if request.branch != "" {
+			variables[alias+"ref"] = "refs/heads/" + request.branch
+			declarations = append(declarations, fmt.Sprintf("$%sref: String!", alias))
+			ref = fmt.Sprintf("ref(qualifiedName: $%sref)", alias)
+		}
+		fmt.Fprintf(&fields, `
+  %[1]s: repository(owner: $%[1]sowner, name: $%[1]sname) {
+    branch: %[2]s {
+      target {
+        ... on Commit {
+          history(first: $%[1]sfirst, since: $%[1]ssince, until: $%[1]suntil, after: $%[1]safter) {
+            ...commitsPage
+          }
+        }
+      }
+    }
+  }`, alias, ref)
+	}
+
+	query := fmt.Sprintf("query(%s) {%s\n}\n%s", strings.Join(declarations, ", "), fields.String(), commitsPageFragment)
+	return graphQLQuery{Query: query, Variables: variables}
+}
+
+const commitsPageFragment = `fragment commitsPage on CommitHistoryConnection {
+  pageInfo {
+    hasNextPage
+    endCursor
+  }
+  nodes {
+    oid
+    url
+    message
+    author {
+      name
+      email
+      date
+      user {
+        login
+      }
+    }
+    committer {
+      name
+      email
+      date
+      user {
+        login
+      }
+    }
+    parents(first: 10) {
+      nodes {
+        oid
+      }
+    }
+  }
+}`
+
+// graphQLError is an entry of the errors list of a GraphQL response.
+type graphQLError struct {
+	Type    string `json:"type"`
+	Message string `json:"message"`
+	Path    []any  `json:"path"`
+}
+
+func (e graphQLError) Error() string {
+	if e.Type == "" {
+		return fmt.Sprintf("CMOS: graphql: %s", e.Message)
+	}
+	return fmt.Sprintf("CMOS: graphql %s: %s", e.Type, e.Message)
+}
+
+func (e graphQLError) Unwrap() error {
+	switch e.Type {
+	case "NOT_FOUND":
+		return ErrNotFound
+	case "RATE_LIMITED":
+		return ErrRateLimited
+	case "FORBIDDEN":
+		return ErrUnauthorized
+	}
+	return nil
+}
+
+// post sends the query and returns the data of each alias. Errors reported
+// for an alias are returned per alias; any other error fails the query.
+func (gq *GithubGraphQLClient) post(query graphQLQuery) (map[string]json.RawMessage, map[string]error, error) {
+	body, err := json.Marshal(query)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	request, err := http.NewRequest(http.MethodPost, gq.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	request.Header.Add("Content-Type", "application/json")
+
+	response, err := gq.pool.do(request)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer response.Body.Close()
+
+	bodyBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		log.Println("CMOS: Error reading response body:", err)
+		return nil, nil, err
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, nil, newAPIError(response.StatusCode, response.Header, bodyBytes)
+	}
+
+	var result struct {
+		Data   map[string]json.RawMessage `json:"data"`
+		Errors []graphQLError             `json:"errors"`
+	}
+	if err := json.Unmarshal(bodyBytes, &result); err != nil {
+		log.Println("CMOS: Error unmarshalling response body:", err)
+		return nil, nil, err
+	}
+
+	errs := make(map[string]error)
+	for _, e := range result.Errors {
+		alias, ok := aliasOf(e)
+		if !ok {
+			return nil, nil, e
+		}
+		if _, seen := errs[alias]; !seen {
+			errs[alias] = e
+		}
+	}
+	if result.Data == nil && len(errs) == 0 {
+		return nil, nil, errors.New("CMOS: graphql response has no data")
+	}
+	return result.Data, errs, nil
+}
+
+// aliasOf returns the alias an error was reported for.
+func aliasOf(e graphQLError) (string, bool) {
+	if len(e.Path) == 0 {
+		return "", false
+	}
+	alias, ok := e.Path[0].(string)
+	return alias, ok
+}
+
+// graphQLRepository is a repository with the history of the queried branch.
+type graphQLRepository struct {
+	Branch *struct {
+		Target struct {
+			History *struct {
+				PageInfo struct {
+					HasNextPage bool   `json:"hasNextPage"`
+					EndCursor   string `json:"endCursor"`
+				} `json:"pageInfo"`
+				Nodes []graphQLCommit `json:"nodes"`
+			} `json:"history"`
+		} `json:"target"`
+	} `json:"branch"`
+}
+
+type graphQLActor struct {
+	Name  string    `json:"name"`
+	Email string    `json:"email"`
+	Date  time.Time `json:"date"`
+	User  *struct {
+		Login string `json:"login"`
+	} `json:"user"`
+}
+
+type graphQLCommit struct {
+	Oid       string       `json:"oid"`
+	URL       string       `json:"url"`
+	Message   string       `json:"message"`
+	Author    graphQLActor `json:"author"`
+	Committer graphQLActor `json:"committer"`
+	Parents   struct {
+		Nodes []struct {
+			Oid string `json:"oid"`
+		} `json:"nodes"`
+	} `json:"parents"`
+}
+
+// commitsPage maps the history to the commits of the provider interface.
+func (r graphQLRepository) commitsPage(baseURL, repositoryName string) models.CommitsPage {
+	if r.Branch.Target.History == nil {
+		return models.CommitsPage{}
+	}
+	history := r.Branch.Target.History
+
+	commits := make([]models.Commit, 0, len(history.Nodes))
+	for _, node := range history.Nodes {
+		commits = append(commits, node.commit(baseURL, repositoryName))
+	}
+
+	var next string
+	if history.PageInfo.HasNextPage {
+		next = history.PageInfo.EndCursor
+	}
+	return models.CommitsPage{Commits: commits, Next: next}
+}
+
+func (c graphQLCommit) commit(baseURL, repositoryName string) models.Commit {
+	commit := models.Commit{
+		Sha:       c.Oid,
+		URL:       fmt.Sprintf("%s/repos/%s/commits/%s", baseURL, repositoryName, c.Oid),
+		HTMLURL:   c.URL,
+		Message:   c.Message,
+		Author:    c.Author.signature(),
+		Committer: c.Committer.signature(),
+	}
+	for _, parent := range c.Parents.Nodes {
+		commit.Parents = append(commit.Parents, parent.Oid)
+	}
+	return commit
+}
+
+func (a graphQLActor) signature() models.Signature {
+	signature := models.Signature{Name: a.Name, Email: a.Email, Date: a.Date.UTC()}
+	if a.User != nil {
+		signature.Login = a.User.Login
+		signature.Type = "User"
+	}
+	return signature
+}
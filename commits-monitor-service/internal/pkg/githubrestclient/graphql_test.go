@@ -0,0 +1,133 @@
+package githubrestclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const historyResponse = `{
+  "data": {
+    "r0": {
+      "branch": {
+        "target": {
+          "history": {
+            "pageInfo": {"hasNextPage": true, "endCursor": "cursor-1"},
+            "nodes": [{
+              "oid": "2222",
+              "id": "C_2",
+              "url": "https://github.com/chromium/%[1]s/commit/2222",
+              "message": "Fix the build",
+              "commentCount": {"totalCount": 3},
+              "tree": {"oid": "tree"},
+              "author": {
+                "name": "Jane",
+                "email": "jane@example.com",
+                "date": "2024-08-02T10:00:00+02:00",
+                "user": {"login": "jane", "databaseId": 7, "id": "U_7", "avatarUrl": "", "url": "https://github.com/jane"}
+              },
+              "committer": {"name": "GitHub", "email": "noreply@github.com", "date": "2024-08-02T08:01:00Z", "user": null},
+              "parents": {"nodes": [{"oid": "1111", "url": "https://github.com/chromium/%[1]s/commit/1111"}]}
+            }]
+          }
+        }
+      }
+    },
+    "r1": null
+  },
+  "errors": [{"type": "NOT_FOUND", "path": ["r1"], "message": "Could not resolve to a Repository with the name 'google/gone'."}]
+}`
+
+func TestGraphQLBatchesConcurrentFetches(t *testing.T) {
+	var requests int
+	var variables map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		require.Equal(t, http.MethodPost, r.Method)
+		require.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+
+		var query graphQLQuery
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&query))
+		require.Contains(t, query.Query, "r0: repository(owner: $r0owner, name: $r0name)")
+		require.Contains(t, query.Query, "r1: repository(owner: $r1owner, name: $r1name)")
+		variables = query.Variables
+
+		w.Write([]byte(fmt.Sprintf(historyResponse, query.Variables["r0name"])))
+	}))
+	defer server.Close()
+
+	client, err := NewGithubGraphQLClient(&models.Config{
+		GithubToken:      "token",
+		GithubGraphQLURL: server.URL,
+	})
+	require.NoError(t, err)
+
+	var wg sync.WaitGroup
+	var found models.CommitsPage
+	var foundErr, goneErr error
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		found, foundErr = client.FetchCommits("chromium/chromium", "", "2024-08-01T00:00:00Z", "2098-10-03T10:01:20Z", 100, "")
+	}()
+	// the second call joins the batch of the first one
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		for {
+			client.mu.Lock()
+			joined := len(client.pending) == 1
+			client.mu.Unlock()
+			if joined {
+				break
+			}
+		}
+		_, goneErr = client.FetchCommits("google/gone", "", "", "", 100, "")
+	}()
+	wg.Wait()
+
+	require.Equal(t, 1, requests)
+	require.Equal(t, "chromium", variables["r0owner"])
+	require.Equal(t, "google", variables["r1owner"])
+	require.Equal(t, "gone", variables["r1name"])
+	require.Equal(t, "2098-10-03T10:01:20Z", variables["r0until"])
+	require.NotContains(t, variables, "r1until")
+	require.Equal(t, "2024-08-01T00:00:00Z", variables["r0since"])
+	require.NotContains(t, variables, "r1since")
+
+	require.NoError(t, foundErr)
+	require.Equal(t, "cursor-1", found.Next)
+	require.Len(t, found.Commits, 1)
+	commit := found.Commits[0]
+	require.Equal(t, "2222", commit.Sha)
+	require.Equal(t, "https://api.github.com/repos/chromium/chromium/commits/2222", commit.URL)
+	require.Equal(t, "Fix the build", commit.Message)
+	require.Equal(t, "Jane", commit.Author.Name)
+	require.Equal(t, "2024-08-02T08:00:00Z", commit.Author.Date.Format("2006-01-02T15:04:05Z"))
+	require.Equal(t, "jane", commit.Author.Login)
+	require.Empty(t, commit.Committer.Login)
+	require.Len(t, commit.Parents, 1)
+	require.Equal(t, "1111", commit.Parents[0])
+
+	require.ErrorIs(t, goneErr, ErrNotFound)
+	require.Contains(t, goneErr.Error(), "NOT_FOUND")
+}
+
+func TestGraphQLEmptyRepository(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"data": {"r0": {"branch": null}}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewGithubGraphQLClient(&models.Config{GithubGraphQLURL: server.URL})
+	require.NoError(t, err)
+
+	_, err = client.FetchCommits("chromium/empty", "", "", "", 100, "")
+	require.ErrorIs(t, err, ErrEmptyRepository)
+}
@@ -0,0 +1,58 @@
+package githubrestclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// FetchIssues fetches a page of the issues of a repository in any state
+// updated at or after since, least recently updated first. Pull requests,
+// which GitHub lists as issues too, are left out. The cursor is the page
+// number returned as Next by the previous call, empty for the first page.
+func (gp GithubRestClient) FetchIssues(repositoryName string, since string, cursor string) (models.IssuesPage, error) {
+	page := cursor
+	if page == "" {
+		page = "1"
+	}
+	queryParams := map[string]string{
+		"state":     "all",
+		"sort":      "updated",
+		"direction": "asc",
+		"per_page":  "100",
+		"page":      page,
+	}
+	if since != "" {
+		queryParams["since"] = since
+	}
+
+	response, err := gp.get(buildURI(gp.baseURL, fmt.Sprintf("/repos/%s/issues", repositoryName), queryParams))
+	if err != nil {
+		return models.IssuesPage{}, err
+	}
+	if response.statusCode != http.StatusOK {
+		return models.IssuesPage{}, newAPIError(response.statusCode, response.header, response.body)
+	}
+
+	var listed []models.IssueResponse
+	if err := json.Unmarshal(response.body, &listed); err != nil {
+		log.Println("CMOS: Error unmarshalling response body:", err)
+		return models.IssuesPage{}, err
+	}
+
+	issues := make([]models.Issue, 0, len(listed))
+	for _, issue := range listed {
+		if issue.PullRequest == nil {
+			issues = append(issues, issueOf(issue))
+		}
+	}
+
+	var next string
+	if pages := paginationOf(response.link); pages.next != 0 {
+		next = strconv.Itoa(pages.next)
+	}
+	return models.IssuesPage{Issues: issues, Next: next}, nil
+}
@@ -0,0 +1,54 @@
+package githubrestclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchIssuesSkipsPullRequests(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/repos/chromium/chromium/issues", r.URL.Path)
+		require.Equal(t, "2024-08-01T00:00:00Z", r.URL.Query().Get("since"))
+		require.Equal(t, "all", r.URL.Query().Get("state"))
+		w.Header().Set("Link", fmt.Sprintf(`<%s/repos/chromium/chromium/issues?page=2>; rel="next"`, server.URL))
+		w.Write([]byte(`[
+		  {
+		    "number": 1,
+		    "title": "Crash on start",
+		    "state": "open",
+		    "user": {"login": "octocat"},
+		    "labels": [{"name": "bug"}, {"name": "p1"}],
+		    "assignees": [{"login": "hubot"}],
+		    "created_at": "2024-08-01T10:00:00Z",
+		    "updated_at": "2024-08-02T10:00:00Z"
+		  },
+		  {
+		    "number": 2,
+		    "title": "Fix the crash",
+		    "state": "open",
+		    "pull_request": {"url": "https://api.github.com/repos/chromium/chromium/pulls/2"}
+		  }
+		]`))
+	}))
+	defer server.Close()
+
+	client, err := NewGithubRestClient(&models.Config{GithubAPIURL: server.URL})
+	require.NoError(t, err)
+
+	page, err := client.FetchIssues("chromium/chromium", "2024-08-01T00:00:00Z", "")
+	require.NoError(t, err)
+	require.Equal(t, "2", page.Next)
+	require.Len(t, page.Issues, 1)
+	issue := page.Issues[0]
+	require.Equal(t, 1, issue.Number)
+	require.Equal(t, "octocat", issue.Author)
+	require.Len(t, issue.Labels, 2)
+	require.Equal(t, "hubot", issue.Assignees[0])
+	require.Nil(t, issue.ClosedAt)
+}
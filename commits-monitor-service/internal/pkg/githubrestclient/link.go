@@ -0,0 +1,63 @@
+package githubrestclient
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// parseLinkHeader parses a RFC 8288 Link header into its URLs keyed by rel,
+// e.g. `<https://api.github.com/...&page=2>; rel="next"`.
+func parseLinkHeader(header string) map[string]string {
+	links := make(map[string]string)
+	for _, link := range strings.Split(header, ",") {
+		segments := strings.Split(link, ";")
+		target := strings.TrimSpace(segments[0])
+		if !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		target = strings.Trim(target, "<>")
+
+		for _, param := range segments[1:] {
+			key, value, found := strings.Cut(strings.TrimSpace(param), "=")
+			if !found || key != "rel" {
+				continue
+			}
+			for _, rel := range strings.Fields(strings.Trim(value, `"`)) {
+				links[rel] = target
+			}
+		}
+	}
+	return links
+}
+
+// pageOf returns the page query parameter of a pagination link, or 0 when
+// the link is missing or carries no page number.
+func pageOf(link string) int {
+	if link == "" {
+		return 0
+	}
+	u, err := url.Parse(link)
+	if err != nil {
+		return 0
+	}
+	page, err := strconv.Atoi(u.Query().Get("page"))
+	if err != nil {
+		return 0
+	}
+	return page
+}
+
+// pagination is the position of a page within a paginated listing.
+type pagination struct {
+	next int
+	last int
+}
+
+func paginationOf(linkHeader string) pagination {
+	links := parseLinkHeader(linkHeader)
+	return pagination{
+		next: pageOf(links["next"]),
+		last: pageOf(links["last"]),
+	}
+}
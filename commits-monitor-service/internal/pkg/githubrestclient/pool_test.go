@@ -0,0 +1,83 @@
+package githubrestclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTokenPool(t *testing.T) {
+	pool, err := newTokenPool(&models.Config{
+		GithubToken:  "ghp_first_token_1234",
+		GithubTokens: []string{"ghp_second_token_5678", "ghp_first_token_1234", ""},
+	}, defaultBaseURL, http.DefaultClient)
+	require.NoError(t, err)
+	require.Len(t, pool.credentials, 2)
+	require.Equal(t, "ghp_…1234", pool.credentials[0].name)
+
+	pool, err = newTokenPool(&models.Config{}, defaultBaseURL, http.DefaultClient)
+	require.NoError(t, err)
+	require.Len(t, pool.credentials, 1)
+
+	_, err = newTokenPool(&models.Config{GithubAppID: "42", GithubAppInstallationID: "99"}, defaultBaseURL, http.DefaultClient)
+	require.Error(t, err)
+}
+
+func TestTokenPoolPrefersMostRemaining(t *testing.T) {
+	pool, err := newTokenPool(&models.Config{GithubTokens: []string{"ghp_first_token_1234", "ghp_second_token_5678"}}, defaultBaseURL, http.DefaultClient)
+	require.NoError(t, err)
+	reset := time.Now().Add(time.Hour)
+	pool.credentials[0].limiter.update(rateLimitHeader(5000, 1000, reset))
+	pool.credentials[1].limiter.update(rateLimitHeader(5000, 4000, reset))
+
+	cred, err := pool.acquire()
+	require.NoError(t, err)
+	require.Equal(t, pool.credentials[1], cred)
+
+	// an exhausted token is parked until its reset
+	pool.credentials[1].limiter.update(rateLimitHeader(5000, 0, reset))
+	cred, err = pool.acquire()
+	require.NoError(t, err)
+	require.Equal(t, pool.credentials[0], cred)
+
+	budget := pool.rateLimit()
+	require.Equal(t, 10000, budget.Limit)
+	require.Equal(t, 1000, budget.Remaining)
+}
+
+func TestTokenPoolParksUnauthorizedTokens(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.Header.Get("Authorization"), "revoked_token_0000") {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		w.Header().Set("X-RateLimit-Reset", "4102444800")
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	client, err := NewGithubRestClient(&models.Config{GithubTokens: []string{"ghp_revoked_token_0000", "ghp_working_token_1111"}})
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		response, err := client.get(server.URL + "/repos/o/r/commits")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, response.statusCode)
+	}
+
+	usage := client.TokenUsage()
+	require.Len(t, usage, 2)
+	require.True(t, usage[0].Unauthorized)
+	require.Equal(t, 1, usage[0].Requests)
+	require.False(t, usage[0].ParkedUntil.IsZero())
+	require.False(t, usage[1].Unauthorized)
+	require.Equal(t, 3, usage[1].Requests)
+	require.Equal(t, 4999, usage[1].RateLimit.Remaining)
+}
@@ -0,0 +1,94 @@
+package githubrestclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// FetchPullRequests fetches a page of the pull requests of a repository in
+// any state, most recently updated first. GitHub cannot filter pull requests
+// by date, so the listing stops at the first pull request updated before
+// since: it is left out of the page and no next page is returned. Unchanged
+// first pages come back from the cache. The cursor is the page number
+// returned as Next by the previous call, empty for the first page.
+func (gp GithubRestClient) FetchPullRequests(repositoryName string, since string, cursor string) (models.PullRequestsPage, error) {
+	page := cursor
+	if page == "" {
+		page = "1"
+	}
+	queryParams := map[string]string{
+		"state":     "all",
+		"sort":      "updated",
+		"direction": "desc",
+		"per_page":  "100",
+		"page":      page,
+	}
+
+	response, err := gp.get(buildURI(gp.baseURL, fmt.Sprintf("/repos/%s/pulls", repositoryName), queryParams))
+	if err != nil {
+		return models.PullRequestsPage{}, err
+	}
+	if response.statusCode != http.StatusOK {
+		return models.PullRequestsPage{}, newAPIError(response.statusCode, response.header, response.body)
+	}
+
+	var listed []models.PullRequestResponse
+	if err := json.Unmarshal(response.body, &listed); err != nil {
+		log.Println("CMOS: Error unmarshalling response body:", err)
+		return models.PullRequestsPage{}, err
+	}
+
+	sinceDate, _ := time.Parse(time.RFC3339, since)
+	pullRequests := make([]models.PullRequest, 0, len(listed))
+	for _, pr := range listed {
+		if pr.UpdatedAt.Before(sinceDate) {
+			return models.PullRequestsPage{PullRequests: pullRequests}, nil
+		}
+		pullRequests = append(pullRequests, pullRequestOf(pr))
+	}
+
+	var next string
+	if pages := paginationOf(response.link); pages.next != 0 {
+		next = strconv.Itoa(pages.next)
+	}
+	return models.PullRequestsPage{PullRequests: pullRequests, Next: next}, nil
+}
+
+// FetchPullRequestCommits returns the SHAs of the commits of a pull request.
+// GitHub lists at most 250 commits per pull request.
+func (gp GithubRestClient) FetchPullRequestCommits(repositoryName string, number int) ([]string, error) {
+	path := fmt.Sprintf("/repos/%s/pulls/%d/commits", repositoryName, number)
+
+	var shas []string
+	for page := 1; page != 0; {
+		queryParams := map[string]string{
+			"per_page": "100",
+			"page":     strconv.Itoa(page),
+		}
+
+		response, err := gp.get(buildURI(gp.baseURL, path, queryParams))
+		if err != nil {
+			return nil, err
+		}
+		if response.statusCode != http.StatusOK {
+			return nil, newAPIError(response.statusCode, response.header, response.body)
+		}
+
+		var commits []models.CommitResponse
+		if err := json.Unmarshal(response.body, &commits); err != nil {
+			log.Println("CMOS: Error unmarshalling response body:", err)
+			return nil, err
+		}
+		for _, commit := range commits {
+			shas = append(shas, commit.Sha)
+		}
+
+		page = paginationOf(response.link).next
+	}
+	return shas, nil
+}
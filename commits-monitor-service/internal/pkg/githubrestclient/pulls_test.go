@@ -0,0 +1,83 @@
+package githubrestclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchPullRequests(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/chromium/chromium/pulls":
+			require.Equal(t, "all", r.URL.Query().Get("state"))
+			require.Equal(t, "updated", r.URL.Query().Get("sort"))
+			require.Equal(t, "desc", r.URL.Query().Get("direction"))
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/chromium/chromium/pulls?page=2>; rel="next"`, server.URL))
+			w.Write([]byte(`[{
+			  "number": 7,
+			  "title": "Fix the build",
+			  "state": "closed",
+			  "user": {"login": "octocat"},
+			  "created_at": "2024-08-01T10:00:00Z",
+			  "updated_at": "2024-08-02T10:00:00Z",
+			  "closed_at": "2024-08-02T10:00:00Z",
+			  "merged_at": "2024-08-02T10:00:00Z",
+			  "head": {"ref": "fix/build", "sha": "2222"},
+			  "base": {"ref": "main", "sha": "1111"}
+			}, {
+			  "number": 6,
+			  "title": "Old",
+			  "state": "open",
+			  "user": {"login": "octocat"},
+			  "created_at": "2024-06-01T10:00:00Z",
+			  "updated_at": "2024-06-02T10:00:00Z",
+			  "head": {"ref": "old", "sha": "3333"},
+			  "base": {"ref": "main", "sha": "1111"}
+			}]`))
+		case "/repos/chromium/chromium/pulls/7/commits":
+			if r.URL.Query().Get("page") == "2" {
+				w.Write([]byte(`[{"sha": "2222"}]`))
+				return
+			}
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/chromium/chromium/pulls/7/commits?page=2>; rel="next"`, server.URL))
+			w.Write([]byte(`[{"sha": "1234"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewGithubRestClient(&models.Config{GithubAPIURL: server.URL})
+	require.NoError(t, err)
+
+	// the listing stops at the first pull request updated before since
+	page, err := client.FetchPullRequests("chromium/chromium", "2024-07-01T00:00:00Z", "")
+	require.NoError(t, err)
+	require.Empty(t, page.Next)
+	require.Len(t, page.PullRequests, 1)
+	pr := page.PullRequests[0]
+	require.Equal(t, 7, pr.Number)
+	require.Equal(t, "octocat", pr.Author)
+	require.Equal(t, "fix/build", pr.HeadRef)
+	require.Equal(t, "main", pr.BaseRef)
+	require.NotNil(t, pr.MergedAt)
+
+	// and goes on while all of them are newer
+	page, err = client.FetchPullRequests("chromium/chromium", "2024-01-01T00:00:00Z", "")
+	require.NoError(t, err)
+	require.Equal(t, "2", page.Next)
+	require.Len(t, page.PullRequests, 2)
+
+	shas, err := client.FetchPullRequestCommits("chromium/chromium", 7)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1234", "2222"}, shas)
+
+	_, err = client.FetchPullRequests("chromium/gone", "", "")
+	require.ErrorIs(t, err, ErrNotFound)
+}
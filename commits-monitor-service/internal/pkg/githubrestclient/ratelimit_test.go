@@ -0,0 +1,99 @@
+package githubrestclient
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func rateLimitHeader(limit, remaining int, reset time.Time) http.Header {
+	header := http.Header{}
+	header.Set("X-RateLimit-Limit", strconv.Itoa(limit))
+	header.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	return header
+}
+
+func TestRateLimiterUpdate(t *testing.T) {
+	rl := newRateLimiter()
+	require.False(t, rl.status().Known())
+
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	rl.update(rateLimitHeader(5000, 4999, reset))
+
+	budget := rl.status()
+	require.Equal(t, 5000, budget.Limit)
+	require.Equal(t, 4999, budget.Remaining)
+	require.True(t, budget.Reset.Equal(reset))
+	require.False(t, budget.Exhausted())
+}
+
+func TestRateLimiterDelay(t *testing.T) {
+	now := time.Now()
+	reset := now.Add(10 * time.Minute)
+
+	rl := newRateLimiter()
+	require.Zero(t, rl.delay(now))
+
+	rl.update(rateLimitHeader(5000, 4000, reset))
+	require.Zero(t, rl.delay(now))
+
+	// below the reserve the remaining requests are spread until the reset
+	rl.update(rateLimitHeader(5000, 100, reset))
+	require.InDelta(t, float64(6*time.Second), float64(rl.delay(now)), float64(time.Second))
+
+	rl.update(rateLimitHeader(5000, 0, reset))
+	require.True(t, rl.status().Exhausted())
+	require.InDelta(t, float64(10*time.Minute), float64(rl.delay(now)), float64(time.Second))
+
+	// a stale reset time no longer holds requests back
+	rl.update(rateLimitHeader(5000, 0, now.Add(-time.Minute)))
+	require.Zero(t, rl.delay(now))
+}
+
+func TestRetryDelay(t *testing.T) {
+	rl := newRateLimiter()
+
+	response := &http.Response{StatusCode: http.StatusTooManyRequests, Header: http.Header{}}
+	response.Header.Set("Retry-After", "30")
+	d, retry := rl.retryDelay(response, 0)
+	require.True(t, retry)
+	require.Equal(t, 30*time.Second, d)
+	require.InDelta(t, float64(30*time.Second), float64(rl.delay(time.Now())), float64(time.Second))
+
+	rl = newRateLimiter()
+	response = &http.Response{
+		StatusCode: http.StatusForbidden,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(`{"message":"You have exceeded a secondary rate limit."}`)),
+	}
+	d, retry = rl.retryDelay(response, 0)
+	require.True(t, retry)
+	require.Equal(t, secondaryRateLimitWait, d)
+	body, err := io.ReadAll(response.Body)
+	require.NoError(t, err)
+	require.Contains(t, string(body), "secondary rate limit")
+
+	response = &http.Response{
+		StatusCode: http.StatusForbidden,
+		Header:     http.Header{},
+		Body:       io.NopCloser(strings.NewReader(`{"message":"Resource not accessible by integration"}`)),
+	}
+	_, retry = rl.retryDelay(response, 0)
+	require.False(t, retry)
+
+	response = &http.Response{StatusCode: http.StatusBadGateway, Header: http.Header{}}
+	d, retry = rl.retryDelay(response, 2)
+	require.True(t, retry)
+	require.GreaterOrEqual(t, d, baseBackoff)
+	require.LessOrEqual(t, d, baseBackoff+4*baseBackoff)
+
+	response = &http.Response{StatusCode: http.StatusNotFound, Header: http.Header{}}
+	_, retry = rl.retryDelay(response, 0)
+	require.False(t, retry)
+}
@@ -0,0 +1,90 @@
+package githubrestclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+)
+
+// FetchReleases fetches a page of the releases of a repository, newest
+// first. The cursor is the page number returned as Next by the previous
+// call, empty for the first page.
+func (gp GithubRestClient) FetchReleases(repositoryName string, cursor string) (models.ReleasesPage, error) {
+	response, err := gp.getPage(fmt.Sprintf("/repos/%s/releases", repositoryName), cursor)
+	if err != nil {
+		return models.ReleasesPage{}, err
+	}
+
+	var releases []models.ReleaseResponse
+	if err := json.Unmarshal(response.body, &releases); err != nil {
+		log.Println("CMOS: Error unmarshalling response body:", err)
+		return models.ReleasesPage{}, err
+	}
+	page := models.ReleasesPage{
+		Releases:    make([]models.Release, 0, len(releases)),
+		Next:        nextCursor(response),
+		NotModified: response.notModified,
+	}
+	for _, release := range releases {
+		page.Releases = append(page.Releases, releaseOf(release))
+	}
+	return page, nil
+}
+
+// FetchTags fetches a page of the tags of a repository with the SHAs of the
+// commits they point to. The cursor is the page number returned as Next by
+// the previous call, empty for the first page.
+func (gp GithubRestClient) FetchTags(repositoryName string, cursor string) (models.TagsPage, error) {
+	response, err := gp.getPage(fmt.Sprintf("/repos/%s/tags", repositoryName), cursor)
+	if err != nil {
+		return models.TagsPage{}, err
+	}
+
+	var tags []models.TagResponse
+	if err := json.Unmarshal(response.body, &tags); err != nil {
+		log.Println("CMOS: Error unmarshalling response body:", err)
+		return models.TagsPage{}, err
+	}
+	page := models.TagsPage{
+		Tags:        make([]models.Tag, 0, len(tags)),
+		Next:        nextCursor(response),
+		NotModified: response.notModified,
+	}
+	for _, tag := range tags {
+		page.Tags = append(page.Tags, models.Tag{Name: tag.Name, Sha: tag.Commit.Sha})
+	}
+	return page, nil
+}
+
+// getPage fetches a page of 100 entries of a listing.
+func (gp GithubRestClient) getPage(path string, cursor string) (apiResponse, error) {
+	page := cursor
+	if page == "" {
+		page = "1"
+	}
+	queryParams := map[string]string{
+		"per_page": "100",
+		"page":     page,
+	}
+
+	response, err := gp.get(buildURI(gp.baseURL, path, queryParams))
+	if err != nil {
+		return apiResponse{}, err
+	}
+	if response.statusCode != http.StatusOK {
+		return apiResponse{}, newAPIError(response.statusCode, response.header, response.body)
+	}
+	return response, nil
+}
+
+// nextCursor returns the cursor of the page after response, empty on the
+// last page.
+func nextCursor(response apiResponse) string {
+	if pages := paginationOf(response.link); pages.next != 0 {
+		return strconv.Itoa(pages.next)
+	}
+	return ""
+}
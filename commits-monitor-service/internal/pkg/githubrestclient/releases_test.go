@@ -0,0 +1,70 @@
+package githubrestclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFetchReleasesAndTags(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/repos/chromium/chromium/releases":
+			w.Header().Set("ETag", `"releases"`)
+			if r.Header.Get("If-None-Match") == `"releases"` {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Write([]byte(`[{
+			  "tag_name": "v2.3",
+			  "name": "2.3",
+			  "target_commitish": "main",
+			  "prerelease": false,
+			  "author": {"login": "octocat"},
+			  "created_at": "2024-08-01T10:00:00Z",
+			  "published_at": "2024-08-02T10:00:00Z"
+			}]`))
+		case "/repos/chromium/chromium/tags":
+			if r.URL.Query().Get("page") == "2" {
+				w.Write([]byte(`[{"name": "v2.2", "commit": {"sha": "1111"}}]`))
+				return
+			}
+			w.Header().Set("Link", fmt.Sprintf(`<%s/repos/chromium/chromium/tags?page=2>; rel="next"`, server.URL))
+			w.Write([]byte(`[{"name": "v2.3", "commit": {"sha": "2222"}}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client, err := NewGithubRestClient(&models.Config{GithubAPIURL: server.URL})
+	require.NoError(t, err)
+
+	releases, err := client.FetchReleases("chromium/chromium", "")
+	require.NoError(t, err)
+	require.False(t, releases.NotModified)
+	require.Len(t, releases.Releases, 1)
+	require.Equal(t, "v2.3", releases.Releases[0].TagName)
+	require.Equal(t, "octocat", releases.Releases[0].Author)
+	require.NotNil(t, releases.Releases[0].PublishedAt)
+
+	releases, err = client.FetchReleases("chromium/chromium", "")
+	require.NoError(t, err)
+	require.True(t, releases.NotModified)
+	require.Len(t, releases.Releases, 1)
+
+	tags, err := client.FetchTags("chromium/chromium", "")
+	require.NoError(t, err)
+	require.Equal(t, "2", tags.Next)
+	require.Equal(t, "2222", tags.Tags[0].Sha)
+
+	tags, err = client.FetchTags("chromium/chromium", tags.Next)
+	require.NoError(t, err)
+	require.Empty(t, tags.Next)
+	require.Equal(t, "v2.2", tags.Tags[0].Name)
+}
@@ -0,0 +1,66 @@
+package githubrestclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const defaultBaseURL = "https://api.github.com"
+
+// apiURL returns the root of the REST API of the configured server.
+func apiURL(config *models.Config) string {
+	if config.GithubAPIURL == "" {
+		return defaultBaseURL
+	}
+	return strings.TrimSuffix(config.GithubAPIURL, "/")
+}
+
+// graphQLEndpoint returns the GraphQL endpoint of the configured server.
+// GitHub Enterprise Server serves it at /api/graphql next to /api/v3.
+func graphQLEndpoint(config *models.Config) string {
+	if config.GithubGraphQLURL != "" {
+		return config.GithubGraphQLURL
+	}
+	base := apiURL(config)
+	if root, ok := strings.CutSuffix(base, "/api/v3"); ok {
+		return root + "/api/graphql"
+	}
+	return base + "/graphql"
+}
+
+// newHTTPClient returns the client used to talk to the configured server,
+// trusting its CA bundle when one is set.
+func newHTTPClient(config *models.Config) (*http.Client, error) {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	if config.GithubCACert == "" {
+		return httpClient, nil
+	}
+
+	data := []byte(config.GithubCACert)
+	if !strings.Contains(config.GithubCACert, "-----BEGIN") {
+		var err error
+		data, err = os.ReadFile(config.GithubCACert)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	if !roots.AppendCertsFromPEM(data) {
+		return nil, errors.New("CMOS: github CA bundle contains no PEM encoded certificate")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: roots}
+	httpClient.Transport = transport
+	return httpClient, nil
+}
@@ -0,0 +1,75 @@
+package githubrestclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGraphQLEndpoint(t *testing.T) {
+	require.Equal(t, "https://api.github.com/graphql", graphQLEndpoint(&models.Config{}))
+	require.Equal(t, "https://ghe.corp/api/graphql", graphQLEndpoint(&models.Config{GithubAPIURL: "https://ghe.corp/api/v3/"}))
+	require.Equal(t, "http://localhost:8080/graphql", graphQLEndpoint(&models.Config{GithubAPIURL: "http://localhost:8080"}))
+	require.Equal(t, "https://ghe.corp/gql", graphQLEndpoint(&models.Config{
+		GithubAPIURL:     "https://ghe.corp/api/v3",
+		GithubGraphQLURL: "https://ghe.corp/gql",
+	}))
+}
+
+func TestRestClientTrustsConfiguredCA(t *testing.T) {
+	var path string
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	caCert := filepath.Join(t.TempDir(), "ca.pem")
+	certificate := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+	require.NoError(t, os.WriteFile(caCert, certificate, 0o600))
+
+	config := &models.Config{GithubAPIURL: server.URL + "/api/v3"}
+	untrusted, err := NewGithubRestClient(config)
+	require.NoError(t, err)
+	_, err = untrusted.FetchCommits("corp/app", "", "", "", 100, "")
+	require.Error(t, err)
+
+	config.GithubCACert = caCert
+	client, err := NewGithubRestClient(config)
+	require.NoError(t, err)
+	page, err := client.FetchCommits("corp/app", "", "", "", 100, "")
+	require.NoError(t, err)
+	require.Empty(t, page.Commits)
+	require.Equal(t, "/api/v3/repos/corp/app/commits", path)
+
+	_, err = NewGithubRestClient(&models.Config{GithubCACert: "not a certificate"})
+	require.Error(t, err)
+}
+
+func TestConfigWithServer(t *testing.T) {
+	config := &models.Config{
+		GithubToken:   "public",
+		GithubAppID:   "42",
+		GithubAPIURL:  "",
+		GithubCACert:  "",
+		GithubServers: map[string]models.GithubServer{"ghe": {Owners: []string{"corp"}}},
+	}
+
+	enterprise := config.WithServer(models.GithubServer{APIURL: "https://ghe.corp/api/v3", Token: "corp"})
+	require.Equal(t, "https://ghe.corp/api/v3", enterprise.GithubAPIURL)
+	require.Equal(t, "corp", enterprise.GithubToken)
+	require.Empty(t, enterprise.GithubAppID)
+	require.Empty(t, enterprise.GithubServers)
+	require.Equal(t, "public", config.GithubToken)
+
+	shared := config.WithServer(models.GithubServer{CACert: "/etc/ssl/proxy.pem"})
+	require.Equal(t, "public", shared.GithubToken)
+	require.Equal(t, "42", shared.GithubAppID)
+	require.Equal(t, "/etc/ssl/proxy.pem", shared.GithubCACert)
+}
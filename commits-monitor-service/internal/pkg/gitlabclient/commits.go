@@ -0,0 +1,215 @@
+package gitlabclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"encoding/json"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// commit is a commit as listed by GET /projects/:id/repository/commits.
+type commit struct {
+	ID             string    `json:"id"`
+	Message        string    `json:"message"`
+	AuthorName     string    `json:"author_name"`
+	AuthorEmail    string    `json:"author_email"`
+	AuthoredDate   time.Time `json:"authored_date"`
+	CommitterName  string    `json:"committer_name"`
+	CommitterEmail string    `json:"committer_email"`
+	CommittedDate  time.Time `json:"committed_date"`
+	ParentIDs      []string  `json:"parent_ids"`
+	WebURL         string    `json:"web_url"`
+	Stats          *struct {
+		Additions int `json:"additions"`
+		Deletions int `json:"deletions"`
+		Total     int `json:"total"`
+	} `json:"stats"`
+}
+
+// commit maps a commit to the type of the provider interface. GitLab lists
+// no account with commits, only the names and emails of their signatures.
+func (c commit) commit() models.Commit {
+	converted := models.Commit{
+		Sha:       c.ID,
+		URL:       c.WebURL,
+		HTMLURL:   c.WebURL,
+		Message:   c.Message,
+		Author:    models.Signature{Name: c.AuthorName, Email: c.AuthorEmail, Date: c.AuthoredDate},
+		Committer: models.Signature{Name: c.CommitterName, Email: c.CommitterEmail, Date: c.CommittedDate},
+		Parents:   c.ParentIDs,
+	}
+	if c.Stats != nil {
+		converted.Stats = &models.CommitStats{Additions: c.Stats.Additions, Deletions: c.Stats.Deletions, Total: c.Stats.Total}
+	}
+	return converted
+}
+
+// FetchCommits fetches a page of the commits of a branch of a project, newest
+// first, committed between since and until, either of them may be empty. An
+// empty branch stands for the default branch.
+func (gl GitlabClient) FetchCommits(repositoryName string, branch string, since string, until string, perPage int32, cursor string) (models.CommitsPage, error) {
+	page := cursor
+	if page == "" {
+		page = "1"
+	}
+	queryParams := map[string]string{
+		"per_page": strconv.Itoa(int(perPage)),
+		"page":     page,
+		"since":    since,
+	}
+	if until != "" {
+		queryParams["until"] = until
+	}
+	if branch != "" {
+		queryParams["ref_name"] = branch
+	}
+
+	response, err := gl.get(gl.uri(projectPath(repositoryName, "/repository/commits"), queryParams))
+	if err != nil {
+		return models.CommitsPage{}, err
+	}
+
+	var commits []commit
+	if err := json.Unmarshal(response.body, &commits); err != nil {
+		log.Println("CMOS: Error unmarshalling response body:", err)
+		return models.CommitsPage{}, err
+	}
+
+	converted := make([]models.Commit, 0, len(commits))
+	for _, commit := range commits {
+		converted = append(converted, commit.commit())
+	}
+	return models.CommitsPage{Commits: converted, Next: response.next()}, nil
+}
+
+// FetchDefaultBranch returns the name of the default branch of a project.
+func (gl GitlabClient) FetchDefaultBranch(repositoryName string) (string, error) {
+	response, err := gl.get(gl.uri(projectPath(repositoryName, ""), nil))
+	if err != nil {
+		return "", err
+	}
+
+	var project struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := json.Unmarshal(response.body, &project); err != nil {
+		log.Println("CMOS: Error unmarshalling response body:", err)
+		return "", err
+	}
+	return project.DefaultBranch, nil
+}
+
+// FetchBranches lists all branches of a project.
+func (gl GitlabClient) FetchBranches(repositoryName string) ([]models.Branch, error) {
+	var branches []models.Branch
+	for cursor := "1"; cursor != ""; {
+		response, err := gl.getPage(projectPath(repositoryName, "/repository/branches"), nil, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		var branchesPage []struct {
+			Name   string `json:"name"`
+			Commit struct {
+				ID string `json:"id"`
+			} `json:"commit"`
+		}
+		if err := json.Unmarshal(response.body, &branchesPage); err != nil {
+			log.Println("CMOS: Error unmarshalling response body:", err)
+			return nil, err
+		}
+		for _, listed := range branchesPage {
+			branches = append(branches, models.Branch{Name: listed.Name, HeadSha: listed.Commit.ID})
+		}
+
+		cursor = response.next()
+	}
+	return branches, nil
+}
+
+// FetchCommitDetails fetches a single commit of a project with its stats and
+// changed files. GitLab does not count the lines of each file, they are
+// counted in its diff.
+func (gl GitlabClient) FetchCommitDetails(repositoryName string, sha string) (models.Commit, error) {
+	path := projectPath(repositoryName, "/repository/commits/"+sha)
+
+	response, err := gl.get(gl.uri(path, map[string]string{"stats": "true"}))
+	if err != nil {
+		return models.Commit{}, err
+	}
+
+	var detailed commit
+	if err := json.Unmarshal(response.body, &detailed); err != nil {
+		log.Println("CMOS: Error unmarshalling response body:", err)
+		return models.Commit{}, err
+	}
+	details := detailed.commit()
+
+	for cursor := "1"; cursor != ""; {
+		response, err := gl.getPage(path+"/diff", nil, cursor)
+		if err != nil {
+			return models.Commit{}, err
+		}
+
+		var diffs []diff
+		if err := json.Unmarshal(response.body, &diffs); err != nil {
+			log.Println("CMOS: Error unmarshalling response body:", err)
+			return models.Commit{}, err
+		}
+		for _, diff := range diffs {
+			file := models.CommitFile{Filename: diff.NewPath}
+			file.Status, file.PreviousFilename = diff.status()
+			file.Additions, file.Deletions = diff.lines()
+			file.Changes = file.Additions + file.Deletions
+			details.Files = append(details.Files, file)
+		}
+
+		cursor = response.next()
+	}
+	return details, nil
+}
+
+// diff is the change of a file as listed by
+// GET /projects/:id/repository/commits/:sha/diff.
+type diff struct {
+	OldPath     string `json:"old_path"`
+	NewPath     string `json:"new_path"`
+	NewFile     bool   `json:"new_file"`
+	RenamedFile bool   `json:"renamed_file"`
+	DeletedFile bool   `json:"deleted_file"`
+	Diff        string `json:"diff"`
+}
+
+// status returns how the file of the diff changed, in the words of GitHub,
+// and its previous path when it was renamed.
+func (d diff) status() (string, string) {
+	switch {
+	case d.NewFile:
+		return "added", ""
+	case d.DeletedFile:
+		return "removed", ""
+	case d.RenamedFile:
+		return "renamed", d.OldPath
+	}
+	return "modified", ""
+}
+
+// lines counts the added and deleted lines of the hunks of the diff.
+func (d diff) lines() (int, int) {
+	var additions, deletions int
+	inHunk := false
+	for _, line := range strings.Split(d.Diff, "\n") {
+		switch {
+		case strings.HasPrefix(line, "@@"):
+			inHunk = true
+		case !inHunk:
+		case strings.HasPrefix(line, "+"):
+			additions++
+		case strings.HasPrefix(line, "-"):
+			deletions++
+		}
+	}
+	return additions, deletions
+}
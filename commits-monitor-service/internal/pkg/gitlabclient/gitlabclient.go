@@ -0,0 +1,235 @@
+package gitlabclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"commits-monitor-service/internal/pkg/githubrestclient"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultBaseURL = "https://gitlab.com/api/v4"
+
+// maxRetries is the number of times a rate limited request is retried
+// before giving up.
+const maxRetries = 5
+
+// GitlabClient syncs projects through the GitLab REST API v4. A project is
+// addressed by its full path (group/subgroup/name), which takes the place of
+// the full name of a GitHub repository. Merge requests are returned as pull
+// requests and pipelines as workflow runs.
+type GitlabClient struct {
+	Config     *models.Config
+	baseURL    string
+	httpClient *http.Client
+	usage      *tokenUsage
+}
+
+func NewGitlabClient(Config *models.Config) (GitlabClient, error) {
+	httpClient, err := newHTTPClient(Config.GitlabCACert)
+	if err != nil {
+		return GitlabClient{}, err
+	}
+
+	baseURL := defaultBaseURL
+	if Config.GitlabAPIURL != "" {
+		baseURL = strings.TrimSuffix(Config.GitlabAPIURL, "/")
+	}
+
+	return GitlabClient{
+		Config:     Config,
+		baseURL:    baseURL,
+		httpClient: httpClient,
+		usage:      &tokenUsage{},
+	}, nil
+}
+
+// RateLimit returns the request budget reported by GitLab on the latest
+// response.
+func (gl GitlabClient) RateLimit() githubrestclient.RateLimit {
+	gl.usage.mu.Lock()
+	defer gl.usage.mu.Unlock()
+	return gl.usage.budget
+}
+
+// TokenUsage returns the budget and usage of the configured token.
+func (gl GitlabClient) TokenUsage() []githubrestclient.TokenUsage {
+	gl.usage.mu.Lock()
+	defer gl.usage.mu.Unlock()
+	return []githubrestclient.TokenUsage{{
+		Token:     "gitlab " + maskToken(gl.Config.GitlabToken),
+		RateLimit: gl.usage.budget,
+		Requests:  gl.usage.requests,
+	}}
+}
+
+// projectPath returns the API path of a project given by its full path.
+func projectPath(repositoryName string, path string) string {
+	return "/projects/" + url.PathEscape(repositoryName) + path
+}
+
+func (gl GitlabClient) uri(path string, queryParams map[string]string) string {
+	query := url.Values{}
+	for key, value := range queryParams {
+		query.Set(key, value)
+	}
+	uri := gl.baseURL + path
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
+	}
+	return uri
+}
+
+// apiResponse is a successful GitLab response with its body already read.
+type apiResponse struct {
+	header http.Header
+	body   []byte
+}
+
+// next returns the cursor of the page after the response, empty on the last
+// page.
+func (r apiResponse) next() string {
+	return r.header.Get("X-Next-Page")
+}
+
+// getPage fetches a page of 100 entries of a listing. The cursor is the page
+// number returned as Next by the previous call, empty for the first page.
+func (gl GitlabClient) getPage(path string, queryParams map[string]string, cursor string) (apiResponse, error) {
+	page := cursor
+	if page == "" {
+		page = "1"
+	}
+	params := map[string]string{
+		"per_page": "100",
+		"page":     page,
+	}
+	for key, value := range queryParams {
+		params[key] = value
+	}
+	return gl.get(gl.uri(path, params))
+}
+
+// get sends a GET request, waiting and retrying while GitLab rate limits
+// it. Any status but 200 is returned as a *githubrestclient.APIError.
+func (gl GitlabClient) get(requestUrl string) (apiResponse, error) {
+	for attempt := 0; ; attempt++ {
+		request, err := http.NewRequest(http.MethodGet, requestUrl, nil)
+		if err != nil {
+			return apiResponse{}, err
+		}
+		if gl.Config.GitlabToken != "" {
+			request.Header.Set("PRIVATE-TOKEN", gl.Config.GitlabToken)
+		}
+
+		response, err := gl.httpClient.Do(request)
+		if err != nil {
+			return apiResponse{}, err
+		}
+		gl.usage.record(response.Header)
+
+		body, err := io.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			log.Println("CMOS: Error reading response body:", err)
+			return apiResponse{}, err
+		}
+
+		if response.StatusCode == http.StatusTooManyRequests && attempt < maxRetries {
+			wait := retryAfter(response.Header, time.Now())
+			log.Printf("CMOS: gitlab rate limited %s, retrying in %s\n", request.URL.Path, wait.Round(time.Second))
+			time.Sleep(wait)
+			continue
+		}
+		if response.StatusCode != http.StatusOK {
+			return apiResponse{}, githubrestclient.NewAPIError(response.StatusCode, response.Header, body)
+		}
+		return apiResponse{header: response.Header, body: body}, nil
+	}
+}
+
+// retryAfter reads how long a rate limited request has to wait from the
+// Retry-After or RateLimit-Reset header.
+func retryAfter(header http.Header, now time.Time) time.Duration {
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if reset, err := strconv.ParseInt(header.Get("RateLimit-Reset"), 10, 64); err == nil && time.Unix(reset, 0).After(now) {
+		return time.Unix(reset, 0).Sub(now)
+	}
+	return time.Minute
+}
+
+// tokenUsage tracks the budget GitLab reports in the RateLimit headers.
+type tokenUsage struct {
+	mu       sync.Mutex
+	budget   githubrestclient.RateLimit
+	requests int
+}
+
+func (u *tokenUsage) record(header http.Header) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.requests++
+
+	limit, err := strconv.Atoi(header.Get("RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, _ := strconv.Atoi(header.Get("RateLimit-Remaining"))
+	reset, _ := strconv.ParseInt(header.Get("RateLimit-Reset"), 10, 64)
+	u.budget = githubrestclient.RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+		Used:      limit - remaining,
+		Reset:     time.Unix(reset, 0),
+	}
+}
+
+// maskToken keeps just enough of a token to tell tokens apart in logs.
+func maskToken(token string) string {
+	if len(token) <= 12 {
+		return "****"
+	}
+	return fmt.Sprintf("%s…%s", token[:4], token[len(token)-4:])
+}
+
+// newHTTPClient returns the client used to talk to GitLab, trusting the CA
+// bundle, given as PEM contents or file path, when one is set.
+func newHTTPClient(caCert string) (*http.Client, error) {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	if caCert == "" {
+		return httpClient, nil
+	}
+
+	data := []byte(caCert)
+	if !strings.Contains(caCert, "-----BEGIN") {
+		var err error
+		data, err = os.ReadFile(caCert)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	if !roots.AppendCertsFromPEM(data) {
+		return nil, errors.New("CMOS: gitlab CA bundle contains no PEM encoded certificate")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: roots}
+	httpClient.Transport = transport
+	return httpClient, nil
+}
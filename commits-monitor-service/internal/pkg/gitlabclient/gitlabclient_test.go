@@ -0,0 +1,257 @@
+package gitlabclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"commits-monitor-service/internal/pkg/githubrestclient"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) GitlabClient {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	client, err := NewGitlabClient(&models.Config{
+		GitlabAPIURL: server.URL + "/api/v4",
+		GitlabToken:  "glpat-secret",
+	})
+	require.NoError(t, err)
+	return client
+}
+
+func TestFetchCommits(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/api/v4/projects/platform%2Fbackend%2Fapi/repository/commits", r.URL.EscapedPath())
+		require.Equal(t, "glpat-secret", r.Header.Get("PRIVATE-TOKEN"))
+		require.Equal(t, "develop", r.URL.Query().Get("ref_name"))
+		require.Equal(t, "2024-01-01T00:00:00Z", r.URL.Query().Get("since"))
+		require.Equal(t, "2098-10-03T10:01:20Z", r.URL.Query().Get("until"))
+
+		w.Header().Set("RateLimit-Limit", "2000")
+		w.Header().Set("RateLimit-Remaining", "1999")
+		w.Header().Set("RateLimit-Reset", "4102444800")
+		if r.URL.Query().Get("page") == "2" {
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Header().Set("X-Next-Page", "2")
+		w.Write([]byte(`[{
+		  "id": "2222",
+		  "message": "Fix login\n\nDetails",
+		  "author_name": "Jane",
+		  "author_email": "jane@corp",
+		  "authored_date": "2024-03-01T10:00:00Z",
+		  "committer_name": "John",
+		  "committed_date": "2024-03-02T10:00:00Z",
+		  "parent_ids": ["1111", "0000"],
+		  "web_url": "https://gitlab.corp/platform/backend/api/-/commit/2222"
+		}]`))
+	})
+
+	page, err := client.FetchCommits("platform/backend/api", "develop", "2024-01-01T00:00:00Z", "2098-10-03T10:01:20Z", 100, "")
+	require.NoError(t, err)
+	require.Equal(t, "2", page.Next)
+	require.Len(t, page.Commits, 1)
+
+	commit := page.Commits[0]
+	require.Equal(t, "2222", commit.Sha)
+	require.Equal(t, "Fix login\n\nDetails", commit.Message)
+	require.Equal(t, "Jane", commit.Author.Name)
+	require.Equal(t, "John", commit.Committer.Name)
+	require.Equal(t, "https://gitlab.corp/platform/backend/api/-/commit/2222", commit.URL)
+	require.Len(t, commit.Parents, 2)
+	require.Equal(t, "1111", commit.Parents[0])
+	require.Equal(t, "0000", commit.Parents[1])
+
+	page, err = client.FetchCommits("platform/backend/api", "develop", "2024-01-01T00:00:00Z", "2098-10-03T10:01:20Z", 100, page.Next)
+	require.NoError(t, err)
+	require.Empty(t, page.Commits)
+	require.Empty(t, page.Next)
+
+	budget := client.RateLimit()
+	require.Equal(t, 2000, budget.Limit)
+	require.Equal(t, 1999, budget.Remaining)
+	require.Equal(t, 2, client.TokenUsage()[0].Requests)
+}
+
+func TestFetchCommitsNotFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "404 Project Not Found"}`))
+	})
+
+	_, err := client.FetchCommits("platform/gone", "", "2024-01-01T00:00:00Z", "", 100, "")
+	require.True(t, errors.Is(err, githubrestclient.ErrNotFound))
+
+	var apiErr *githubrestclient.APIError
+	require.True(t, errors.As(err, &apiErr))
+	require.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+}
+
+func TestFetchBranchesAndDefaultBranch(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/platform%2Fapi":
+			w.Write([]byte(`{"default_branch": "main"}`))
+		case "/api/v4/projects/platform%2Fapi/repository/branches":
+			if r.URL.Query().Get("page") == "2" {
+				w.Write([]byte(`[{"name": "develop", "commit": {"id": "2222"}}]`))
+				return
+			}
+			w.Header().Set("X-Next-Page", "2")
+			w.Write([]byte(`[{"name": "main", "protected": true, "commit": {"id": "1111"}}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	defaultBranch, err := client.FetchDefaultBranch("platform/api")
+	require.NoError(t, err)
+	require.Equal(t, "main", defaultBranch)
+
+	branches, err := client.FetchBranches("platform/api")
+	require.NoError(t, err)
+	require.Len(t, branches, 2)
+	require.Equal(t, "main", branches[0].Name)
+	require.Equal(t, "1111", branches[0].HeadSha)
+	require.Equal(t, "develop", branches[1].Name)
+}
+
+func TestFetchCommitDetails(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/platform%2Fapi/repository/commits/2222":
+			require.Equal(t, "true", r.URL.Query().Get("stats"))
+			w.Write([]byte(`{"id": "2222", "stats": {"additions": 3, "deletions": 2, "total": 5}}`))
+		case "/api/v4/projects/platform%2Fapi/repository/commits/2222/diff":
+			w.Write([]byte(`[
+			  {"old_path": "a.go", "new_path": "a.go", "diff": "@@ -1,2 +1,2 @@\n-old\n--- not a header\n+new\n context\n"},
+			  {"old_path": "b.go", "new_path": "c.go", "renamed_file": true, "diff": ""},
+			  {"old_path": "d.go", "new_path": "d.go", "new_file": true, "diff": "@@ -0,0 +1,2 @@\n+one\n+++two\n"}
+			]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	commit, err := client.FetchCommitDetails("platform/api", "2222")
+	require.NoError(t, err)
+	require.NotNil(t, commit.Stats)
+	require.Equal(t, 5, commit.Stats.Total)
+	require.Len(t, commit.Files, 3)
+
+	require.Equal(t, "a.go", commit.Files[0].Filename)
+	require.Equal(t, "modified", commit.Files[0].Status)
+	require.Equal(t, 1, commit.Files[0].Additions)
+	require.Equal(t, 2, commit.Files[0].Deletions)
+
+	require.Equal(t, "renamed", commit.Files[1].Status)
+	require.Equal(t, "b.go", commit.Files[1].PreviousFilename)
+
+	require.Equal(t, "added", commit.Files[2].Status)
+	require.Equal(t, 2, commit.Files[2].Additions)
+}
+
+func TestFetchPullRequestsAndIssues(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/platform%2Fapi/merge_requests":
+			require.Equal(t, "all", r.URL.Query().Get("state"))
+			require.Equal(t, "desc", r.URL.Query().Get("sort"))
+			require.Equal(t, "2024-01-01T00:00:00Z", r.URL.Query().Get("updated_after"))
+			w.Write([]byte(`[
+			  {"iid": 7, "title": "Add cache", "state": "merged", "author": {"username": "jane"},
+			   "created_at": "2024-03-01T10:00:00Z", "updated_at": "2024-03-03T10:00:00Z", "merged_at": "2024-03-03T10:00:00Z",
+			   "squash_commit_sha": "3333", "sha": "2222", "source_branch": "cache", "target_branch": "main"},
+			  {"iid": 8, "title": "Draft", "state": "opened", "draft": true, "author": {"username": "john"},
+			   "created_at": "2024-03-04T10:00:00Z", "updated_at": "2024-03-04T10:00:00Z"}
+			]`))
+		case "/api/v4/projects/platform%2Fapi/merge_requests/7/commits":
+			w.Write([]byte(`[{"id": "2222"}, {"id": "1111"}]`))
+		case "/api/v4/projects/platform%2Fapi/issues":
+			require.Equal(t, "2024-01-01T00:00:00Z", r.URL.Query().Get("updated_after"))
+			w.Write([]byte(`[{"iid": 3, "title": "Crash", "state": "closed", "author": {"username": "jane"},
+			  "labels": ["bug"], "assignees": [{"username": "john"}], "user_notes_count": 4,
+			  "created_at": "2024-02-01T10:00:00Z", "updated_at": "2024-02-02T10:00:00Z", "closed_at": "2024-02-02T10:00:00Z"}]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	pulls, err := client.FetchPullRequests("platform/api", "2024-01-01T00:00:00Z", "")
+	require.NoError(t, err)
+	require.Len(t, pulls.PullRequests, 2)
+	merged := pulls.PullRequests[0]
+	require.Equal(t, 7, merged.Number)
+	require.Equal(t, "closed", merged.State)
+	require.NotNil(t, merged.MergedAt)
+	require.NotNil(t, merged.ClosedAt)
+	require.Equal(t, "3333", merged.MergeCommitSha)
+	require.Equal(t, "cache", merged.HeadRef)
+	require.Equal(t, "main", merged.BaseRef)
+	require.Equal(t, "open", pulls.PullRequests[1].State)
+	require.True(t, pulls.PullRequests[1].Draft)
+
+	shas, err := client.FetchPullRequestCommits("platform/api", 7)
+	require.NoError(t, err)
+	require.Equal(t, []string{"2222", "1111"}, shas)
+
+	issues, err := client.FetchIssues("platform/api", "2024-01-01T00:00:00Z", "")
+	require.NoError(t, err)
+	require.Len(t, issues.Issues, 1)
+	issue := issues.Issues[0]
+	require.Equal(t, 3, issue.Number)
+	require.Equal(t, "closed", issue.State)
+	require.Equal(t, "bug", issue.Labels[0])
+	require.Equal(t, "john", issue.Assignees[0])
+	require.Equal(t, 4, issue.Comments)
+}
+
+func TestFetchReleasesTagsAndPipelines(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/api/v4/projects/platform%2Fapi/releases":
+			w.Write([]byte(`[{"tag_name": "v1.0", "name": "1.0", "created_at": "2024-03-01T10:00:00Z",
+			  "released_at": "2024-03-02T10:00:00Z", "author": {"username": "jane"}, "commit": {"id": "2222"}}]`))
+		case "/api/v4/projects/platform%2Fapi/repository/tags":
+			w.Write([]byte(`[{"name": "v1.0", "commit": {"id": "2222"}}]`))
+		case "/api/v4/projects/platform%2Fapi/pipelines":
+			require.Equal(t, "asc", r.URL.Query().Get("sort"))
+			w.Write([]byte(`[
+			  {"id": 12, "sha": "2222", "ref": "main", "status": "failed", "source": "push",
+			   "created_at": "2024-03-01T10:00:00Z", "updated_at": "2024-03-01T10:05:00Z", "started_at": "2024-03-01T10:01:00Z"},
+			  {"id": 13, "sha": "3333", "ref": "main", "status": "running", "source": "push",
+			   "created_at": "2024-03-01T11:00:00Z", "updated_at": "2024-03-01T11:00:00Z"}
+			]`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	releases, err := client.FetchReleases("platform/api", "")
+	require.NoError(t, err)
+	require.Len(t, releases.Releases, 1)
+	require.Equal(t, "v1.0", releases.Releases[0].TagName)
+	require.Equal(t, "2222", releases.Releases[0].TargetCommitish)
+	require.Equal(t, "jane", releases.Releases[0].Author)
+	require.NotNil(t, releases.Releases[0].PublishedAt)
+
+	tags, err := client.FetchTags("platform/api", "")
+	require.NoError(t, err)
+	require.Len(t, tags.Tags, 1)
+	require.Equal(t, "2222", tags.Tags[0].Sha)
+
+	runs, err := client.FetchWorkflowRuns("platform/api", "", "")
+	require.NoError(t, err)
+	require.Len(t, runs.Runs, 2)
+	require.Equal(t, "completed", runs.Runs[0].Status)
+	require.Equal(t, "failure", runs.Runs[0].Conclusion)
+	require.Equal(t, "pipeline", runs.Runs[0].Name)
+	require.Equal(t, 1, runs.Runs[0].RunStartedAt.Minute())
+	require.Equal(t, "in_progress", runs.Runs[1].Status)
+	require.Empty(t, runs.Runs[1].Conclusion)
+}
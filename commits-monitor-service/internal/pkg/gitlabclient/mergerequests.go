@@ -0,0 +1,189 @@
+package gitlabclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+)
+
+// mergeRequest is a merge request as listed by
+// GET /projects/:id/merge_requests.
+type mergeRequest struct {
+	IID    int    `json:"iid"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	Draft  bool   `json:"draft"`
+	Author struct {
+		Username string `json:"username"`
+	} `json:"author"`
+	WebURL          string     `json:"web_url"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+	ClosedAt        *time.Time `json:"closed_at"`
+	MergedAt        *time.Time `json:"merged_at"`
+	MergeCommitSha  string     `json:"merge_commit_sha"`
+	SquashCommitSha string     `json:"squash_commit_sha"`
+	Sha             string     `json:"sha"`
+	SourceBranch    string     `json:"source_branch"`
+	TargetBranch    string     `json:"target_branch"`
+}
+
+// pullRequest maps a merge request to the type of the provider interface.
+// Merge requests that are not open any more are closed.
+func (mr mergeRequest) pullRequest() models.PullRequest {
+	pullRequest := models.PullRequest{
+		Number:         mr.IID,
+		Title:          mr.Title,
+		State:          "closed",
+		Draft:          mr.Draft,
+		Author:         mr.Author.Username,
+		HTMLURL:        mr.WebURL,
+		CreatedAt:      mr.CreatedAt,
+		UpdatedAt:      mr.UpdatedAt,
+		ClosedAt:       mr.ClosedAt,
+		MergedAt:       mr.MergedAt,
+		MergeCommitSha: mr.MergeCommitSha,
+		HeadRef:        mr.SourceBranch,
+		HeadSha:        mr.Sha,
+		BaseRef:        mr.TargetBranch,
+	}
+	if mr.State == "opened" {
+		pullRequest.State = "open"
+	}
+	if mr.MergedAt != nil && pullRequest.ClosedAt == nil {
+		pullRequest.ClosedAt = mr.MergedAt
+	}
+	if pullRequest.MergeCommitSha == "" {
+		pullRequest.MergeCommitSha = mr.SquashCommitSha
+	}
+	return pullRequest
+}
+
+// FetchPullRequests fetches a page of the merge requests of a project in any
+// state updated at or after since, most recently updated first.
+func (gl GitlabClient) FetchPullRequests(repositoryName string, since string, cursor string) (models.PullRequestsPage, error) {
+	queryParams := map[string]string{
+		"state":    "all",
+		"order_by": "updated_at",
+		"sort":     "desc",
+	}
+	if since != "" {
+		queryParams["updated_after"] = since
+	}
+	response, err := gl.getPage(projectPath(repositoryName, "/merge_requests"), queryParams, cursor)
+	if err != nil {
+		return models.PullRequestsPage{}, err
+	}
+
+	var mergeRequests []mergeRequest
+	if err := json.Unmarshal(response.body, &mergeRequests); err != nil {
+		log.Println("CMOS: Error unmarshalling response body:", err)
+		return models.PullRequestsPage{}, err
+	}
+
+	pullRequests := make([]models.PullRequest, 0, len(mergeRequests))
+	for _, mergeRequest := range mergeRequests {
+		pullRequests = append(pullRequests, mergeRequest.pullRequest())
+	}
+	return models.PullRequestsPage{PullRequests: pullRequests, Next: response.next()}, nil
+}
+
+// FetchPullRequestCommits returns the SHAs of the commits of a merge request.
+func (gl GitlabClient) FetchPullRequestCommits(repositoryName string, number int) ([]string, error) {
+	path := projectPath(repositoryName, fmt.Sprintf("/merge_requests/%d/commits", number))
+
+	var shas []string
+	for cursor := "1"; cursor != ""; {
+		response, err := gl.getPage(path, nil, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		var commits []commit
+		if err := json.Unmarshal(response.body, &commits); err != nil {
+			log.Println("CMOS: Error unmarshalling response body:", err)
+			return nil, err
+		}
+		for _, commit := range commits {
+			shas = append(shas, commit.ID)
+		}
+
+		cursor = response.next()
+	}
+	return shas, nil
+}
+
+// issue is an issue as listed by GET /projects/:id/issues.
+type issue struct {
+	IID    int    `json:"iid"`
+	Title  string `json:"title"`
+	State  string `json:"state"`
+	Author struct {
+		Username string `json:"username"`
+	} `json:"author"`
+	Labels    []string `json:"labels"`
+	Assignees []struct {
+		Username string `json:"username"`
+	} `json:"assignees"`
+	UserNotesCount int        `json:"user_notes_count"`
+	WebURL         string     `json:"web_url"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	ClosedAt       *time.Time `json:"closed_at"`
+}
+
+// issue maps an issue to the type of the provider interface.
+func (i issue) issue() models.Issue {
+	converted := models.Issue{
+		Number:    i.IID,
+		Title:     i.Title,
+		State:     "closed",
+		Author:    i.Author.Username,
+		Labels:    i.Labels,
+		Assignees: make([]string, 0, len(i.Assignees)),
+		Comments:  i.UserNotesCount,
+		HTMLURL:   i.WebURL,
+		CreatedAt: i.CreatedAt,
+		UpdatedAt: i.UpdatedAt,
+		ClosedAt:  i.ClosedAt,
+	}
+	if i.State == "opened" {
+		converted.State = "open"
+	}
+	for _, assignee := range i.Assignees {
+		converted.Assignees = append(converted.Assignees, assignee.Username)
+	}
+	return converted
+}
+
+// FetchIssues fetches a page of the issues of a project in any state updated
+// at or after since, least recently updated first. GitLab lists merge
+// requests apart, so none are included.
+func (gl GitlabClient) FetchIssues(repositoryName string, since string, cursor string) (models.IssuesPage, error) {
+	queryParams := map[string]string{
+		"scope":    "all",
+		"order_by": "updated_at",
+		"sort":     "asc",
+	}
+	if since != "" {
+		queryParams["updated_after"] = since
+	}
+	response, err := gl.getPage(projectPath(repositoryName, "/issues"), queryParams, cursor)
+	if err != nil {
+		return models.IssuesPage{}, err
+	}
+
+	var issues []issue
+	if err := json.Unmarshal(response.body, &issues); err != nil {
+		log.Println("CMOS: Error unmarshalling response body:", err)
+		return models.IssuesPage{}, err
+	}
+
+	converted := make([]models.Issue, 0, len(issues))
+	for _, issue := range issues {
+		converted = append(converted, issue.issue())
+	}
+	return models.IssuesPage{Issues: converted, Next: response.next()}, nil
+}
@@ -0,0 +1,90 @@
+package gitlabclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"encoding/json"
+	"log"
+	"time"
+)
+
+// pipeline is a pipeline as listed by GET /projects/:id/pipelines.
+type pipeline struct {
+	ID        int64      `json:"id"`
+	Name      string     `json:"name"`
+	Sha       string     `json:"sha"`
+	Ref       string     `json:"ref"`
+	Status    string     `json:"status"`
+	Source    string     `json:"source"`
+	WebURL    string     `json:"web_url"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+	StartedAt *time.Time `json:"started_at"`
+}
+
+// workflowRun maps a pipeline to a workflow run. Finished pipelines are
+// completed with the conclusion matching their status, running ones in
+// progress and all others queued.
+func (p pipeline) workflowRun() models.WorkflowRun {
+	run := models.WorkflowRun{
+		ID:           p.ID,
+		Name:         p.Name,
+		HeadBranch:   p.Ref,
+		HeadSha:      p.Sha,
+		Event:        p.Source,
+		RunAttempt:   1,
+		HTMLURL:      p.WebURL,
+		CreatedAt:    p.CreatedAt,
+		UpdatedAt:    p.UpdatedAt,
+		RunStartedAt: p.CreatedAt,
+	}
+	if run.Name == "" {
+		run.Name = "pipeline"
+	}
+	if p.StartedAt != nil {
+		run.RunStartedAt = *p.StartedAt
+	}
+
+	switch p.Status {
+	case "success":
+		run.Status, run.Conclusion = "completed", "success"
+	case "failed":
+		run.Status, run.Conclusion = "completed", "failure"
+	case "canceled":
+		run.Status, run.Conclusion = "completed", "cancelled"
+	case "skipped":
+		run.Status, run.Conclusion = "completed", "skipped"
+	case "running":
+		run.Status = "in_progress"
+	default:
+		run.Status = "queued"
+	}
+	return run
+}
+
+// FetchWorkflowRuns fetches a page of the pipelines of a project updated at
+// or after since, which includes all created since then, oldest first.
+func (gl GitlabClient) FetchWorkflowRuns(repositoryName string, since string, cursor string) (models.WorkflowRunsPage, error) {
+	queryParams := map[string]string{
+		"order_by": "id",
+		"sort":     "asc",
+	}
+	if since != "" {
+		queryParams["updated_after"] = since
+	}
+	response, err := gl.getPage(projectPath(repositoryName, "/pipelines"), queryParams, cursor)
+	if err != nil {
+		return models.WorkflowRunsPage{}, err
+	}
+
+	var pipelines []pipeline
+	if err := json.Unmarshal(response.body, &pipelines); err != nil {
+		log.Println("CMOS: Error unmarshalling response body:", err)
+		return models.WorkflowRunsPage{}, err
+	}
+
+	runs := make([]models.WorkflowRun, 0, len(pipelines))
+	for _, pipeline := range pipelines {
+		runs = append(runs, pipeline.workflowRun())
+	}
+	return models.WorkflowRunsPage{Runs: runs, Next: response.next()}, nil
+}
@@ -0,0 +1,87 @@
+package gitlabclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"encoding/json"
+	"log"
+	"time"
+)
+
+// release is a release as listed by GET /projects/:id/releases.
+type release struct {
+	TagName         string     `json:"tag_name"`
+	Name            string     `json:"name"`
+	CreatedAt       time.Time  `json:"created_at"`
+	ReleasedAt      *time.Time `json:"released_at"`
+	UpcomingRelease bool       `json:"upcoming_release"`
+	Author          struct {
+		Username string `json:"username"`
+	} `json:"author"`
+	Commit struct {
+		ID string `json:"id"`
+	} `json:"commit"`
+	Links struct {
+		Self string `json:"self"`
+	} `json:"_links"`
+}
+
+// release maps a release to the type of the provider interface. GitLab
+// has no drafts; upcoming releases are taken for prereleases.
+func (r release) release() models.Release {
+	return models.Release{
+		TagName:         r.TagName,
+		Name:            r.Name,
+		TargetCommitish: r.Commit.ID,
+		Prerelease:      r.UpcomingRelease,
+		Author:          r.Author.Username,
+		HTMLURL:         r.Links.Self,
+		CreatedAt:       r.CreatedAt,
+		PublishedAt:     r.ReleasedAt,
+	}
+}
+
+// FetchReleases fetches a page of the releases of a project, newest first.
+func (gl GitlabClient) FetchReleases(repositoryName string, cursor string) (models.ReleasesPage, error) {
+	response, err := gl.getPage(projectPath(repositoryName, "/releases"), nil, cursor)
+	if err != nil {
+		return models.ReleasesPage{}, err
+	}
+
+	var releases []release
+	if err := json.Unmarshal(response.body, &releases); err != nil {
+		log.Println("CMOS: Error unmarshalling response body:", err)
+		return models.ReleasesPage{}, err
+	}
+
+	converted := make([]models.Release, 0, len(releases))
+	for _, release := range releases {
+		converted = append(converted, release.release())
+	}
+	return models.ReleasesPage{Releases: converted, Next: response.next()}, nil
+}
+
+// FetchTags fetches a page of the tags of a project with the SHAs of the
+// commits they point to.
+func (gl GitlabClient) FetchTags(repositoryName string, cursor string) (models.TagsPage, error) {
+	response, err := gl.getPage(projectPath(repositoryName, "/repository/tags"), nil, cursor)
+	if err != nil {
+		return models.TagsPage{}, err
+	}
+
+	var tags []struct {
+		Name   string `json:"name"`
+		Commit struct {
+			ID string `json:"id"`
+		} `json:"commit"`
+	}
+	if err := json.Unmarshal(response.body, &tags); err != nil {
+		log.Println("CMOS: Error unmarshalling response body:", err)
+		return models.TagsPage{}, err
+	}
+
+	converted := make([]models.Tag, 0, len(tags))
+	for _, tag := range tags {
+		converted = append(converted, models.Tag{Name: tag.Name, Sha: tag.Commit.ID})
+	}
+	return models.TagsPage{Tags: converted, Next: response.next()}, nil
+}
@@ -0,0 +1,396 @@
+package localgitclient
+
+import (
+	"bytes"
+	"commits-monitor-service/internal/constants/models"
+	"commits-monitor-service/internal/pkg/githubrestclient"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// LocalGitClient syncs git repositories in a directory laid out as
+// <owner>/<name>, for mirrors that cannot reach any API. A repository is a
+// working copy or a bare repository, <name>.git, and is read with the git
+// command. Commits come with their parents, author, committer and file
+// stats; plain git has no pull requests, issues, releases or workflow runs,
+// so those are listed empty.
+type LocalGitClient struct {
+	Config *models.Config
+	root   string
+}
+
+func NewLocalGitClient(Config *models.Config) (LocalGitClient, error) {
+	root, err := filepath.Abs(Config.LocalReposDir)
+	if err != nil {
+		return LocalGitClient{}, err
+	}
+	// git compares safe.directory with the resolved path of a repository
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return LocalGitClient{}, fmt.Errorf("CMOS: local repositories need the git command: %w", err)
+	}
+	return LocalGitClient{Config: Config, root: root}, nil
+}
+
+// Owners lists the owner directories of the repositories directory.
+func (lg LocalGitClient) Owners() ([]string, error) {
+	entries, err := os.ReadDir(lg.root)
+	if err != nil {
+		return nil, err
+	}
+
+	var owners []string
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			owners = append(owners, entry.Name())
+		}
+	}
+	return owners, nil
+}
+
+// RateLimit returns no budget, reading from disk is not limited.
+func (lg LocalGitClient) RateLimit() githubrestclient.RateLimit {
+	return githubrestclient.RateLimit{}
+}
+
+// TokenUsage returns nothing, no token is used.
+func (lg LocalGitClient) TokenUsage() []githubrestclient.TokenUsage {
+	return nil
+}
+
+// FetchDefaultBranch returns the branch HEAD points to.
+func (lg LocalGitClient) FetchDefaultBranch(repositoryName string) (string, error) {
+	output, err := lg.git(repositoryName, "symbolic-ref", "--short", "HEAD")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(output), nil
+}
+
+// FetchBranches lists all branches of a repository.
+func (lg LocalGitClient) FetchBranches(repositoryName string) ([]models.Branch, error) {
+	output, err := lg.git(repositoryName, "for-each-ref", "--format=%(refname:short)%00%(objectname)", "refs/heads")
+	if err != nil {
+		return nil, err
+	}
+
+	var branches []models.Branch
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		name, sha, ok := strings.Cut(line, "\x00")
+		if !ok {
+			continue
+		}
+		branches = append(branches, models.Branch{Name: name, HeadSha: sha})
+	}
+	return branches, nil
+}
+
+// commitFormat prints the fields of a commit separated by NUL, each commit
+// starting with a record separator since messages span lines.
+const commitFormat = "--format=%x1e%H%x00%P%x00%an%x00%ae%x00%aI%x00%cn%x00%ce%x00%cI%x00%B"
+
+// FetchCommits fetches a page of the commits of a branch, newest first,
+// committed between since and until, either of them may be empty. An empty
+// branch stands for HEAD. The cursor is the number of commits to skip.
+func (lg LocalGitClient) FetchCommits(repositoryName string, branch string, since string, until string, perPage int32, cursor string) (models.CommitsPage, error) {
+	skip, _ := strconv.Atoi(cursor)
+	args := []string{"log", commitFormat,
+		"--max-count=" + strconv.Itoa(int(perPage)),
+		"--skip=" + strconv.Itoa(skip),
+	}
+	if since != "" {
+		args = append(args, "--since="+since)
+	}
+	if until != "" {
+		args = append(args, "--until="+until)
+	}
+	rev := "HEAD"
+	if branch != "" {
+		rev = "refs/heads/" + branch
+	}
+	args = append(args, "--end-of-options", rev, "--")
+
+	output, err := lg.git(repositoryName, args...)
+	if err != nil {
+		return models.CommitsPage{}, err
+	}
+
+	commits := parseCommits(output, lg.commitURL(repositoryName))
+	var next string
+	if len(commits) == int(perPage) {
+		next = strconv.Itoa(skip + len(commits))
+	}
+	return models.CommitsPage{Commits: commits, Next: next}, nil
+}
+
+// FetchCommitDetails reads a single commit with its stats and the files it
+// changed against its first parent, renames detected.
+func (lg LocalGitClient) FetchCommitDetails(repositoryName string, sha string) (models.Commit, error) {
+	output, err := lg.git(repositoryName, "log", commitFormat, "--max-count=1", "--end-of-options", sha, "--")
+	if err != nil {
+		return models.Commit{}, err
+	}
+	commits := parseCommits(output, lg.commitURL(repositoryName))
+	if len(commits) == 0 {
+		return models.Commit{}, fmt.Errorf("CMOS: commit %s of <%s>: %w", sha, repositoryName, githubrestclient.ErrNotFound)
+	}
+	commit := commits[0]
+
+	args := []string{"diff-tree", "-r", "-M", "--raw", "--numstat", "-z", "--no-commit-id"}
+	if len(commit.Parents) == 0 {
+		args = append(args, "--root", "--end-of-options", commit.Sha)
+	} else {
+		args = append(args, "--end-of-options", commit.Parents[0], commit.Sha)
+	}
+	output, err = lg.git(repositoryName, args...)
+	if err != nil {
+		return models.Commit{}, err
+	}
+
+	changes := parseDiffTree(output)
+	commit.Stats = &models.CommitStats{}
+	commit.Files = make([]models.CommitFile, 0, len(changes))
+	for _, change := range changes {
+		commit.Files = append(commit.Files, models.CommitFile{
+			Sha:              change.sha,
+			Filename:         change.path,
+			Status:           change.status,
+			Additions:        change.additions,
+			Deletions:        change.deletions,
+			Changes:          change.additions + change.deletions,
+			PreviousFilename: change.previousPath,
+		})
+		commit.Stats.Additions += change.additions
+		commit.Stats.Deletions += change.deletions
+	}
+	commit.Stats.Total = commit.Stats.Additions + commit.Stats.Deletions
+	return commit, nil
+}
+
+// FetchPullRequests lists nothing, plain git has no pull requests.
+func (lg LocalGitClient) FetchPullRequests(repositoryName string, since string, cursor string) (models.PullRequestsPage, error) {
+	return models.PullRequestsPage{}, nil
+}
+
+// FetchPullRequestCommits lists nothing, plain git has no pull requests.
+func (lg LocalGitClient) FetchPullRequestCommits(repositoryName string, number int) ([]string, error) {
+	return nil, nil
+}
+
+// FetchIssues lists nothing, plain git has no issues.
+func (lg LocalGitClient) FetchIssues(repositoryName string, since string, cursor string) (models.IssuesPage, error) {
+	return models.IssuesPage{}, nil
+}
+
+// FetchReleases lists nothing, plain git has tags but no releases.
+func (lg LocalGitClient) FetchReleases(repositoryName string, cursor string) (models.ReleasesPage, error) {
+	return models.ReleasesPage{}, nil
+}
+
+// FetchTags lists all tags of a repository, newest first, with the commits
+// they point to.
+func (lg LocalGitClient) FetchTags(repositoryName string, cursor string) (models.TagsPage, error) {
+	output, err := lg.git(repositoryName, "for-each-ref", "--sort=-creatordate",
+		"--format=%(refname:short)%00%(objectname)%00%(*objectname)", "refs/tags")
+	if err != nil {
+		return models.TagsPage{}, err
+	}
+
+	var tags []models.Tag
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 3 {
+			continue
+		}
+		tag := models.Tag{Name: fields[0], Sha: fields[1]}
+		// Annotated tags point to a tag object, peeled to its commit.
+		if fields[2] != "" {
+			tag.Sha = fields[2]
+		}
+		tags = append(tags, tag)
+	}
+	return models.TagsPage{Tags: tags}, nil
+}
+
+// FetchWorkflowRuns lists nothing, plain git has no CI.
+func (lg LocalGitClient) FetchWorkflowRuns(repositoryName string, since string, cursor string) (models.WorkflowRunsPage, error) {
+	return models.WorkflowRunsPage{}, nil
+}
+
+// dir returns the directory of a repository given by its full name, the
+// working copy owner/name or the bare repository owner/name.git.
+func (lg LocalGitClient) dir(repositoryName string) (string, error) {
+	owner, name, ok := strings.Cut(repositoryName, "/")
+	if ok && isPathElement(owner) && isPathElement(name) {
+		for _, dir := range []string{name, name + ".git"} {
+			path := filepath.Join(lg.root, owner, dir)
+			if isRepository(path) {
+				return path, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("CMOS: local repository <%s>: %w", repositoryName, githubrestclient.ErrNotFound)
+}
+
+// commitURL returns the URL of the commits of a repository, given their SHA.
+func (lg LocalGitClient) commitURL(repositoryName string) func(sha string) string {
+	dir, _ := lg.dir(repositoryName)
+	return func(sha string) string {
+		return "file://" + filepath.ToSlash(dir) + "#" + sha
+	}
+}
+
+// git runs a git command in a repository. Mirrors are often owned by
+// another user, so the repository is trusted, and only that one: it is
+// always a repository of the repositories directory. Unknown revisions are
+// reported as githubrestclient.ErrNotFound like a missing GitHub branch.
+func (lg LocalGitClient) git(repositoryName string, args ...string) (string, error) {
+	dir, err := lg.dir(repositoryName)
+	if err != nil {
+		return "", err
+	}
+
+	cmd := exec.Command("git", append([]string{"-c", "safe.directory=" + dir, "-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		message := strings.TrimSpace(stderr.String())
+		if isUnknownRevision(message) {
+			return "", fmt.Errorf("CMOS: git %s in <%s>: %s: %w", args[0], repositoryName, message, githubrestclient.ErrNotFound)
+		}
+		return "", fmt.Errorf("CMOS: git %s in <%s>: %s: %w", args[0], repositoryName, message, err)
+	}
+	return string(output), nil
+}
+
+func isUnknownRevision(message string) bool {
+	for _, text := range []string{"unknown revision", "bad revision", "bad object", "not a valid object", "ambiguous argument"} {
+		if strings.Contains(message, text) {
+			return true
+		}
+	}
+	return false
+}
+
+// parseCommits reads the commits printed with commitFormat.
+func parseCommits(output string, commitURL func(sha string) string) []models.Commit {
+	var commits []models.Commit
+	for _, record := range strings.Split(output, "\x1e") {
+		fields := strings.SplitN(record, "\x00", 9)
+		if len(fields) != 9 {
+			continue
+		}
+
+		commits = append(commits, models.Commit{
+			Sha:       fields[0],
+			URL:       commitURL(fields[0]),
+			HTMLURL:   commitURL(fields[0]),
+			Message:   strings.TrimRight(fields[8], "\n"),
+			Author:    models.Signature{Name: fields[2], Email: fields[3], Date: parseDate(fields[4])},
+			Committer: models.Signature{Name: fields[5], Email: fields[6], Date: parseDate(fields[7])},
+			Parents:   strings.Fields(fields[1]),
+		})
+	}
+	return commits
+}
+
+func parseDate(value string) time.Time {
+	date, _ := time.Parse(time.RFC3339, value)
+	return date.UTC()
+}
+
+// fileChange is a file changed by a commit.
+type fileChange struct {
+	sha          string
+	path         string
+	previousPath string
+	status       string
+	additions    int
+	deletions    int
+}
+
+// parseDiffTree reads the output of git diff-tree -z with both --raw and
+// --numstat: first an entry per changed file with its status, then the
+// line counts of the same files in the same order. Binary files have no
+// line counts.
+func parseDiffTree(output string) []fileChange {
+	fields := strings.Split(output, "\x00")
+
+	var changes []fileChange
+	var counted int
+	for i := 0; i < len(fields); {
+		field := fields[i]
+		switch {
+		case field == "":
+			i++
+		case strings.HasPrefix(field, ":"):
+			// :<old mode> <new mode> <old sha> <new sha> <status>
+			raw := strings.Fields(field)
+			if len(raw) < 5 || i+1 >= len(fields) {
+				return changes
+			}
+			change := fileChange{sha: raw[3], path: fields[i+1], status: fileStatus(raw[4])}
+			i += 2
+			if change.status == "renamed" || change.status == "copied" {
+				if i >= len(fields) {
+					return changes
+				}
+				change.previousPath, change.path = change.path, fields[i]
+				i++
+			}
+			changes = append(changes, change)
+		default:
+			// <additions>\t<deletions>\t<path>, the path being empty and
+			// followed by the old and new path for renames.
+			counts := strings.SplitN(field, "\t", 3)
+			i++
+			if len(counts) == 3 && counts[2] == "" {
+				i += 2
+			}
+			if len(counts) == 3 && counted < len(changes) {
+				changes[counted].additions, _ = strconv.Atoi(counts[0])
+				changes[counted].deletions, _ = strconv.Atoi(counts[1])
+			}
+			counted++
+		}
+	}
+	return changes
+}
+
+// fileStatus returns the status of a file in the words of GitHub.
+func fileStatus(status string) string {
+	switch status[0] {
+	case 'A':
+		return "added"
+	case 'D':
+		return "removed"
+	case 'R':
+		return "renamed"
+	case 'C':
+		return "copied"
+	}
+	return "modified"
+}
+
+// isPathElement reports whether name is a single element of a path that
+// stays in its parent directory.
+func isPathElement(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`)
+}
+
+// isRepository reports whether dir is a working copy or a bare repository.
+func isRepository(dir string) bool {
+	if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+		return true
+	}
+	_, err := os.Stat(filepath.Join(dir, "HEAD"))
+	return err == nil
+}
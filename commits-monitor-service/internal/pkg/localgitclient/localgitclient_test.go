@@ -0,0 +1,224 @@
+package localgitclient
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"commits-monitor-service/internal/pkg/githubrestclient"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// run runs a git command in dir with fixed identities and dates.
+func run(t *testing.T, dir string, date string, args ...string) string {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Jane", "GIT_AUTHOR_EMAIL=jane@corp", "GIT_AUTHOR_DATE="+date,
+		"GIT_COMMITTER_NAME=John", "GIT_COMMITTER_EMAIL=john@corp", "GIT_COMMITTER_DATE="+date,
+		"GIT_CONFIG_NOSYSTEM=1", "HOME="+t.TempDir(),
+	)
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+	return strings.TrimSpace(string(output))
+}
+
+func writeFile(t *testing.T, path string, content string) {
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+// newTestRepositories lays out mirrors/app as a working copy with a few
+// commits, a tag and a second branch, and mirrors/app-bare.git as its bare
+// clone.
+func newTestRepositories(t *testing.T) (string, []string) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "mirrors", "app")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+
+	run(t, dir, "2024-03-01T10:00:00Z", "init", "-q", "-b", "main")
+	writeFile(t, filepath.Join(dir, "a.txt"), "one\ntwo\n")
+	writeFile(t, filepath.Join(dir, "b.txt"), "alpha\nbeta\ngamma\ndelta\n")
+	run(t, dir, "2024-03-01T10:00:00Z", "add", ".")
+	run(t, dir, "2024-03-01T10:00:00Z", "commit", "-q", "-m", "Initial commit")
+	first := run(t, dir, "2024-03-01T10:00:00Z", "rev-parse", "HEAD")
+	run(t, dir, "2024-03-01T10:00:00Z", "tag", "-a", "v1.0", "-m", "First release")
+
+	writeFile(t, filepath.Join(dir, "a.txt"), "one\nthree\nfour\n")
+	run(t, dir, "2024-03-02T10:00:00Z", "mv", "b.txt", "c.txt")
+	writeFile(t, filepath.Join(dir, "d.bin"), "\x00\x01\x02")
+	run(t, dir, "2024-03-02T10:00:00Z", "add", ".")
+	run(t, dir, "2024-03-02T10:00:00Z", "commit", "-q", "-m", "Rework files\n\nWith a body")
+	second := run(t, dir, "2024-03-02T10:00:00Z", "rev-parse", "HEAD")
+
+	run(t, dir, "2024-03-03T10:00:00Z", "rm", "-q", "a.txt")
+	run(t, dir, "2024-03-03T10:00:00Z", "commit", "-q", "-m", "Remove a")
+	third := run(t, dir, "2024-03-03T10:00:00Z", "rev-parse", "HEAD")
+	run(t, dir, "2024-03-03T10:00:00Z", "branch", "feature", second)
+
+	run(t, root, "2024-03-03T10:00:00Z", "clone", "-q", "--bare", dir, filepath.Join(root, "mirrors", "app-bare.git"))
+	return root, []string{first, second, third}
+}
+
+func TestOwnersAndBranches(t *testing.T) {
+	root, shas := newTestRepositories(t)
+	client, err := NewLocalGitClient(&models.Config{LocalReposDir: root})
+	require.NoError(t, err)
+
+	owners, err := client.Owners()
+	require.NoError(t, err)
+	require.Equal(t, []string{"mirrors"}, owners)
+
+	for _, repo := range []string{"mirrors/app", "mirrors/app-bare"} {
+		defaultBranch, err := client.FetchDefaultBranch(repo)
+		require.NoError(t, err)
+		require.Equal(t, "main", defaultBranch)
+
+		branches, err := client.FetchBranches(repo)
+		require.NoError(t, err)
+		require.Len(t, branches, 2)
+		require.Equal(t, "feature", branches[0].Name)
+		require.Equal(t, shas[1], branches[0].HeadSha)
+		require.Equal(t, "main", branches[1].Name)
+		require.Equal(t, shas[2], branches[1].HeadSha)
+	}
+}
+
+// TestForeignOwnedRepository reads a repository owned by another user,
+// which git refuses unless it is trusted.
+func TestForeignOwnedRepository(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("changing the owner of a repository needs root")
+	}
+	root, shas := newTestRepositories(t)
+	require.NoError(t, filepath.Walk(filepath.Join(root, "mirrors"), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Lchown(path, 65534, 65534)
+	}))
+	client, err := NewLocalGitClient(&models.Config{LocalReposDir: root})
+	require.NoError(t, err)
+
+	for _, repo := range []string{"mirrors/app", "mirrors/app-bare"} {
+		page, err := client.FetchCommits(repo, "main", "", "", 10, "")
+		require.NoError(t, err)
+		require.Len(t, page.Commits, 3)
+		require.Equal(t, shas[2], page.Commits[0].Sha)
+	}
+}
+
+func TestFetchCommits(t *testing.T) {
+	root, shas := newTestRepositories(t)
+	client, err := NewLocalGitClient(&models.Config{LocalReposDir: root})
+	require.NoError(t, err)
+
+	page, err := client.FetchCommits("mirrors/app-bare", "main", "2024-01-01T00:00:00Z", "2098-10-03T10:01:20Z", 2, "")
+	require.NoError(t, err)
+	require.Len(t, page.Commits, 2)
+	require.Equal(t, "2", page.Next)
+
+	commit := page.Commits[1]
+	require.Equal(t, shas[1], commit.Sha)
+	require.Equal(t, "Rework files\n\nWith a body", commit.Message)
+	require.Equal(t, "Jane", commit.Author.Name)
+	require.Equal(t, "jane@corp", commit.Author.Email)
+	require.Equal(t, "John", commit.Committer.Name)
+	require.Equal(t, "2024-03-02T10:00:00Z", commit.Author.Date.Format("2006-01-02T15:04:05Z07:00"))
+	require.Len(t, commit.Parents, 1)
+	require.Equal(t, shas[0], commit.Parents[0])
+
+	page, err = client.FetchCommits("mirrors/app-bare", "main", "2024-01-01T00:00:00Z", "2098-10-03T10:01:20Z", 2, page.Next)
+	require.NoError(t, err)
+	require.Len(t, page.Commits, 1)
+	require.Equal(t, shas[0], page.Commits[0].Sha)
+	require.Empty(t, page.Commits[0].Parents)
+	require.Empty(t, page.Next)
+
+	page, err = client.FetchCommits("mirrors/app", "", "2024-03-02T12:00:00Z", "", 100, "")
+	require.NoError(t, err)
+	require.Len(t, page.Commits, 1)
+	require.Equal(t, shas[2], page.Commits[0].Sha)
+}
+
+func TestFetchCommitsNotFound(t *testing.T) {
+	root, _ := newTestRepositories(t)
+	client, err := NewLocalGitClient(&models.Config{LocalReposDir: root})
+	require.NoError(t, err)
+
+	_, err = client.FetchCommits("mirrors/app", "gone", "2024-01-01T00:00:00Z", "", 100, "")
+	require.True(t, errors.Is(err, githubrestclient.ErrNotFound))
+
+	_, err = client.FetchBranches("mirrors/missing")
+	require.True(t, errors.Is(err, githubrestclient.ErrNotFound))
+
+	_, err = client.FetchBranches("../mirrors")
+	require.True(t, errors.Is(err, githubrestclient.ErrNotFound))
+}
+
+func TestFetchCommitDetails(t *testing.T) {
+	root, shas := newTestRepositories(t)
+	client, err := NewLocalGitClient(&models.Config{LocalReposDir: root})
+	require.NoError(t, err)
+
+	commit, err := client.FetchCommitDetails("mirrors/app", shas[1])
+	require.NoError(t, err)
+	require.Len(t, commit.Files, 3)
+
+	files := map[string]int{}
+	for i, file := range commit.Files {
+		files[file.Filename] = i
+	}
+
+	modified := commit.Files[files["a.txt"]]
+	require.Equal(t, "modified", modified.Status)
+	require.Equal(t, 2, modified.Additions)
+	require.Equal(t, 1, modified.Deletions)
+	require.Equal(t, 3, modified.Changes)
+	require.Len(t, modified.Sha, 40)
+
+	renamed := commit.Files[files["c.txt"]]
+	require.Equal(t, "renamed", renamed.Status)
+	require.Equal(t, "b.txt", renamed.PreviousFilename)
+	require.Equal(t, 0, renamed.Changes)
+
+	binary := commit.Files[files["d.bin"]]
+	require.Equal(t, "added", binary.Status)
+	require.Equal(t, 0, binary.Changes)
+
+	require.Equal(t, 2, commit.Stats.Additions)
+	require.Equal(t, 1, commit.Stats.Deletions)
+	require.Equal(t, 3, commit.Stats.Total)
+
+	initial, err := client.FetchCommitDetails("mirrors/app", shas[0])
+	require.NoError(t, err)
+	require.Len(t, initial.Files, 2)
+	require.Equal(t, 6, initial.Stats.Additions)
+
+	removal, err := client.FetchCommitDetails("mirrors/app", shas[2])
+	require.NoError(t, err)
+	require.Len(t, removal.Files, 1)
+	require.Equal(t, "removed", removal.Files[0].Status)
+	require.Equal(t, 3, removal.Files[0].Deletions)
+
+	_, err = client.FetchCommitDetails("mirrors/app", strings.Repeat("0", 40))
+	require.True(t, errors.Is(err, githubrestclient.ErrNotFound))
+}
+
+func TestFetchTags(t *testing.T) {
+	root, shas := newTestRepositories(t)
+	client, err := NewLocalGitClient(&models.Config{LocalReposDir: root})
+	require.NoError(t, err)
+
+	tags, err := client.FetchTags("mirrors/app", "")
+	require.NoError(t, err)
+	require.Len(t, tags.Tags, 1)
+	require.Equal(t, "v1.0", tags.Tags[0].Name)
+	require.Equal(t, shas[0], tags.Tags[0].Sha)
+}
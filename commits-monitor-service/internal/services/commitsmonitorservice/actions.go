@@ -0,0 +1,102 @@
+package commitsmonitorservice
+
+import (
+	"commits-monitor-service/internal/constants"
+	"commits-monitor-service/internal/constants/models"
+	"commits-monitor-service/internal/message-broker/rabbitmq"
+	"commits-monitor-service/internal/pkg/githubrestclient"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+)
+
+// maxWorkflowRunPages caps the pages of workflow runs listed per repository
+// and cycle.
+const maxWorkflowRunPages = 10
+
+// fetchAndSaveWorkflowRuns pushes the GitHub Actions workflow runs of a
+// repository created since the oldest stored run that was not completed yet,
+// or else since the newest stored run, so runs still in progress are picked
+// up again once they finish. On the first sync the runs created before the
+// configured start date are left out. Runs are listed oldest first and every
+// page is published as soon as it is fetched, so the stored watermark never
+// moves past runs that were not fetched and the runs beyond the page cap are
+// picked up by the next cycle.
+func (sc *CommentMonitorService) fetchAndSaveWorkflowRuns(repo string) {
+	createdSince, err := sc.ActionsMetaDataServiceClient.GetWorkflowRunsWatermark(repo)
+	if err != nil {
+		log.Println("CMOS: error getting a repository workflow runs watermark")
+		log.Println("CMOS: err:", err)
+		return
+	}
+	since := sc.since(repo, createdSince)
+
+	var total int
+	var cursor string
+	for page := 0; page < maxWorkflowRunPages; page++ {
+		runsPage, err := sc.Provider.FetchWorkflowRuns(repo, since, cursor)
+		if errors.Is(err, githubrestclient.ErrRateLimited) {
+			until := retryAt(err, sc.Provider.RateLimit(), time.Now())
+			sc.backoff.set(until)
+			log.Printf("CMOS: rate limited fetching workflow runs of <%s>, backing off until %s\n", repo, until.Format(time.RFC3339))
+			return
+		}
+		if errors.Is(err, githubrestclient.ErrNotFound) {
+			// Actions are disabled for the repository.
+			return
+		}
+		if err != nil {
+			log.Println("CMOS: error fetching workflow runs of ", repo)
+			log.Println("CMOS: err:", err)
+			return
+		}
+
+		if len(runsPage.Runs) > 0 {
+			if err := sc.pushWorkflowRunsToQueue(repo, runsPage.Runs); err != nil {
+				log.Println("CMOS: error pushing workflow runs of ", repo)
+				log.Println("CMOS: err:", err)
+				return
+			}
+			total += len(runsPage.Runs)
+		}
+
+		if runsPage.Next == "" {
+			break
+		}
+		if page == maxWorkflowRunPages-1 {
+			log.Printf("CMOS: repo <%s> more than %d pages of workflow runs, newer ones are left to the next cycle\n", repo, maxWorkflowRunPages)
+		}
+		cursor = runsPage.Next
+	}
+
+	log.Printf("CMOS: repo <%s> %d workflow runs pulled\n", repo, total)
+}
+
+func (sc *CommentMonitorService) pushWorkflowRunsToQueue(repoName string, runs []models.WorkflowRun) error {
+	emitter, err := event.NewEventEmitter(sc.Rabbit)
+	if err != nil {
+		return err
+	}
+
+	j, err := json.MarshalIndent(&event.Payload{
+		Name: "workflow-runs",
+		Data: WorkflowRunsMetaData{
+			Repository:   repoName,
+			FetchTime:    time.Now().UTC(),
+			WorkflowRuns: workflowRunMessages(runs),
+		},
+	}, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return emitter.Push(string(j), constants.ACTIONS_EVENT)
+}
+
+type WorkflowRunsMetaData struct {
+	// Repository is the full name (owner/name) of the repository.
+	Repository   string
+	FetchTime    time.Time
+	WorkflowRuns []models.WorkflowRunResponse
+}
@@ -0,0 +1,316 @@
+package commitsmonitorservice
+
+import (
+	"commits-monitor-service/internal/constants"
+	"commits-monitor-service/internal/constants/models"
+	bmds "commits-monitor-service/internal/http/grpc/protos/backfills"
+	"commits-monitor-service/internal/message-broker/rabbitmq"
+	"commits-monitor-service/internal/pkg/githubrestclient"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// defaultBackfillWorkers is the number of repositories of a backfill job
+// fetched at once when none is configured. It is kept low as backfills share
+// the request budget with regular polling.
+const defaultBackfillWorkers = 2
+
+// backfillBudgetReserve is the share of the request budget left to regular
+// polling: backfills wait for the budget to reset below it.
+const backfillBudgetReserve = 0.25
+
+// backfillHeartbeat is how often the progress of a running backfill job is
+// reported, which also keeps the commits manager from handing it out again.
+const backfillHeartbeat = time.Minute
+
+// Statuses of a backfill job.
+const (
+	backfillStatusRunning   = "running"
+	backfillStatusCompleted = "completed"
+	backfillStatusFailed    = "failed"
+)
+
+// BackfillProgress is the progress of a backfill job. The commits manager
+// stores it for the backfill jobs API.
+type BackfillProgress struct {
+	JobID             int64
+	Status            string
+	RepositoriesTotal int
+	RepositoriesDone  int
+	Commits           int
+	Error             string
+}
+
+func newBackfillPool(config *models.Config) *fetchPool {
+	workers := config.BackfillWorkers
+	if workers <= 0 {
+		workers = defaultBackfillWorkers
+	}
+	return newFetchPool(workers, config.FetchWorkersPerOwner)
+}
+
+// ScheduleBackfills runs the backfill jobs queued in the commits manager one
+// at a time, looking for a new one every interval while none is queued. Jobs
+// run on their own workers next to regular polling. The priority only orders
+// the queue: a job queued with a higher priority than the running one waits
+// for it to finish.
+func (sc *CommentMonitorService) ScheduleBackfills(interval time.Duration) {
+	for {
+		job, err := sc.BackfillsMetaDataServiceClient.ClaimBackfillJob()
+		if err != nil {
+			log.Println("CMOS: error claiming a backfill job")
+			log.Println("CMOS: err:", err)
+		}
+		if job == nil {
+			time.Sleep(interval)
+			continue
+		}
+		sc.runBackfill(job)
+	}
+}
+
+// runBackfill fetches the commits of the job's repositories authored in its
+// date range and reports its progress until it completes or fails.
+func (sc *CommentMonitorService) runBackfill(job *bmds.BackfillJob) {
+	progress := &backfillProgress{BackfillProgress: BackfillProgress{JobID: job.GetId(), Status: backfillStatusRunning}}
+
+	repositories := []string{job.GetRepositoryName()}
+	if job.GetRepositoryName() == "" {
+		names, err := sc.ReposMetaDataServiceClient.GetRepositoryNames()
+		if err != nil {
+			log.Printf("CMOS: error listing the repositories of backfill job %d\n", job.GetId())
+			log.Println("CMOS: err:", err)
+			progress.fail(fmt.Sprintf("cannot list repositories: %s", err))
+			sc.reportBackfill(progress.snapshot())
+			return
+		}
+		// paused repositories are left out of backfills of all of them
+		sc.refreshTrackingSettings()
+		repositories = make([]string, 0, len(names))
+		for _, name := range names {
+			if sc.settings(name).enabled {
+				repositories = append(repositories, name)
+			}
+		}
+	}
+	progress.start(len(repositories))
+
+	log.Printf("CMOS: backfill job %d of %d repositories from %s to %s started\n",
+		job.GetId(), len(repositories), job.GetStartDate(), job.GetEndDate())
+	sc.reportBackfill(progress.snapshot())
+
+	done := make(chan struct{})
+	go func() {
+		ticker := time.NewTicker(backfillHeartbeat)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				sc.reportBackfill(progress.snapshot())
+			}
+		}
+	}()
+
+	sc.backfillPool.run(repositories, func(repo string) {
+		fetched, err := sc.backfillRepository(repo, job.GetStartDate(), job.GetEndDate())
+		if err != nil {
+			log.Printf("CMOS: backfill job %d failed for <%s>\n", job.GetId(), repo)
+			log.Println("CMOS: err:", err)
+		}
+		progress.repositoryDone(repo, fetched, err)
+	})
+	close(done)
+
+	progress.finish()
+	report := progress.snapshot()
+	log.Printf("CMOS: backfill job %d %s, %d/%d repositories, %d commits\n",
+		job.GetId(), report.Status, report.RepositoriesDone, report.RepositoriesTotal, report.Commits)
+	sc.reportBackfill(report)
+}
+
+// backfillRepository pushes the commits of the tracked branches of a
+// repository authored between since and until. Commits are linked to their
+// branch only when they are not newer than the newest stored commit of that
+// branch, so backfills never move the watermark regular polling resumes
+// from. The rest are stored unlinked until polling reaches them.
+func (sc *CommentMonitorService) backfillRepository(repo string, since string, until string) (int, error) {
+	sc.waitForBackfillBudget()
+
+	defaultBranch, err := sc.Provider.FetchDefaultBranch(repo)
+	if err != nil {
+		return 0, sc.backfillError(err)
+	}
+	branches, err := sc.Provider.FetchBranches(repo)
+	if err != nil {
+		return 0, sc.backfillError(err)
+	}
+
+	var total int
+	for _, branch := range sc.trackedBranches(repo, defaultBranch, branches) {
+		fetched, err := sc.backfillBranch(repo, branch.Name, since, until)
+		total += fetched
+		if err != nil && branch.Name != defaultBranch && errors.Is(err, githubrestclient.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return total, sc.backfillError(err)
+		}
+	}
+	return total, nil
+}
+
+func (sc *CommentMonitorService) backfillBranch(repo string, branch string, since string, until string) (int, error) {
+	watermark, err := sc.CommitsMetaDataServiceClient.GetCommitWatermark(repo, branch)
+	if err != nil {
+		return 0, err
+	}
+	lastCommitDate, err := time.Parse(constants.ISO_8601_TIME_LAYOUT, watermark.LastCommitDate)
+	if err != nil {
+		lastCommitDate = time.Time{}
+	}
+
+	log.Printf("CMOS: backfilling commits of <%s> branch %s from %s to %s\n", repo, branch, since, until)
+
+	var total int
+	var cursor string
+	for {
+		sc.waitForBackfillBudget()
+		commitsPage, err := sc.Provider.FetchCommits(repo, branch, since, until, perPage, cursor)
+		if err != nil {
+			return total, err
+		}
+
+		linked, unlinked := splitAtWatermark(commitsPage.Commits, lastCommitDate)
+		if len(linked) > 0 {
+			if err := sc.pushToQueue(repo, branch, linked[0].Author.Date, linked); err != nil {
+				return total, err
+			}
+			total += len(linked)
+		}
+		if len(unlinked) > 0 {
+			if err := sc.pushToQueue(repo, "", unlinked[0].Author.Date, unlinked); err != nil {
+				return total, err
+			}
+			total += len(unlinked)
+		}
+
+		if commitsPage.Next == "" {
+			return total, nil
+		}
+		cursor = commitsPage.Next
+	}
+}
+
+// splitAtWatermark splits commits into those authored at or before the
+// watermark date and the newer ones. Without a watermark all are newer.
+func splitAtWatermark(commits []models.Commit, watermark time.Time) ([]models.Commit, []models.Commit) {
+	var linked, unlinked []models.Commit
+	for _, commit := range commits {
+		if !watermark.IsZero() && !commit.Author.Date.After(watermark) {
+			linked = append(linked, commit)
+		} else {
+			unlinked = append(unlinked, commit)
+		}
+	}
+	return linked, unlinked
+}
+
+// backfillError holds back polling and backfills alike when the request
+// budget ran out, as they share it.
+func (sc *CommentMonitorService) backfillError(err error) error {
+	if fetchOutcome(err).Outcome == constants.FETCH_OUTCOME_RATE_LIMITED {
+		sc.backoff.set(retryAt(err, sc.Provider.RateLimit(), time.Now()))
+	}
+	return err
+}
+
+// waitForBackfillBudget waits out a rate limit backoff and, while less than
+// backfillBudgetReserve of the request budget is left, its reset.
+func (sc *CommentMonitorService) waitForBackfillBudget() {
+	until := sc.backoff.get()
+	budget := sc.Provider.RateLimit()
+	if budget.Known() && float64(budget.Remaining) < float64(budget.Limit)*backfillBudgetReserve && budget.Reset.After(until) {
+		until = budget.Reset
+	}
+	if wait := time.Until(until); wait > 0 {
+		log.Printf("CMOS: backfill waiting %s for the rate limit budget\n", wait.Round(time.Second))
+		time.Sleep(wait)
+	}
+}
+
+// reportBackfill publishes the progress of a backfill job.
+func (sc *CommentMonitorService) reportBackfill(progress BackfillProgress) {
+	emitter, err := event.NewEventEmitter(sc.Rabbit)
+	if err == nil {
+		var j []byte
+		j, err = json.MarshalIndent(&event.Payload{
+			Name: "backfill-progress",
+			Data: progress,
+		}, "", "\t")
+		if err == nil {
+			err = emitter.Push(string(j), constants.COMMITS_EVENT)
+		}
+	}
+	if err != nil {
+		log.Printf("CMOS: error pushing progress of backfill job %d\n", progress.JobID)
+		log.Println("CMOS: err:", err)
+	}
+}
+
+// backfillProgress is the progress of a running backfill job, updated by
+// its workers.
+type backfillProgress struct {
+	mu sync.Mutex
+	BackfillProgress
+	failed []string
+}
+
+func (p *backfillProgress) start(total int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.RepositoriesTotal = total
+}
+
+func (p *backfillProgress) repositoryDone(repo string, fetched int, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.RepositoriesDone++
+	p.Commits += fetched
+	if err != nil {
+		p.failed = append(p.failed, repo)
+		if p.Error == "" {
+			p.Error = fmt.Sprintf("<%s>: %s", repo, err)
+		}
+	}
+}
+
+func (p *backfillProgress) fail(message string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.Status = backfillStatusFailed
+	p.Error = message
+}
+
+// finish completes the job, or fails it when any repository failed.
+func (p *backfillProgress) finish() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if len(p.failed) == 0 {
+		p.Status = backfillStatusCompleted
+		return
+	}
+	p.Status = backfillStatusFailed
+	p.Error = fmt.Sprintf("%d of %d repositories failed, first %s", len(p.failed), p.RepositoriesTotal, p.Error)
+}
+
+func (p *backfillProgress) snapshot() BackfillProgress {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.BackfillProgress
+}
@@ -3,120 +3,405 @@ package commitsmonitorservice
 import (
 	"commits-monitor-service/internal/constants"
 	"commits-monitor-service/internal/constants/models"
+	amdsc "commits-monitor-service/internal/http/grpc/client/actions"
+	bmdsc "commits-monitor-service/internal/http/grpc/client/backfills"
 	cmdsc "commits-monitor-service/internal/http/grpc/client/commits"
+	imdsc "commits-monitor-service/internal/http/grpc/client/issues"
+	pmdsc "commits-monitor-service/internal/http/grpc/client/pulls"
 	rmdsc "commits-monitor-service/internal/http/grpc/client/repos"
 	"commits-monitor-service/internal/message-broker/rabbitmq"
 	"commits-monitor-service/internal/pkg/githubrestclient"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
-	"sync"
+	"path"
+	"strings"
 	"time"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
 
-const perPage = 10
+const perPage = 100
+
+// Provider is a code hosting service repositories are synced from. It lists
+// the branches of a repository and their commits page by page, newest first,
+// fetches the details of single commits and lists the pull requests, issues,
+// releases, tags and workflow runs. Each client converts the responses of
+// its service to the types of the models package. It is implemented by the
+// GitHub REST and GraphQL clients, the GitLab client and the client of local
+// git repositories.
+type Provider interface {
+	FetchDefaultBranch(repositoryName string) (string, error)
+	FetchBranches(repositoryName string) ([]models.Branch, error)
+	FetchCommits(repositoryName string, branch string, since string, until string, perPage int32, cursor string) (models.CommitsPage, error)
+	FetchCommitDetails(repositoryName string, sha string) (models.Commit, error)
+	FetchPullRequests(repositoryName string, since string, cursor string) (models.PullRequestsPage, error)
+	FetchPullRequestCommits(repositoryName string, number int) ([]string, error)
+	FetchIssues(repositoryName string, since string, cursor string) (models.IssuesPage, error)
+	FetchReleases(repositoryName string, cursor string) (models.ReleasesPage, error)
+	FetchTags(repositoryName string, cursor string) (models.TagsPage, error)
+	FetchWorkflowRuns(repositoryName string, since string, cursor string) (models.WorkflowRunsPage, error)
+	RateLimit() githubrestclient.RateLimit
+	TokenUsage() []githubrestclient.TokenUsage
+}
 
 type CommentMonitorService struct {
-	GithubRestClient             githubrestclient.GithubRestClient
-	ReposMetaDataServiceClient   rmdsc.ReposMetaDataServiceClient
-	CommitsMetaDataServiceClient cmdsc.CommitsMetaDataServiceClient
-	Rabbit                       *amqp.Connection
+	Config                         *models.Config
+	Provider                       Provider
+	ReposMetaDataServiceClient     rmdsc.ReposMetaDataServiceClient
+	CommitsMetaDataServiceClient   cmdsc.CommitsMetaDataServiceClient
+	PullsMetaDataServiceClient     pmdsc.PullsMetaDataServiceClient
+	IssuesMetaDataServiceClient    imdsc.IssuesMetaDataServiceClient
+	ActionsMetaDataServiceClient   amdsc.ActionsMetaDataServiceClient
+	BackfillsMetaDataServiceClient bmdsc.BackfillsMetaDataServiceClient
+	Rabbit                         *amqp.Connection
+
+	backoff      *backoff
+	pool         *fetchPool
+	backfillPool *fetchPool
+	tracking     *trackingSettings
+	initialSyncs *initialSyncs
 }
 
 func NewCommentMonitorService(
-	githubRestClient githubrestclient.GithubRestClient,
+	config *models.Config,
+	provider Provider,
 	reposMetaDataServiceClient rmdsc.ReposMetaDataServiceClient,
 	commitsMetaDataServiceClient cmdsc.CommitsMetaDataServiceClient,
+	pullsMetaDataServiceClient pmdsc.PullsMetaDataServiceClient,
+	issuesMetaDataServiceClient imdsc.IssuesMetaDataServiceClient,
+	actionsMetaDataServiceClient amdsc.ActionsMetaDataServiceClient,
+	backfillsMetaDataServiceClient bmdsc.BackfillsMetaDataServiceClient,
 	rabbit *amqp.Connection,
 ) CommentMonitorService {
 	return CommentMonitorService{
-		GithubRestClient:             githubRestClient,
-		ReposMetaDataServiceClient:   reposMetaDataServiceClient,
-		CommitsMetaDataServiceClient: commitsMetaDataServiceClient,
-		Rabbit:                       rabbit,
+		Config:                         config,
+		Provider:                       provider,
+		ReposMetaDataServiceClient:     reposMetaDataServiceClient,
+		CommitsMetaDataServiceClient:   commitsMetaDataServiceClient,
+		PullsMetaDataServiceClient:     pullsMetaDataServiceClient,
+		IssuesMetaDataServiceClient:    issuesMetaDataServiceClient,
+		ActionsMetaDataServiceClient:   actionsMetaDataServiceClient,
+		BackfillsMetaDataServiceClient: backfillsMetaDataServiceClient,
+		Rabbit:                         rabbit,
+		backoff:                        &backoff{},
+		pool:                           newFetchPool(config.FetchWorkers, config.FetchWorkersPerOwner),
+		backfillPool:                   newBackfillPool(config),
+		tracking:                       &trackingSettings{},
+		initialSyncs:                   &initialSyncs{},
 	}
 }
 
+// ScheduleFetchingCommits looks for repositories due for polling every
+// interval and fetches them in a cycle. Cycles never overlap: one that takes
+// longer than the interval is followed right away by the next.
 func (sc *CommentMonitorService) ScheduleFetchingCommits(interval time.Duration) {
-	sc.fetchAndSaveCommits()
-	ticker := time.NewTicker(interval)
-	defer ticker.Stop()
-	for range ticker.C {
-		go sc.fetchAndSaveCommits()
+	for {
+		started := time.Now()
+		sc.fetchAndSaveCommits()
+		time.Sleep(interval - time.Since(started))
 	}
 }
 
 func (sc *CommentMonitorService) fetchAndSaveCommits() {
-	repositories, err := sc.ReposMetaDataServiceClient.GetRepositoryNames()
+	sc.waitForRateLimit()
+	sc.refreshTrackingSettings()
+
+	recentCommitsSince := time.Now().Add(-recentCommitsWindow).UTC().Format(constants.ISO_8601_TIME_LAYOUT)
+	schedules, err := sc.ReposMetaDataServiceClient.GetPollSchedules(recentCommitsSince)
 	if err != nil {
-		log.Println("CMOS: error getting repository Names")
+		log.Println("CMOS: error getting repository poll schedules")
 		log.Println("CMOS: err:", err)
 		return
 	}
-	log.Printf("CMOS: fetching commits of %d repositories started\n", len(repositories))
-	var wg sync.WaitGroup
-	for _, repo := range repositories {
-		wg.Add(1)
-		go func(repo string) {
-			defer wg.Done()
-			sc.fetchAndSaveCommitsForRepo(repo)
-		}(repo)
+	// paused repositories are left out
+	states := make([]pollState, 0, len(schedules))
+	for _, schedule := range schedules {
+		state := convertPollSchedule(schedule)
+		if !sc.settings(state.repository).enabled {
+			continue
+		}
+		states = append(states, state)
+	}
+	due := dueRepositories(states, time.Now())
+	if len(due) == 0 {
+		return
+	}
+
+	byName := make(map[string]pollState, len(due))
+	repositories := make([]string, len(due))
+	for i, state := range due {
+		byName[state.repository] = state
+		repositories[i] = state.repository
+	}
+
+	log.Printf("CMOS: fetching commits of %d/%d repositories due started on %d workers, %d per owner\n",
+		len(repositories), len(schedules), sc.pool.workers, sc.pool.perOwner)
+	fetched := sc.pool.run(repositories, func(repo string) {
+		total, ok := sc.fetchAndSaveCommitsForRepo(repo)
+		sc.reschedule(byName[repo], total, ok)
+	})
+	if !fetched {
+		log.Println("CMOS: previous fetch cycle still running, skipping")
+		return
+	}
+
+	report := sc.pool.snapshot()
+	log.Printf("CMOS: fetch cycle %d finished %d repositories in %s\n",
+		report.Cycle, report.Finished, report.FinishedAt.Sub(report.StartedAt).Round(time.Second))
+	budget := sc.Provider.RateLimit()
+	log.Printf("CMOS: fetching commits finished, rate limit %d/%d remaining, resets at %s\n",
+		budget.Remaining, budget.Limit, budget.Reset.Format(time.RFC3339))
+	for _, usage := range sc.Provider.TokenUsage() {
+		log.Printf("CMOS: token %s sent %d requests, %d/%d remaining\n",
+			usage.Token, usage.Requests, usage.RateLimit.Remaining, usage.RateLimit.Limit)
 	}
-	wg.Wait()
 }
 
-func (sc *CommentMonitorService) fetchAndSaveCommitsForRepo(repo string) {
-	commitFetchHistory, err := sc.CommitsMetaDataServiceClient.GetCommitFetchHistory(repo)
-	if err != nil {
-		log.Println("CMOS: error getting a repository last commit fetch history")
-		log.Println("CMOS: err:", err)
+// waitForRateLimit defers a cycle until the GitHub budget resets when the
+// previous cycle used it up or was rate limited.
+func (sc *CommentMonitorService) waitForRateLimit() {
+	until := sc.backoff.get()
+	if budget := sc.Provider.RateLimit(); budget.Exhausted() && budget.Reset.After(until) {
+		until = budget.Reset
+	}
+	wait := time.Until(until)
+	if wait <= 0 {
+		return
 	}
+	log.Printf("CMOS: rate limit exhausted, deferring commits fetch by %s\n", wait.Round(time.Second))
+	time.Sleep(wait)
+}
 
-	page := commitFetchHistory.LastPage + 1
+// fetchAndSaveCommitsForRepo syncs the tracked branches of a repository and
+// then its pull requests, issues, releases and workflow runs. It returns the
+// number of new commits pushed and whether the commits could be fetched.
+func (sc *CommentMonitorService) fetchAndSaveCommitsForRepo(repo string) (int, bool) {
+	if until := sc.backoff.get(); time.Now().Before(until) {
+		log.Printf("CMOS: skipping <%s>, rate limited until %s\n", repo, until.Format(time.RFC3339))
+		return 0, false
+	}
 
-	log.Printf("CMOS: fetching commits of <%s> started from page=> %d\n", repo, page)
+	defaultBranch, err := sc.Provider.FetchDefaultBranch(repo)
+	if err != nil {
+		sc.handleFetchError(repo, err)
+		return 0, false
+	}
+	branches, err := sc.Provider.FetchBranches(repo)
+	if err != nil {
+		sc.handleFetchError(repo, err)
+		return 0, false
+	}
+	if len(branches) == 0 {
+		sc.handleFetchError(repo, fmt.Errorf("CMOS: <%s> has no branches: %w", repo, githubrestclient.ErrEmptyRepository))
+		return 0, false
+	}
 
 	var totalCommitsFetched int
+	var modified bool
+	for _, branch := range sc.trackedBranches(repo, defaultBranch, branches) {
+		fetched, branchModified, err := sc.fetchAndSaveCommitsForBranch(repo, branch)
+		if err != nil && branch.Name != defaultBranch && errors.Is(err, githubrestclient.ErrNotFound) {
+			log.Printf("CMOS: branch %s of <%s> is gone\n", branch.Name, repo)
+			continue
+		}
+		if err != nil {
+			sc.handleFetchError(repo, err)
+			return 0, false
+		}
+		totalCommitsFetched += fetched
+		modified = modified || branchModified
+	}
+
+	if modified {
+		log.Printf("CMOS: repo <%s>  total commits: %d pulled\n", repo, totalCommitsFetched)
+		sc.recordOutcome(CommitsFetchOutcome{Repository: repo, Outcome: constants.FETCH_OUTCOME_OK, Total: totalCommitsFetched})
+	} else {
+		log.Printf("CMOS: commits of <%s> not modified\n", repo)
+		sc.recordOutcome(CommitsFetchOutcome{Repository: repo, Outcome: constants.FETCH_OUTCOME_NOT_MODIFIED})
+		totalCommitsFetched = 0
+	}
+
+	sc.enrichCommits(repo)
+	sc.fetchAndSavePullRequests(repo)
+	sc.fetchAndSaveIssues(repo)
+	sc.fetchAndSaveReleases(repo)
+	sc.fetchAndSaveWorkflowRuns(repo)
+	return totalCommitsFetched, true
+}
+
+// trackedBranches returns the default branch of a repository followed by the
+// other branches matching its patterns.
+func (sc *CommentMonitorService) trackedBranches(repo string, defaultBranch string, branches []models.Branch) []models.Branch {
+	patterns := sc.settings(repo).branches
+	var tracked []models.Branch
+	for _, branch := range branches {
+		switch {
+		case branch.Name == defaultBranch:
+			tracked = append([]models.Branch{branch}, tracked...)
+		case matchesAny(patterns, branch.Name):
+			tracked = append(tracked, branch)
+		}
+	}
+	return tracked
+}
+
+// matchesAny reports whether name matches one of the glob patterns. No
+// patterns match no name, so only the default branch is synced.
+func matchesAny(patterns []string, name string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, name); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchAndSaveCommitsForBranch pushes the commits of a branch newer than the
+// newest stored commit of that branch, or its history on the first sync. It
+// returns the number of commits pushed and whether GitHub reported any
+// change.
+func (sc *CommentMonitorService) fetchAndSaveCommitsForBranch(repo string, branch models.Branch) (int, bool, error) {
+	watermark, err := sc.CommitsMetaDataServiceClient.GetCommitWatermark(repo, branch.Name)
+	if err != nil {
+		return 0, false, fmt.Errorf("CMOS: getting the watermark of <%s> branch %s: %w", repo, branch.Name, err)
+	}
+	if watermark.LastCommitSha == "" {
+		since := sc.since(repo, "")
+		log.Printf("CMOS: syncing the history of <%s> branch %s since %s\n", repo, branch.Name, since)
+		fetched, err := sc.initialSyncBranch(repo, branch.Name, since)
+		return fetched, true, err
+	}
+	sc.initialSyncs.done(repo, branch.Name)
+	if watermark.LastCommitSha == branch.HeadSha {
+		return 0, false, nil
+	}
+
+	since := sc.since(repo, watermark.LastCommitDate)
+
+	log.Printf("CMOS: fetching commits of <%s> branch %s since %s\n", repo, branch.Name, since)
+
+	// Pages are buffered and published once the walk reached the watermark,
+	// so the stored watermark never moves past commits that were not fetched.
+	// Only the commits pushed since the last poll are buffered.
+	var pages [][]models.Commit
+	var modified bool
+	var cursor string
 
 	for {
-		commits, err := sc.GithubRestClient.FetchCommits(repo, perPage, page)
+		commitsPage, err := sc.Provider.FetchCommits(repo, branch.Name, since, sc.Config.EndDate, perPage, cursor)
 		if err != nil {
-			log.Println("CMOS: error fetching commits of ", repo)
-			log.Println("CMOS: err:", err)
-			return
+			return 0, false, err
 		}
 
-		if len(commits) == 0 {
+		commits, reachedWatermark := newCommits(commitsPage.Commits, watermark.LastCommitSha)
+		if len(commits) > 0 {
+			pages = append(pages, commits)
+		}
+		modified = modified || !commitsPage.NotModified
+
+		if reachedWatermark || commitsPage.Next == "" {
 			break
 		}
-        
-		log.Printf("CMOS: pulled %d commits %s \n", len(commits), repo)
-		
-		fetchTime := commits[len(commits)-1].Commit.Author.Date
-		sc.pushToQueue(repo, fetchTime, page, commits)
+		cursor = commitsPage.Next
+	}
 
+	if !modified {
+		return 0, false, nil
+	}
+
+	var totalCommitsFetched int
+	for i := len(pages) - 1; i >= 0; i-- {
+		commits := pages[i]
+		fetchTime := commits[0].Author.Date
+		if err := sc.pushToQueue(repo, branch.Name, fetchTime, commits); err != nil {
+			return totalCommitsFetched, true, fmt.Errorf("CMOS: pushing commits of <%s> branch %s: %w", repo, branch.Name, err)
+		}
 		totalCommitsFetched += len(commits)
-		page++
 	}
+	return totalCommitsFetched, true, nil
+}
+
+// handleFetchError records a failed fetch. Empty and deleted repositories
+// are marked by the commits manager, the latter are no longer listed for
+// polling; a rate limit holds back the rest of the cycle.
+func (sc *CommentMonitorService) handleFetchError(repo string, err error) {
+	outcome := fetchOutcome(err)
+	outcome.Repository = repo
+
+	switch outcome.Outcome {
+	case constants.FETCH_OUTCOME_EMPTY:
+		log.Printf("CMOS: repo <%s> is empty\n", repo)
+	case constants.FETCH_OUTCOME_NOT_FOUND:
+		log.Printf("CMOS: repo <%s> not found, it will no longer be polled\n", repo)
+	case constants.FETCH_OUTCOME_RATE_LIMITED:
+		until := retryAt(err, sc.Provider.RateLimit(), time.Now())
+		sc.backoff.set(until)
+		log.Printf("CMOS: rate limited fetching <%s>, backing off until %s\n", repo, until.Format(time.RFC3339))
+	default:
+		log.Println("CMOS: error fetching commits of ", repo)
+		log.Println("CMOS: err:", err)
+	}
+	sc.recordOutcome(outcome)
+}
+
+func (sc *CommentMonitorService) recordOutcome(outcome CommitsFetchOutcome) {
+	outcome.FetchTime = time.Now().UTC()
+	if err := sc.pushOutcomeToQueue(outcome); err != nil {
+		log.Println("CMOS: error pushing fetch outcome of ", outcome.Repository)
+		log.Println("CMOS: err:", err)
+	}
+}
 
-	log.Printf("CMOS: repo <%s>  total commits: %d pulled\n", repo, totalCommitsFetched)
+// since returns the date to list the commits of a repository from: the
+// author date of the newest stored commit, or the sync start date of the
+// repository on the first sync.
+func (sc *CommentMonitorService) since(repo string, lastCommitDate string) string {
+	since := sc.settings(repo).syncStartDate
+	watermark, err := time.Parse(constants.ISO_8601_TIME_LAYOUT, lastCommitDate)
+	if err != nil {
+		return since
+	}
+	startDate, err := time.Parse(constants.ISO_8601_TIME_LAYOUT, since)
+	if err != nil || watermark.After(startDate) {
+		return lastCommitDate
+	}
+	return since
+}
+
+// newCommits returns the commits listed before the already stored commit
+// with the given SHA, and whether that commit was found.
+func newCommits(commits []models.Commit, lastCommitSha string) ([]models.Commit, bool) {
+	if lastCommitSha == "" {
+		return commits, false
+	}
+	for i, commit := range commits {
+		if commit.Sha == lastCommitSha {
+			return commits[:i], true
+		}
+	}
+	return commits, false
 }
 
-// pushToQueue pushes a message into RabbitMQ
-func (sc *CommentMonitorService) pushToQueue(repoName string, fetchTime time.Time, lastPage int32, commits []models.CommitResponse) error {
+// pushToQueue pushes a message into RabbitMQ. The commits of bots are pushed
+// as well; the commits manager leaves them out of the repositories excluding
+// them.
+func (sc *CommentMonitorService) pushToQueue(repoName string, branch string, fetchTime time.Time, commits []models.Commit) error {
 	emitter, err := event.NewEventEmitter(sc.Rabbit)
 	if err != nil {
 		return err
 	}
 
+	owner, _, _ := strings.Cut(repoName, "/")
 	j, err := json.MarshalIndent(&event.Payload{
 		Name: "commits",
 		Data: CommitMetaData{
+			Owner:      owner,
 			Repository: repoName,
+			Branch:     branch,
 			FetchTime:  fetchTime,
-			Commits:    commits,
-			LastPage:   int(lastPage),
+			Commits:    commitMessages(commits),
 		},
 	}, "", "\t")
 	if err != nil {
@@ -131,8 +416,10 @@ func (sc *CommentMonitorService) pushToQueue(repoName string, fetchTime time.Tim
 }
 
 type CommitMetaData struct {
-	LastPage   int
+	Owner string
+	// Repository is the full name (owner/name) of the repository.
 	Repository string
+	Branch     string
 	FetchTime  time.Time
 	Commits    []models.CommitResponse
 }
@@ -0,0 +1,144 @@
+package commitsmonitorservice
+
+import (
+	"commits-monitor-service/internal/constants"
+	"commits-monitor-service/internal/constants/models"
+	"commits-monitor-service/internal/message-broker/rabbitmq"
+	"commits-monitor-service/internal/pkg/githubrestclient"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+)
+
+const (
+	// maxEnrichedCommits caps the commit details fetched per repository and
+	// cycle, so a large backlog is worked off over several cycles.
+	maxEnrichedCommits = 100
+
+	// commitDetailsBatchSize is the number of commit details per message.
+	commitDetailsBatchSize = 10
+)
+
+// enrichCommits fetches the stats and changed files of the stored commits of
+// a repository that have none yet. Commits pushed in this cycle are usually
+// picked up by the next one, once the commits manager stored them. Commits
+// whose details cannot be fetched for good are reported as failures, so they
+// are not asked for again.
+func (sc *CommentMonitorService) enrichCommits(repo string) {
+	shas, err := sc.CommitsMetaDataServiceClient.GetCommitsWithoutStats(repo, maxEnrichedCommits)
+	if err != nil {
+		log.Println("CMOS: error getting commits without stats of ", repo)
+		log.Println("CMOS: err:", err)
+		return
+	}
+	if len(shas) == 0 {
+		return
+	}
+
+	var batch []models.Commit
+	var failures []CommitDetailsFailure
+	var enriched int
+	for _, sha := range shas {
+		if time.Now().Before(sc.backoff.get()) {
+			break
+		}
+
+		commit, err := sc.Provider.FetchCommitDetails(repo, sha)
+		if errors.Is(err, githubrestclient.ErrRateLimited) {
+			until := retryAt(err, sc.Provider.RateLimit(), time.Now())
+			sc.backoff.set(until)
+			log.Printf("CMOS: rate limited fetching commit details of <%s>, backing off until %s\n", repo, until.Format(time.RFC3339))
+			break
+		}
+		if err != nil {
+			log.Printf("CMOS: error fetching details of commit %s of <%s>\n", sha, repo)
+			log.Println("CMOS: err:", err)
+			if failure, ok := commitDetailsFailure(sha, err); ok {
+				failures = append(failures, failure)
+			}
+			continue
+		}
+
+		batch = append(batch, commit)
+		if len(batch) == commitDetailsBatchSize {
+			if err := sc.pushCommitDetailsToQueue(repo, batch, failures); err != nil {
+				log.Println("CMOS: error pushing commit details of ", repo)
+				log.Println("CMOS: err:", err)
+				return
+			}
+			enriched += len(batch)
+			batch, failures = nil, nil
+		}
+	}
+
+	if len(batch) > 0 || len(failures) > 0 {
+		if err := sc.pushCommitDetailsToQueue(repo, batch, failures); err != nil {
+			log.Println("CMOS: error pushing commit details of ", repo)
+			log.Println("CMOS: err:", err)
+			return
+		}
+		enriched += len(batch)
+	}
+
+	log.Printf("CMOS: repo <%s> details of %d/%d commits pulled\n", repo, enriched, len(shas))
+}
+
+// commitDetailsFailure tells whether fetching the details of a commit failed
+// for good: the commit is unknown (404), e.g. as it was force pushed away, or
+// cannot be processed (422). Other errors are retried in the next cycle.
+func commitDetailsFailure(sha string, err error) (CommitDetailsFailure, bool) {
+	failure := CommitDetailsFailure{Sha: sha, Message: err.Error()}
+	var apiErr *githubrestclient.APIError
+	if errors.As(err, &apiErr) {
+		failure.StatusCode = apiErr.StatusCode
+		failure.Message = apiErr.Message
+	}
+	switch {
+	case errors.Is(err, githubrestclient.ErrNotFound):
+		if failure.StatusCode == 0 {
+			failure.StatusCode = 404
+		}
+		return failure, true
+	case failure.StatusCode == 422:
+		return failure, true
+	}
+	return CommitDetailsFailure{}, false
+}
+
+// pushCommitDetailsToQueue publishes single commits carrying stats
+// and changed files, and the commits whose details cannot be fetched.
+func (sc *CommentMonitorService) pushCommitDetailsToQueue(repoName string, commits []models.Commit, failures []CommitDetailsFailure) error {
+	emitter, err := event.NewEventEmitter(sc.Rabbit)
+	if err != nil {
+		return err
+	}
+
+	j, err := json.MarshalIndent(&event.Payload{
+		Name: "commit-details",
+		Data: CommitDetailsMetaData{
+			Repository: repoName,
+			Commits:    commitMessages(commits),
+			Failures:   failures,
+		},
+	}, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return emitter.Push(string(j), constants.COMMITS_EVENT)
+}
+
+type CommitDetailsMetaData struct {
+	// Repository is the full name (owner/name) of the repository.
+	Repository string
+	Commits    []models.CommitResponse
+	Failures   []CommitDetailsFailure
+}
+
+// CommitDetailsFailure is a commit whose details fetch failed for good.
+type CommitDetailsFailure struct {
+	Sha        string
+	StatusCode int
+	Message    string
+}
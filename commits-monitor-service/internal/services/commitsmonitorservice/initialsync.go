@@ -0,0 +1,153 @@
+package commitsmonitorservice
+
+import (
+	"commits-monitor-service/internal/constants"
+	"commits-monitor-service/internal/message-broker/rabbitmq"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// branchLinksBatchSize is the number of commits linked to their branch per
+// message at the end of an initial sync.
+const branchLinksBatchSize = 1000
+
+// initialSync is the progress of the first sync of a branch, which lists its
+// whole history since the sync start date. Commits are listed newest first,
+// so they are published without their branch as they are fetched and only
+// linked to it, oldest first, once the history was listed: linking moves the
+// watermark regular polling resumes from, which must not pass commits that
+// were not fetched.
+type initialSync struct {
+	mu sync.Mutex
+	// since is the date the history is listed from; a change of the sync
+	// start date starts the sync over.
+	since string
+	// cursor is the page the listing resumes from.
+	cursor string
+	// listed is set once the whole history was listed.
+	listed bool
+	// shas are the commits published but not linked yet, newest first.
+	shas []string
+}
+
+// initialSyncs are the first syncs of branches left unfinished by an error
+// or a rate limit, keyed by repository and branch, so the next poll resumes
+// them instead of listing the history again.
+type initialSyncs struct {
+	mu       sync.Mutex
+	byBranch map[string]*initialSync
+}
+
+// get returns the unfinished first sync of a branch listing its history since
+// the given date, or starts a new one.
+func (s *initialSyncs) get(repo string, branch string, since string) *initialSync {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	key := repo + "\x00" + branch
+	if is, ok := s.byBranch[key]; ok && is.since == since {
+		return is
+	}
+	if s.byBranch == nil {
+		s.byBranch = map[string]*initialSync{}
+	}
+	is := &initialSync{since: since}
+	s.byBranch[key] = is
+	return is
+}
+
+// done forgets the first sync of a branch.
+func (s *initialSyncs) done(repo string, branch string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.byBranch, repo+"\x00"+branch)
+}
+
+// nextLinks returns the oldest commits of the sync still to be linked, at
+// most n of them.
+func (is *initialSync) nextLinks(n int) []string {
+	from := max(len(is.shas)-n, 0)
+	links := make([]string, 0, len(is.shas)-from)
+	for i := len(is.shas) - 1; i >= from; i-- {
+		links = append(links, is.shas[i])
+	}
+	return links
+}
+
+// initialSyncBranch lists the history of a branch without stored commits,
+// resuming an unfinished first sync. It returns the number of commits
+// pushed.
+func (sc *CommentMonitorService) initialSyncBranch(repo string, branch string, since string) (int, error) {
+	is := sc.initialSyncs.get(repo, branch, since)
+	is.mu.Lock()
+	defer is.mu.Unlock()
+
+	var total int
+	for !is.listed {
+		commitsPage, err := sc.Provider.FetchCommits(repo, branch, since, sc.Config.EndDate, perPage, is.cursor)
+		if err != nil {
+			return total, err
+		}
+
+		if len(commitsPage.Commits) > 0 {
+			if err := sc.pushToQueue(repo, "", commitsPage.Commits[0].Author.Date, commitsPage.Commits); err != nil {
+				return total, fmt.Errorf("CMOS: pushing commits of <%s> branch %s: %w", repo, branch, err)
+			}
+			for _, commit := range commitsPage.Commits {
+				is.shas = append(is.shas, commit.Sha)
+			}
+			total += len(commitsPage.Commits)
+		}
+
+		is.cursor = commitsPage.Next
+		is.listed = commitsPage.Next == ""
+	}
+
+	for len(is.shas) > 0 {
+		links := is.nextLinks(branchLinksBatchSize)
+		if err := sc.pushBranchLinksToQueue(repo, branch, links); err != nil {
+			return total, fmt.Errorf("CMOS: linking commits of <%s> branch %s: %w", repo, branch, err)
+		}
+		is.shas = is.shas[:len(is.shas)-len(links)]
+	}
+
+	sc.initialSyncs.done(repo, branch)
+	log.Printf("CMOS: history of <%s> branch %s synced\n", repo, branch)
+	return total, nil
+}
+
+// pushBranchLinksToQueue publishes that stored commits are reachable from a
+// branch.
+func (sc *CommentMonitorService) pushBranchLinksToQueue(repoName string, branch string, shas []string) error {
+	emitter, err := event.NewEventEmitter(sc.Rabbit)
+	if err != nil {
+		return err
+	}
+
+	j, err := json.MarshalIndent(&event.Payload{
+		Name: "commit-branches",
+		Data: BranchLinksMetaData{
+			Repository: repoName,
+			Branch:     branch,
+			FetchTime:  time.Now().UTC(),
+			Shas:       shas,
+		},
+	}, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return emitter.Push(string(j), constants.COMMITS_EVENT)
+}
+
+// BranchLinksMetaData are stored commits of a repository reachable from a
+// branch.
+type BranchLinksMetaData struct {
+	// Repository is the full name (owner/name) of the repository.
+	Repository string
+	Branch     string
+	FetchTime  time.Time
+	Shas       []string
+}
@@ -0,0 +1,32 @@
+package commitsmonitorservice
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInitialSyncs(t *testing.T) {
+	syncs := &initialSyncs{}
+
+	is := syncs.get("acme/api", "main", "2024-01-01T00:00:00Z")
+	is.cursor = "3"
+	is.shas = []string{"e", "d", "c", "b", "a"}
+
+	// an unfinished sync is resumed
+	require.Same(t, is, syncs.get("acme/api", "main", "2024-01-01T00:00:00Z"))
+	require.NotSame(t, is, syncs.get("acme/api", "release/1", "2024-01-01T00:00:00Z"))
+
+	// oldest commits are linked first
+	require.Equal(t, []string{"a", "b"}, is.nextLinks(2))
+	require.Equal(t, []string{"a", "b", "c", "d", "e"}, is.nextLinks(10))
+
+	// a new start date starts over
+	restarted := syncs.get("acme/api", "main", "2023-01-01T00:00:00Z")
+	require.NotSame(t, is, restarted)
+	require.Empty(t, restarted.cursor)
+	require.Empty(t, restarted.shas)
+
+	syncs.done("acme/api", "main")
+	require.NotSame(t, restarted, syncs.get("acme/api", "main", "2023-01-01T00:00:00Z"))
+}
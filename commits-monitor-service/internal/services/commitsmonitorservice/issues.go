@@ -0,0 +1,87 @@
+package commitsmonitorservice
+
+import (
+	"commits-monitor-service/internal/constants"
+	"commits-monitor-service/internal/constants/models"
+	"commits-monitor-service/internal/message-broker/rabbitmq"
+	"commits-monitor-service/internal/pkg/githubrestclient"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+)
+
+// fetchAndSaveIssues pushes the issues of a repository updated since the
+// most recently updated stored one, or since the configured start date on
+// the first sync. Issues are listed least recently updated first, so every
+// page is published as soon as it is fetched.
+func (sc *CommentMonitorService) fetchAndSaveIssues(repo string) {
+	lastUpdatedAt, err := sc.IssuesMetaDataServiceClient.GetIssuesWatermark(repo)
+	if err != nil {
+		log.Println("CMOS: error getting a repository issues watermark")
+		log.Println("CMOS: err:", err)
+		return
+	}
+	since := sc.since(repo, lastUpdatedAt)
+
+	var total int
+	var cursor string
+	for {
+		page, err := sc.Provider.FetchIssues(repo, since, cursor)
+		if errors.Is(err, githubrestclient.ErrRateLimited) {
+			until := retryAt(err, sc.Provider.RateLimit(), time.Now())
+			sc.backoff.set(until)
+			log.Printf("CMOS: rate limited fetching issues of <%s>, backing off until %s\n", repo, until.Format(time.RFC3339))
+			return
+		}
+		if err != nil {
+			log.Println("CMOS: error fetching issues of ", repo)
+			log.Println("CMOS: err:", err)
+			return
+		}
+
+		if len(page.Issues) > 0 {
+			if err := sc.pushIssuesToQueue(repo, page.Issues); err != nil {
+				log.Println("CMOS: error pushing issues of ", repo)
+				log.Println("CMOS: err:", err)
+				return
+			}
+			total += len(page.Issues)
+		}
+
+		if page.Next == "" {
+			break
+		}
+		cursor = page.Next
+	}
+
+	log.Printf("CMOS: repo <%s> %d updated issues pulled\n", repo, total)
+}
+
+func (sc *CommentMonitorService) pushIssuesToQueue(repoName string, issues []models.Issue) error {
+	emitter, err := event.NewEventEmitter(sc.Rabbit)
+	if err != nil {
+		return err
+	}
+
+	j, err := json.MarshalIndent(&event.Payload{
+		Name: "issues",
+		Data: IssuesMetaData{
+			Repository: repoName,
+			FetchTime:  time.Now().UTC(),
+			Issues:     issueMessages(issues),
+		},
+	}, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return emitter.Push(string(j), constants.ISSUES_EVENT)
+}
+
+type IssuesMetaData struct {
+	// Repository is the full name (owner/name) of the repository.
+	Repository string
+	FetchTime  time.Time
+	Issues     []models.IssueResponse
+}
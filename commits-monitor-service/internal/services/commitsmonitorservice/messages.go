@@ -0,0 +1,176 @@
+package commitsmonitorservice
+
+import "commits-monitor-service/internal/constants/models"
+
+// The functions below map the types of the provider interface to the shape
+// of the GitHub responses the commits manager reads from the queues, the
+// same whatever provider the repository is synced from.
+
+func commitMessages(commits []models.Commit) []models.CommitResponse {
+	messages := make([]models.CommitResponse, 0, len(commits))
+	for _, commit := range commits {
+		messages = append(messages, commitMessage(commit))
+	}
+	return messages
+}
+
+func commitMessage(commit models.Commit) models.CommitResponse {
+	var message models.CommitResponse
+	message.Sha = commit.Sha
+	message.URL = commit.URL
+	message.HTMLURL = commit.HTMLURL
+	message.Commit.Message = commit.Message
+	message.Commit.Author.Name = commit.Author.Name
+	message.Commit.Author.Email = commit.Author.Email
+	message.Commit.Author.Date = commit.Author.Date
+	message.Commit.Committer.Name = commit.Committer.Name
+	message.Commit.Committer.Email = commit.Committer.Email
+	message.Commit.Committer.Date = commit.Committer.Date
+	message.Author.Login = commit.Author.Login
+	message.Author.Type = commit.Author.Type
+	message.Committer.Login = commit.Committer.Login
+	message.Committer.Type = commit.Committer.Type
+
+	message.Parents = make([]struct {
+		Sha     string `json:"sha"`
+		URL     string `json:"url"`
+		HTMLURL string `json:"html_url"`
+	}, len(commit.Parents))
+	for i, parent := range commit.Parents {
+		message.Parents[i].Sha = parent
+	}
+
+	if commit.Stats != nil {
+		message.Stats = &struct {
+			Total     int `json:"total"`
+			Additions int `json:"additions"`
+			Deletions int `json:"deletions"`
+		}{Total: commit.Stats.Total, Additions: commit.Stats.Additions, Deletions: commit.Stats.Deletions}
+	}
+	if len(commit.Files) > 0 {
+		message.Files = make([]struct {
+			Sha              string `json:"sha"`
+			Filename         string `json:"filename"`
+			Status           string `json:"status"`
+			Additions        int    `json:"additions"`
+			Deletions        int    `json:"deletions"`
+			Changes          int    `json:"changes"`
+			PreviousFilename string `json:"previous_filename,omitempty"`
+		}, len(commit.Files))
+		for i, file := range commit.Files {
+			message.Files[i].Sha = file.Sha
+			message.Files[i].Filename = file.Filename
+			message.Files[i].Status = file.Status
+			message.Files[i].Additions = file.Additions
+			message.Files[i].Deletions = file.Deletions
+			message.Files[i].Changes = file.Changes
+			message.Files[i].PreviousFilename = file.PreviousFilename
+		}
+	}
+	return message
+}
+
+func pullRequestMessage(pullRequest models.PullRequest) models.PullRequestResponse {
+	message := models.PullRequestResponse{
+		Number:         pullRequest.Number,
+		Title:          pullRequest.Title,
+		State:          pullRequest.State,
+		Draft:          pullRequest.Draft,
+		HTMLURL:        pullRequest.HTMLURL,
+		CreatedAt:      pullRequest.CreatedAt,
+		UpdatedAt:      pullRequest.UpdatedAt,
+		ClosedAt:       pullRequest.ClosedAt,
+		MergedAt:       pullRequest.MergedAt,
+		MergeCommitSha: pullRequest.MergeCommitSha,
+	}
+	message.User.Login = pullRequest.Author
+	message.Head.Ref = pullRequest.HeadRef
+	message.Head.Sha = pullRequest.HeadSha
+	message.Base.Ref = pullRequest.BaseRef
+	message.Base.Sha = pullRequest.BaseSha
+	return message
+}
+
+func issueMessages(issues []models.Issue) []models.IssueResponse {
+	messages := make([]models.IssueResponse, 0, len(issues))
+	for _, issue := range issues {
+		message := models.IssueResponse{
+			Number:    issue.Number,
+			Title:     issue.Title,
+			State:     issue.State,
+			Comments:  issue.Comments,
+			HTMLURL:   issue.HTMLURL,
+			CreatedAt: issue.CreatedAt,
+			UpdatedAt: issue.UpdatedAt,
+			ClosedAt:  issue.ClosedAt,
+		}
+		message.User.Login = issue.Author
+		message.Labels = make([]struct {
+			Name string `json:"name"`
+		}, len(issue.Labels))
+		for i, label := range issue.Labels {
+			message.Labels[i].Name = label
+		}
+		message.Assignees = make([]struct {
+			Login string `json:"login"`
+		}, len(issue.Assignees))
+		for i, assignee := range issue.Assignees {
+			message.Assignees[i].Login = assignee
+		}
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+func releaseMessages(releases []models.Release) []models.ReleaseResponse {
+	messages := make([]models.ReleaseResponse, 0, len(releases))
+	for _, release := range releases {
+		message := models.ReleaseResponse{
+			ID:              release.ID,
+			TagName:         release.TagName,
+			Name:            release.Name,
+			TargetCommitish: release.TargetCommitish,
+			Draft:           release.Draft,
+			Prerelease:      release.Prerelease,
+			HTMLURL:         release.HTMLURL,
+			CreatedAt:       release.CreatedAt,
+			PublishedAt:     release.PublishedAt,
+		}
+		message.Author.Login = release.Author
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+func tagMessages(tags []models.Tag) []models.TagResponse {
+	messages := make([]models.TagResponse, 0, len(tags))
+	for _, tag := range tags {
+		var message models.TagResponse
+		message.Name = tag.Name
+		message.Commit.Sha = tag.Sha
+		messages = append(messages, message)
+	}
+	return messages
+}
+
+func workflowRunMessages(runs []models.WorkflowRun) []models.WorkflowRunResponse {
+	messages := make([]models.WorkflowRunResponse, 0, len(runs))
+	for _, run := range runs {
+		messages = append(messages, models.WorkflowRunResponse{
+			ID:           run.ID,
+			Name:         run.Name,
+			WorkflowID:   run.WorkflowID,
+			HeadBranch:   run.HeadBranch,
+			HeadSha:      run.HeadSha,
+			Event:        run.Event,
+			Status:       run.Status,
+			Conclusion:   run.Conclusion,
+			RunAttempt:   run.RunAttempt,
+			HTMLURL:      run.HTMLURL,
+			CreatedAt:    run.CreatedAt,
+			UpdatedAt:    run.UpdatedAt,
+			RunStartedAt: run.RunStartedAt,
+		})
+	}
+	return messages
+}
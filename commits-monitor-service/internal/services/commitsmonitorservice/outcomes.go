@@ -0,0 +1,100 @@
+package commitsmonitorservice
+
+import (
+	"commits-monitor-service/internal/constants"
+	"commits-monitor-service/internal/message-broker/rabbitmq"
+	"commits-monitor-service/internal/pkg/githubrestclient"
+	"encoding/json"
+	"errors"
+	"sync"
+	"time"
+)
+
+// rateLimitBackoff is how long fetching pauses after a rate limited request
+// when GitHub did not say when to retry.
+const rateLimitBackoff = time.Minute
+
+// CommitsFetchOutcome is how fetching the commits of a repository went. The
+// commits manager records it and marks empty and deleted repositories.
+type CommitsFetchOutcome struct {
+	Repository string
+	Outcome    string
+	StatusCode int
+	Message    string
+	Total      int
+	FetchTime  time.Time
+}
+
+// fetchOutcome classifies the error of a failed fetch.
+func fetchOutcome(err error) CommitsFetchOutcome {
+	outcome := CommitsFetchOutcome{Outcome: constants.FETCH_OUTCOME_ERROR, Message: err.Error()}
+	var apiErr *githubrestclient.APIError
+	if errors.As(err, &apiErr) {
+		outcome.StatusCode = apiErr.StatusCode
+	}
+
+	switch {
+	case errors.Is(err, githubrestclient.ErrEmptyRepository):
+		outcome.Outcome = constants.FETCH_OUTCOME_EMPTY
+	case errors.Is(err, githubrestclient.ErrNotFound):
+		outcome.Outcome = constants.FETCH_OUTCOME_NOT_FOUND
+	case errors.Is(err, githubrestclient.ErrRateLimited):
+		outcome.Outcome = constants.FETCH_OUTCOME_RATE_LIMITED
+	case errors.Is(err, githubrestclient.ErrUnauthorized):
+		outcome.Outcome = constants.FETCH_OUTCOME_UNAUTHORIZED
+	case errors.Is(err, githubrestclient.ErrServer):
+		outcome.Outcome = constants.FETCH_OUTCOME_SERVER_ERROR
+	}
+	return outcome
+}
+
+// retryAt returns when a rate limited fetch may be retried.
+func retryAt(err error, budget githubrestclient.RateLimit, now time.Time) time.Time {
+	var apiErr *githubrestclient.APIError
+	if errors.As(err, &apiErr) && apiErr.RetryAt.After(now) {
+		return apiErr.RetryAt
+	}
+	if budget.Exhausted() && budget.Reset.After(now) {
+		return budget.Reset
+	}
+	return now.Add(rateLimitBackoff)
+}
+
+// backoff holds fetching back after GitHub rate limited a request.
+type backoff struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+func (b *backoff) set(until time.Time) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if until.After(b.until) {
+		b.until = until
+	}
+}
+
+func (b *backoff) get() time.Time {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.until
+}
+
+// pushOutcomeToQueue publishes the outcome of fetching the commits of a
+// repository.
+func (sc *CommentMonitorService) pushOutcomeToQueue(outcome CommitsFetchOutcome) error {
+	emitter, err := event.NewEventEmitter(sc.Rabbit)
+	if err != nil {
+		return err
+	}
+
+	j, err := json.MarshalIndent(&event.Payload{
+		Name: "commits-outcome",
+		Data: outcome,
+	}, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return emitter.Push(string(j), constants.COMMITS_EVENT)
+}
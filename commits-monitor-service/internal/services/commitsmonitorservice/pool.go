@@ -0,0 +1,180 @@
+package commitsmonitorservice
+
+import (
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Worker counts used when none are configured.
+const (
+	defaultFetchWorkers         = 8
+	defaultFetchWorkersPerOwner = 4
+)
+
+// reportInterval is how often a running cycle logs its progress.
+const reportInterval = time.Minute
+
+// fetchPool runs the repositories of a fetch cycle on a bounded number of
+// workers, with at most perOwner repositories of the same owner at once so a
+// large organization neither hogs the workers nor trips the secondary rate
+// limits of its server. Repositories are started in the order given, skipping
+// over those whose owner is at its cap. A pool runs one cycle at a time.
+type fetchPool struct {
+	workers  int
+	perOwner int
+
+	cycle sync.Mutex
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	report FetchReport
+	queued []string
+	owners map[string]int
+	// inProgress holds when each running repository was started.
+	inProgress map[string]time.Time
+}
+
+// FetchReport is the progress of a fetch cycle.
+type FetchReport struct {
+	Cycle      int
+	StartedAt  time.Time
+	FinishedAt time.Time
+	Queued     int
+	InProgress []string
+	Finished   int
+}
+
+func newFetchPool(workers int, perOwner int) *fetchPool {
+	if workers <= 0 {
+		workers = defaultFetchWorkers
+	}
+	if perOwner <= 0 {
+		perOwner = defaultFetchWorkersPerOwner
+	}
+	pool := &fetchPool{workers: workers, perOwner: min(perOwner, workers)}
+	pool.cond = sync.NewCond(&pool.mu)
+	return pool
+}
+
+// run calls fetch for every repository and returns once all are done. It
+// returns false without doing anything while the previous cycle still runs.
+// Each pool has a single caller running its cycles one after the other, the
+// polling loop for the fetch pool and the backfill loop for the backfill
+// pool, so this does not happen in the service; the check keeps any other
+// caller from resetting the queue and report of a running cycle.
+func (p *fetchPool) run(repos []string, fetch func(repo string)) bool {
+	if !p.cycle.TryLock() {
+		return false
+	}
+	defer p.cycle.Unlock()
+
+	p.mu.Lock()
+	p.report = FetchReport{Cycle: p.report.Cycle + 1, StartedAt: time.Now(), Queued: len(repos)}
+	p.queued = append([]string(nil), repos...)
+	p.owners = map[string]int{}
+	p.inProgress = map[string]time.Time{}
+	p.mu.Unlock()
+
+	done := make(chan struct{})
+	go p.logProgress(done)
+
+	var wg sync.WaitGroup
+	for i := 0; i < min(p.workers, len(repos)); i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				repo, ok := p.next()
+				if !ok {
+					return
+				}
+				fetch(repo)
+				p.done(repo)
+			}
+		}()
+	}
+	wg.Wait()
+	close(done)
+
+	p.mu.Lock()
+	p.report.FinishedAt = time.Now()
+	p.mu.Unlock()
+	return true
+}
+
+// next takes the first queued repository whose owner is below its cap,
+// waiting for a running one to finish when there is none. It returns false
+// once the queue is empty.
+func (p *fetchPool) next() (string, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for len(p.queued) > 0 {
+		for i, repo := range p.queued {
+			owner := repoOwner(repo)
+			if p.owners[owner] >= p.perOwner {
+				continue
+			}
+			p.queued = append(p.queued[:i], p.queued[i+1:]...)
+			p.owners[owner]++
+			p.inProgress[repo] = time.Now()
+			p.report.Queued--
+			return repo, true
+		}
+		p.cond.Wait()
+	}
+	return "", false
+}
+
+func (p *fetchPool) done(repo string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.owners[repoOwner(repo)]--
+	delete(p.inProgress, repo)
+	p.report.Finished++
+	p.cond.Broadcast()
+}
+
+// snapshot returns the progress of the running cycle, or of the last one once
+// it finished.
+func (p *fetchPool) snapshot() FetchReport {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	report := p.report
+	report.InProgress = make([]string, 0, len(p.inProgress))
+	for repo := range p.inProgress {
+		report.InProgress = append(report.InProgress, repo)
+	}
+	sort.Slice(report.InProgress, func(i, j int) bool {
+		return p.inProgress[report.InProgress[i]].Before(p.inProgress[report.InProgress[j]])
+	})
+	return report
+}
+
+// logProgress logs the progress of the cycle until done is closed.
+func (p *fetchPool) logProgress(done <-chan struct{}) {
+	ticker := time.NewTicker(reportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+			report := p.snapshot()
+			log.Printf("CMOS: cycle %d: %d queued, %d in progress, %d finished, running for %s\n",
+				report.Cycle, report.Queued, len(report.InProgress), report.Finished,
+				time.Since(report.StartedAt).Round(time.Second))
+			if len(report.InProgress) > 0 {
+				log.Printf("CMOS: cycle %d in progress: %s\n", report.Cycle, strings.Join(report.InProgress, ", "))
+			}
+		}
+	}
+}
+
+// repoOwner returns the lower cased owner of a repository full name.
+func repoOwner(repo string) string {
+	owner, _, _ := strings.Cut(repo, "/")
+	return strings.ToLower(owner)
+}
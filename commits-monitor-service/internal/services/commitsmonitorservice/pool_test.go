@@ -0,0 +1,204 @@
+package commitsmonitorservice
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// gatedFetch is a fetch function whose calls block until released one by
+// one, reporting the repositories as they are started.
+type gatedFetch struct {
+	started chan string
+
+	mu       sync.Mutex
+	release  map[string]chan struct{}
+	running  int
+	maxTotal int
+	maxOwner map[string]int
+	owners   map[string]int
+}
+
+func newGatedFetch(repos []string) *gatedFetch {
+	g := &gatedFetch{
+		started:  make(chan string, len(repos)),
+		release:  map[string]chan struct{}{},
+		maxOwner: map[string]int{},
+		owners:   map[string]int{},
+	}
+	for _, repo := range repos {
+		g.release[repo] = make(chan struct{})
+	}
+	return g
+}
+
+func (g *gatedFetch) fetch(repo string) {
+	owner := repoOwner(repo)
+	g.mu.Lock()
+	g.running++
+	g.owners[owner]++
+	g.maxTotal = max(g.maxTotal, g.running)
+	g.maxOwner[owner] = max(g.maxOwner[owner], g.owners[owner])
+	g.mu.Unlock()
+
+	g.started <- repo
+	<-g.release[repo]
+
+	g.mu.Lock()
+	g.running--
+	g.owners[owner]--
+	g.mu.Unlock()
+}
+
+// next returns the next started repository.
+func (g *gatedFetch) next(t *testing.T) string {
+	select {
+	case repo := <-g.started:
+		return repo
+	case <-time.After(5 * time.Second):
+		t.Fatal("no repository started")
+		return ""
+	}
+}
+
+// nextN returns the next n started repositories, in any order.
+func (g *gatedFetch) nextN(t *testing.T, n int) []string {
+	repos := make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		repos = append(repos, g.next(t))
+	}
+	return repos
+}
+
+// idle fails when a repository is started.
+func (g *gatedFetch) idle(t *testing.T) {
+	select {
+	case repo := <-g.started:
+		t.Fatalf("%s started", repo)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+// runPool runs the pool in the background, sending the result of run on the
+// returned channel.
+func runPool(p *fetchPool, repos []string, fetch func(repo string)) chan bool {
+	finished := make(chan bool, 1)
+	go func() {
+		finished <- p.run(repos, fetch)
+	}()
+	return finished
+}
+
+func TestFetchPoolLimitsWorkers(t *testing.T) {
+	repos := []string{"a/1", "b/1", "c/1", "d/1", "e/1"}
+	pool := newFetchPool(3, 3)
+	fetch := newGatedFetch(repos)
+	finished := runPool(pool, repos, fetch.fetch)
+
+	require.ElementsMatch(t, []string{"a/1", "b/1", "c/1"}, fetch.nextN(t, 3))
+	fetch.idle(t)
+	report := pool.snapshot()
+	require.Equal(t, 2, report.Queued)
+	require.ElementsMatch(t, []string{"a/1", "b/1", "c/1"}, report.InProgress)
+
+	close(fetch.release["b/1"])
+	require.Equal(t, "d/1", fetch.next(t))
+	fetch.idle(t)
+
+	for _, repo := range []string{"a/1", "c/1", "d/1", "e/1"} {
+		close(fetch.release[repo])
+	}
+	require.Equal(t, "e/1", fetch.next(t))
+	require.True(t, <-finished)
+	require.Equal(t, 3, fetch.maxTotal)
+
+	report = pool.snapshot()
+	require.Equal(t, 1, report.Cycle)
+	require.Equal(t, 5, report.Finished)
+	require.Zero(t, report.Queued)
+	require.Empty(t, report.InProgress)
+	require.False(t, report.FinishedAt.Before(report.StartedAt))
+}
+
+func TestFetchPoolLimitsWorkersPerOwner(t *testing.T) {
+	repos := []string{"big/1", "Big/2", "big/3", "big/4", "small/1"}
+	pool := newFetchPool(4, 2)
+	fetch := newGatedFetch(repos)
+	finished := runPool(pool, repos, fetch.fetch)
+
+	// owners are compared case insensitively
+	require.ElementsMatch(t, []string{"big/1", "Big/2", "small/1"}, fetch.nextN(t, 3))
+	fetch.idle(t)
+
+	close(fetch.release["small/1"])
+	fetch.idle(t)
+
+	close(fetch.release["Big/2"])
+	require.Equal(t, "big/3", fetch.next(t))
+	fetch.idle(t)
+
+	for _, repo := range []string{"big/1", "big/3", "big/4"} {
+		close(fetch.release[repo])
+	}
+	require.Equal(t, "big/4", fetch.next(t))
+	require.True(t, <-finished)
+	require.Equal(t, 2, fetch.maxOwner["big"])
+}
+
+func TestFetchPoolSkipsCappedOwnersInOrder(t *testing.T) {
+	repos := []string{"a/1", "a/2", "a/3", "b/1", "c/1"}
+	pool := newFetchPool(2, 1)
+	fetch := newGatedFetch(repos)
+	finished := runPool(pool, repos, fetch.fetch)
+
+	// a/2 and a/3 wait for a/1, b/1 is started in their place
+	require.ElementsMatch(t, []string{"a/1", "b/1"}, fetch.nextN(t, 2))
+	fetch.idle(t)
+
+	// the first queued repository whose owner is free goes first
+	close(fetch.release["a/1"])
+	require.Equal(t, "a/2", fetch.next(t))
+	close(fetch.release["b/1"])
+	require.Equal(t, "c/1", fetch.next(t))
+	close(fetch.release["c/1"])
+	fetch.idle(t)
+	close(fetch.release["a/2"])
+	require.Equal(t, "a/3", fetch.next(t))
+	close(fetch.release["a/3"])
+	require.True(t, <-finished)
+}
+
+func TestFetchPoolRunsOneCycleAtATime(t *testing.T) {
+	repos := []string{"a/1"}
+	pool := newFetchPool(2, 2)
+	fetch := newGatedFetch(repos)
+	finished := runPool(pool, repos, fetch.fetch)
+	require.Equal(t, "a/1", fetch.next(t))
+
+	called := false
+	require.False(t, pool.run([]string{"b/1"}, func(repo string) { called = true }))
+	require.False(t, called)
+	report := pool.snapshot()
+	require.Equal(t, 1, report.Cycle)
+	require.Equal(t, []string{"a/1"}, report.InProgress)
+
+	close(fetch.release["a/1"])
+	require.True(t, <-finished)
+
+	// the next cycle runs once the previous one is done
+	require.True(t, pool.run([]string{"b/1"}, func(repo string) { called = true }))
+	require.True(t, called)
+	require.Equal(t, 2, pool.snapshot().Cycle)
+}
+
+func TestNewFetchPool(t *testing.T) {
+	pool := newFetchPool(0, 0)
+	require.Equal(t, defaultFetchWorkers, pool.workers)
+	require.Equal(t, defaultFetchWorkersPerOwner, pool.perOwner)
+
+	// an owner cannot have more workers than the pool
+	pool = newFetchPool(2, 5)
+	require.Equal(t, 2, pool.perOwner)
+}
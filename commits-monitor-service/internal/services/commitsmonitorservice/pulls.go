@@ -0,0 +1,139 @@
+package commitsmonitorservice
+
+import (
+	"commits-monitor-service/internal/constants"
+	"commits-monitor-service/internal/constants/models"
+	"commits-monitor-service/internal/message-broker/rabbitmq"
+	"commits-monitor-service/internal/pkg/githubrestclient"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+)
+
+// pullRequestsBatchSize is the number of pull requests per message.
+const pullRequestsBatchSize = 50
+
+// fetchAndSavePullRequests pushes the pull requests of a repository updated
+// since the most recently updated stored one, with the SHAs of their
+// commits. Those updated in the same second as the watermark are pushed
+// again, as another pull request may have been updated in that second after
+// the last poll; storing them is idempotent. On the first sync pull requests updated before the configured
+// start date are left out. Pull requests are listed most recently updated
+// first down to the watermark, then published oldest first in batches as
+// their commits are fetched, so the stored watermark moves forward with
+// every batch and never past pull requests that were not fetched.
+func (sc *CommentMonitorService) fetchAndSavePullRequests(repo string) {
+	lastUpdatedAt, err := sc.PullsMetaDataServiceClient.GetPullRequestsWatermark(repo)
+	if err != nil {
+		log.Println("CMOS: error getting a repository pull requests watermark")
+		log.Println("CMOS: err:", err)
+		return
+	}
+	since := sc.since(repo, lastUpdatedAt)
+
+	var updated []models.PullRequest
+	var cursor string
+	for {
+		page, err := sc.Provider.FetchPullRequests(repo, since, cursor)
+		if err != nil {
+			sc.handlePullRequestsError(repo, err)
+			return
+		}
+		updated = append(updated, page.PullRequests...)
+
+		if page.Next == "" {
+			break
+		}
+		cursor = page.Next
+	}
+
+	var total int
+	var batch []PullRequestMetaData
+	// publish pushes the pull requests fetched so far, also when the walk
+	// stops early, as they are complete.
+	publish := func() bool {
+		if len(batch) == 0 {
+			return true
+		}
+		if err := sc.pushPullRequestsToQueue(repo, batch); err != nil {
+			log.Println("CMOS: error pushing pull requests of ", repo)
+			log.Println("CMOS: err:", err)
+			return false
+		}
+		total += len(batch)
+		batch = nil
+		return true
+	}
+
+	for i := len(updated) - 1; i >= 0; i-- {
+		pr := updated[i]
+		if time.Now().Before(sc.backoff.get()) {
+			publish()
+			return
+		}
+
+		shas, err := sc.Provider.FetchPullRequestCommits(repo, pr.Number)
+		if err != nil {
+			sc.handlePullRequestsError(repo, err)
+			publish()
+			return
+		}
+		batch = append(batch, PullRequestMetaData{PullRequest: pullRequestMessage(pr), Commits: shas})
+		if len(batch) == pullRequestsBatchSize && !publish() {
+			return
+		}
+	}
+	if !publish() {
+		return
+	}
+
+	log.Printf("CMOS: repo <%s> %d updated pull requests pulled\n", repo, total)
+}
+
+// handlePullRequestsError logs a failed pull requests fetch; a rate limit
+// holds back the rest of the cycle.
+func (sc *CommentMonitorService) handlePullRequestsError(repo string, err error) {
+	if errors.Is(err, githubrestclient.ErrRateLimited) {
+		until := retryAt(err, sc.Provider.RateLimit(), time.Now())
+		sc.backoff.set(until)
+		log.Printf("CMOS: rate limited fetching pull requests of <%s>, backing off until %s\n", repo, until.Format(time.RFC3339))
+		return
+	}
+	log.Println("CMOS: error fetching pull requests of ", repo)
+	log.Println("CMOS: err:", err)
+}
+
+func (sc *CommentMonitorService) pushPullRequestsToQueue(repoName string, pullRequests []PullRequestMetaData) error {
+	emitter, err := event.NewEventEmitter(sc.Rabbit)
+	if err != nil {
+		return err
+	}
+
+	j, err := json.MarshalIndent(&event.Payload{
+		Name: "pulls",
+		Data: PullRequestsMetaData{
+			Repository:   repoName,
+			FetchTime:    time.Now().UTC(),
+			PullRequests: pullRequests,
+		},
+	}, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return emitter.Push(string(j), constants.PULLS_EVENT)
+}
+
+type PullRequestsMetaData struct {
+	// Repository is the full name (owner/name) of the repository.
+	Repository   string
+	FetchTime    time.Time
+	PullRequests []PullRequestMetaData
+}
+
+// PullRequestMetaData is a pull request with the SHAs of its commits.
+type PullRequestMetaData struct {
+	PullRequest models.PullRequestResponse
+	Commits     []string
+}
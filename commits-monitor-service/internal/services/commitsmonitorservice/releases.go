@@ -0,0 +1,109 @@
+package commitsmonitorservice
+
+import (
+	"commits-monitor-service/internal/constants"
+	"commits-monitor-service/internal/constants/models"
+	"commits-monitor-service/internal/message-broker/rabbitmq"
+	"commits-monitor-service/internal/pkg/githubrestclient"
+	"encoding/json"
+	"errors"
+	"log"
+	"time"
+)
+
+// maxReleasePages caps the pages of releases and of tags listed per
+// repository and cycle.
+const maxReleasePages = 10
+
+// fetchAndSaveReleases pushes the releases and tags of a repository. Both
+// listings are served from the response cache while unchanged, and nothing
+// is pushed when neither changed.
+func (sc *CommentMonitorService) fetchAndSaveReleases(repo string) {
+	var tags []models.Tag
+	var releases []models.Release
+	var modified bool
+
+	var cursor string
+	for page := 0; page < maxReleasePages; page++ {
+		tagsPage, err := sc.Provider.FetchTags(repo, cursor)
+		if err != nil {
+			sc.handleReleasesError(repo, err)
+			return
+		}
+		tags = append(tags, tagsPage.Tags...)
+		modified = modified || !tagsPage.NotModified
+		if tagsPage.Next == "" {
+			break
+		}
+		cursor = tagsPage.Next
+	}
+
+	cursor = ""
+	for page := 0; page < maxReleasePages; page++ {
+		releasesPage, err := sc.Provider.FetchReleases(repo, cursor)
+		if err != nil {
+			sc.handleReleasesError(repo, err)
+			return
+		}
+		releases = append(releases, releasesPage.Releases...)
+		modified = modified || !releasesPage.NotModified
+		if releasesPage.Next == "" {
+			break
+		}
+		cursor = releasesPage.Next
+	}
+
+	if !modified {
+		return
+	}
+
+	if err := sc.pushReleasesToQueue(repo, releases, tags); err != nil {
+		log.Println("CMOS: error pushing releases of ", repo)
+		log.Println("CMOS: err:", err)
+		return
+	}
+	log.Printf("CMOS: repo <%s> %d releases and %d tags pulled\n", repo, len(releases), len(tags))
+}
+
+// handleReleasesError logs a failed releases or tags fetch; a rate limit
+// holds back the rest of the cycle.
+func (sc *CommentMonitorService) handleReleasesError(repo string, err error) {
+	if errors.Is(err, githubrestclient.ErrRateLimited) {
+		until := retryAt(err, sc.Provider.RateLimit(), time.Now())
+		sc.backoff.set(until)
+		log.Printf("CMOS: rate limited fetching releases of <%s>, backing off until %s\n", repo, until.Format(time.RFC3339))
+		return
+	}
+	log.Println("CMOS: error fetching releases of ", repo)
+	log.Println("CMOS: err:", err)
+}
+
+func (sc *CommentMonitorService) pushReleasesToQueue(repoName string, releases []models.Release, tags []models.Tag) error {
+	emitter, err := event.NewEventEmitter(sc.Rabbit)
+	if err != nil {
+		return err
+	}
+
+	j, err := json.MarshalIndent(&event.Payload{
+		Name: "releases",
+		Data: ReleasesMetaData{
+			Repository: repoName,
+			FetchTime:  time.Now().UTC(),
+			Releases:   releaseMessages(releases),
+			Tags:       tagMessages(tags),
+		},
+	}, "", "\t")
+	if err != nil {
+		return err
+	}
+
+	return emitter.Push(string(j), constants.RELEASES_EVENT)
+}
+
+type ReleasesMetaData struct {
+	// Repository is the full name (owner/name) of the repository.
+	Repository string
+	FetchTime  time.Time
+	Releases   []models.ReleaseResponse
+	Tags       []models.TagResponse
+}
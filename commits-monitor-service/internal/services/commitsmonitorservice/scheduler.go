@@ -0,0 +1,153 @@
+package commitsmonitorservice
+
+import (
+	"commits-monitor-service/internal/constants"
+	rmds "commits-monitor-service/internal/http/grpc/protos/repos"
+	"log"
+	"sort"
+	"time"
+)
+
+// Poll intervals used when none are configured.
+const (
+	defaultPollIntervalFloor   = 10 * time.Minute
+	defaultPollIntervalCeiling = 24 * time.Hour
+)
+
+// recentCommitsWindow is the period the recent commits of a repository are
+// counted over to estimate its commit rate.
+const recentCommitsWindow = 7 * 24 * time.Hour
+
+// maxEmptyFetchesBackoff bounds how many fetches in a row that found nothing
+// double the poll interval.
+const maxEmptyFetchesBackoff = 6
+
+// PollSchedule is when a repository is polled next. The commits manager
+// stores it so the schedule survives restarts.
+type PollSchedule struct {
+	Repository   string
+	Interval     time.Duration
+	EmptyFetches int
+	NextPollAt   time.Time
+}
+
+// pollState is the schedule of a repository as stored by the commits
+// manager, along with what its next interval is set from.
+type pollState struct {
+	repository     string
+	pushedAt       time.Time
+	recentCommits  int
+	interval       time.Duration
+	pinnedInterval time.Duration
+	emptyFetches   int
+	// nextPollAt is zero for repositories never polled.
+	nextPollAt time.Time
+}
+
+func convertPollSchedule(schedule *rmds.PollSchedule) pollState {
+	state := pollState{
+		repository:     schedule.GetRepository(),
+		recentCommits:  int(schedule.GetRecentCommits()),
+		interval:       time.Duration(schedule.GetIntervalSeconds()) * time.Second,
+		pinnedInterval: time.Duration(schedule.GetPinnedIntervalSeconds()) * time.Second,
+		emptyFetches:   int(schedule.GetEmptyFetches()),
+	}
+	state.pushedAt, _ = time.Parse(constants.ISO_8601_TIME_LAYOUT, schedule.GetPushedAt())
+	state.nextPollAt, _ = time.Parse(constants.ISO_8601_TIME_LAYOUT, schedule.GetNextPollAt())
+	return state
+}
+
+// dueRepositories returns the repositories whose next poll time has come,
+// the ones never polled first and then the most overdue.
+func dueRepositories(states []pollState, now time.Time) []pollState {
+	var due []pollState
+	for _, state := range states {
+		if !state.nextPollAt.After(now) {
+			due = append(due, state)
+		}
+	}
+	sort.SliceStable(due, func(i, j int) bool {
+		return due[i].nextPollAt.Before(due[j].nextPollAt)
+	})
+	return due
+}
+
+// pollInterval returns how long to wait before polling a repository again.
+// A pinned interval is used as is. Otherwise the interval is the average
+// time between its recent commits or, without any, half the time since its
+// last push, doubled for every fetch in a row that found no new commit, and
+// kept between the floor and the ceiling.
+func pollInterval(state pollState, floor, ceiling time.Duration, now time.Time) time.Duration {
+	if state.pinnedInterval > 0 {
+		return state.pinnedInterval
+	}
+
+	interval := ceiling
+	switch {
+	case state.recentCommits > 0:
+		interval = recentCommitsWindow / time.Duration(state.recentCommits)
+	case !state.pushedAt.IsZero():
+		interval = now.Sub(state.pushedAt) / 2
+	}
+	for i := 0; i < min(state.emptyFetches, maxEmptyFetchesBackoff) && interval < ceiling; i++ {
+		interval *= 2
+	}
+	return min(max(interval, floor), ceiling)
+}
+
+// nextPollSchedule returns when a repository is polled next after a fetch
+// that pushed fetched new commits. Failed fetches leave the count of empty
+// fetches as it was. A repository skipped or failed while rate limited is
+// polled again once the rate limit is over rather than an interval later.
+func nextPollSchedule(state pollState, fetched int, ok bool, floor, ceiling time.Duration, now, rateLimitedUntil time.Time) PollSchedule {
+	switch {
+	case ok && fetched == 0:
+		state.emptyFetches++
+	case ok:
+		state.emptyFetches = 0
+		state.recentCommits += fetched
+	}
+
+	interval := pollInterval(state, floor, ceiling, now)
+	schedule := PollSchedule{
+		Repository:   state.repository,
+		Interval:     interval,
+		EmptyFetches: state.emptyFetches,
+		NextPollAt:   now.Add(interval),
+	}
+	if !ok && rateLimitedUntil.After(now) {
+		schedule.NextPollAt = rateLimitedUntil
+	}
+	return schedule
+}
+
+// reschedule stores when a repository is polled next, before the fetch cycle
+// goes on so the next one finds it.
+func (sc *CommentMonitorService) reschedule(state pollState, fetched int, ok bool) {
+	floor, ceiling := sc.pollIntervalBounds()
+	schedule := nextPollSchedule(state, fetched, ok, floor, ceiling, time.Now().UTC(), sc.backoff.get())
+	log.Printf("CMOS: next poll of <%s> at %s\n", state.repository, schedule.NextPollAt.Format(time.RFC3339))
+	err := sc.ReposMetaDataServiceClient.SavePollSchedule(&rmds.SavePollScheduleRequest{
+		Repository:      schedule.Repository,
+		IntervalSeconds: int64(schedule.Interval / time.Second),
+		EmptyFetches:    int32(schedule.EmptyFetches),
+		NextPollAt:      schedule.NextPollAt.UTC().Format(constants.ISO_8601_TIME_LAYOUT),
+	})
+	if err != nil {
+		log.Println("CMOS: error saving poll schedule of ", state.repository)
+		log.Println("CMOS: err:", err)
+	}
+}
+
+// pollIntervalBounds returns the configured floor and ceiling of the poll
+// intervals.
+func (sc *CommentMonitorService) pollIntervalBounds() (time.Duration, time.Duration) {
+	floor, ceiling := sc.Config.PollIntervalFloor, sc.Config.PollIntervalCeiling
+	if floor <= 0 {
+		floor = defaultPollIntervalFloor
+	}
+	if ceiling <= 0 {
+		ceiling = defaultPollIntervalCeiling
+	}
+	return floor, max(floor, ceiling)
+}
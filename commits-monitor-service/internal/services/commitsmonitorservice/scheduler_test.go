@@ -0,0 +1,184 @@
+package commitsmonitorservice
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPollInterval(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	floor, ceiling := 10*time.Minute, 24*time.Hour
+	tests := []struct {
+		name  string
+		state pollState
+		want  time.Duration
+	}{
+		{
+			name:  "average time between recent commits",
+			state: pollState{recentCommits: 14},
+			want:  12 * time.Hour,
+		},
+		{
+			name:  "half the time since the last push without recent commits",
+			state: pollState{pushedAt: now.Add(-10 * time.Hour)},
+			want:  5 * time.Hour,
+		},
+		{
+			name:  "ceiling without commits or push",
+			state: pollState{},
+			want:  ceiling,
+		},
+		{
+			name:  "ceiling for a push long ago",
+			state: pollState{pushedAt: now.Add(-90 * 24 * time.Hour)},
+			want:  ceiling,
+		},
+		{
+			name:  "floor for busy repositories",
+			state: pollState{recentCommits: 5000},
+			want:  floor,
+		},
+		{
+			name:  "doubled for every empty fetch",
+			state: pollState{recentCommits: 168, emptyFetches: 2},
+			want:  4 * time.Hour,
+		},
+		{
+			name:  "empty fetches double at most six times",
+			state: pollState{recentCommits: 1008, emptyFetches: 10},
+			want:  64 * floor,
+		},
+		{
+			name:  "empty fetches stop at the ceiling",
+			state: pollState{recentCommits: 14, emptyFetches: 3},
+			want:  ceiling,
+		},
+		{
+			name:  "pinned below the floor",
+			state: pollState{recentCommits: 14, emptyFetches: 3, pinnedInterval: time.Minute},
+			want:  time.Minute,
+		},
+		{
+			name:  "pinned above the ceiling",
+			state: pollState{pinnedInterval: 48 * time.Hour},
+			want:  48 * time.Hour,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, pollInterval(tt.state, floor, ceiling, now))
+		})
+	}
+}
+
+func TestDueRepositories(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		states []pollState
+		want   []string
+	}{
+		{
+			name:   "none",
+			states: nil,
+			want:   nil,
+		},
+		{
+			name: "none due",
+			states: []pollState{
+				{repository: "a/1", nextPollAt: now.Add(time.Second)},
+				{repository: "a/2", nextPollAt: now.Add(time.Hour)},
+			},
+			want: nil,
+		},
+		{
+			name: "never polled first, then the most overdue",
+			states: []pollState{
+				{repository: "a/1", nextPollAt: now.Add(-time.Minute)},
+				{repository: "a/2", nextPollAt: now.Add(time.Minute)},
+				{repository: "a/3"},
+				{repository: "a/4", nextPollAt: now.Add(-time.Hour)},
+				{repository: "a/5", nextPollAt: now},
+				{repository: "a/6"},
+			},
+			want: []string{"a/3", "a/6", "a/4", "a/1", "a/5"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var names []string
+			for _, state := range dueRepositories(tt.states, now) {
+				names = append(names, state.repository)
+			}
+			require.Equal(t, tt.want, names)
+		})
+	}
+}
+
+func TestNextPollSchedule(t *testing.T) {
+	now := time.Date(2024, 3, 10, 12, 0, 0, 0, time.UTC)
+	floor, ceiling := 10*time.Minute, 24*time.Hour
+	rateLimited := now.Add(20 * time.Minute)
+	tests := []struct {
+		name             string
+		state            pollState
+		fetched          int
+		ok               bool
+		rateLimitedUntil time.Time
+		want             PollSchedule
+	}{
+		{
+			name:    "new commits reset the empty fetches",
+			state:   pollState{recentCommits: 12, emptyFetches: 3},
+			fetched: 2,
+			ok:      true,
+			want:    PollSchedule{Interval: 12 * time.Hour, NextPollAt: now.Add(12 * time.Hour)},
+		},
+		{
+			name:  "an empty fetch backs off",
+			state: pollState{recentCommits: 168, emptyFetches: 1},
+			ok:    true,
+			want:  PollSchedule{Interval: 4 * time.Hour, EmptyFetches: 2, NextPollAt: now.Add(4 * time.Hour)},
+		},
+		{
+			name:  "an empty fetch of a pinned repository keeps its interval",
+			state: pollState{recentCommits: 168, pinnedInterval: 30 * time.Minute},
+			ok:    true,
+			want:  PollSchedule{Interval: 30 * time.Minute, EmptyFetches: 1, NextPollAt: now.Add(30 * time.Minute)},
+		},
+		{
+			name:  "a failed fetch keeps the empty fetches",
+			state: pollState{recentCommits: 168, emptyFetches: 1},
+			want:  PollSchedule{Interval: 2 * time.Hour, EmptyFetches: 1, NextPollAt: now.Add(2 * time.Hour)},
+		},
+		{
+			name:             "a fetch skipped while rate limited is retried when the limit is over",
+			state:            pollState{recentCommits: 168, emptyFetches: 1},
+			rateLimitedUntil: rateLimited,
+			want:             PollSchedule{Interval: 2 * time.Hour, EmptyFetches: 1, NextPollAt: rateLimited},
+		},
+		{
+			name:             "a rate limit over does not hold back a failed fetch",
+			state:            pollState{recentCommits: 168},
+			rateLimitedUntil: now.Add(-time.Minute),
+			want:             PollSchedule{Interval: time.Hour, NextPollAt: now.Add(time.Hour)},
+		},
+		{
+			name:             "a fetch that went through before the rate limit waits an interval",
+			state:            pollState{recentCommits: 167},
+			fetched:          1,
+			ok:               true,
+			rateLimitedUntil: rateLimited,
+			want:             PollSchedule{Interval: time.Hour, NextPollAt: now.Add(time.Hour)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.state.repository = "acme/api"
+			tt.want.Repository = "acme/api"
+			require.Equal(t, tt.want, nextPollSchedule(tt.state, tt.fetched, tt.ok, floor, ceiling, now, tt.rateLimitedUntil))
+		})
+	}
+}
@@ -0,0 +1,102 @@
+package commitsmonitorservice
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	"commits-monitor-service/internal/pkg/githubrestclient"
+	"strings"
+)
+
+// ServerCommitsFetcher sends the requests for a repository to the provider
+// of its owner, a further GitHub server, a GitLab instance or the
+// repositories on disk, and to the default server for the other owners.
+type ServerCommitsFetcher struct {
+	fallback Provider
+	servers  []Provider
+	owners   map[string]Provider
+}
+
+func NewServerCommitsFetcher(fallback Provider) *ServerCommitsFetcher {
+	return &ServerCommitsFetcher{
+		fallback: fallback,
+		owners:   map[string]Provider{},
+	}
+}
+
+// AddServer serves the repositories of owners from fetcher.
+func (sf *ServerCommitsFetcher) AddServer(owners []string, fetcher Provider) {
+	sf.servers = append(sf.servers, fetcher)
+	for _, owner := range owners {
+		sf.owners[strings.ToLower(owner)] = fetcher
+	}
+}
+
+// fetcher returns the provider of the owner of a repository. GitLab projects
+// may sit in subgroups, the longest namespace configured as owner wins.
+func (sf *ServerCommitsFetcher) fetcher(repositoryName string) Provider {
+	namespace := strings.ToLower(repositoryName)
+	for {
+		i := strings.LastIndex(namespace, "/")
+		if i < 0 {
+			return sf.fallback
+		}
+		namespace = namespace[:i]
+		if fetcher, ok := sf.owners[namespace]; ok {
+			return fetcher
+		}
+	}
+}
+
+func (sf *ServerCommitsFetcher) FetchDefaultBranch(repositoryName string) (string, error) {
+	return sf.fetcher(repositoryName).FetchDefaultBranch(repositoryName)
+}
+
+func (sf *ServerCommitsFetcher) FetchBranches(repositoryName string) ([]models.Branch, error) {
+	return sf.fetcher(repositoryName).FetchBranches(repositoryName)
+}
+
+func (sf *ServerCommitsFetcher) FetchCommits(repositoryName string, branch string, since string, until string, perPage int32, cursor string) (models.CommitsPage, error) {
+	return sf.fetcher(repositoryName).FetchCommits(repositoryName, branch, since, until, perPage, cursor)
+}
+
+func (sf *ServerCommitsFetcher) FetchCommitDetails(repositoryName string, sha string) (models.Commit, error) {
+	return sf.fetcher(repositoryName).FetchCommitDetails(repositoryName, sha)
+}
+
+func (sf *ServerCommitsFetcher) FetchPullRequests(repositoryName string, since string, cursor string) (models.PullRequestsPage, error) {
+	return sf.fetcher(repositoryName).FetchPullRequests(repositoryName, since, cursor)
+}
+
+func (sf *ServerCommitsFetcher) FetchPullRequestCommits(repositoryName string, number int) ([]string, error) {
+	return sf.fetcher(repositoryName).FetchPullRequestCommits(repositoryName, number)
+}
+
+func (sf *ServerCommitsFetcher) FetchIssues(repositoryName string, since string, cursor string) (models.IssuesPage, error) {
+	return sf.fetcher(repositoryName).FetchIssues(repositoryName, since, cursor)
+}
+
+func (sf *ServerCommitsFetcher) FetchReleases(repositoryName string, cursor string) (models.ReleasesPage, error) {
+	return sf.fetcher(repositoryName).FetchReleases(repositoryName, cursor)
+}
+
+func (sf *ServerCommitsFetcher) FetchTags(repositoryName string, cursor string) (models.TagsPage, error) {
+	return sf.fetcher(repositoryName).FetchTags(repositoryName, cursor)
+}
+
+func (sf *ServerCommitsFetcher) FetchWorkflowRuns(repositoryName string, since string, cursor string) (models.WorkflowRunsPage, error) {
+	return sf.fetcher(repositoryName).FetchWorkflowRuns(repositoryName, since, cursor)
+}
+
+// RateLimit returns the budget of the default server, which a cycle waits
+// for.
+func (sf *ServerCommitsFetcher) RateLimit() githubrestclient.RateLimit {
+	return sf.fallback.RateLimit()
+}
+
+// TokenUsage returns the token usage of every server.
+func (sf *ServerCommitsFetcher) TokenUsage() []githubrestclient.TokenUsage {
+	usage := sf.fallback.TokenUsage()
+	for _, fetcher := range sf.servers {
+		usage = append(usage, fetcher.TokenUsage()...)
+	}
+	return usage
+}
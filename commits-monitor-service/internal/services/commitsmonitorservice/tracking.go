@@ -0,0 +1,71 @@
+package commitsmonitorservice
+
+import (
+	rmds "commits-monitor-service/internal/http/grpc/protos/repos"
+	"log"
+	"strings"
+	"sync"
+)
+
+// repositorySettings is how a repository is tracked: the settings stored for
+// it in the commits manager over the configured defaults.
+type repositorySettings struct {
+	enabled bool
+	// syncStartDate is the date commits, pull requests, issues and workflow
+	// runs are synced from on the first sync, ISO 8601.
+	syncStartDate string
+	// branches are the glob patterns of the branches synced along with the
+	// default branch, none when empty.
+	branches []string
+}
+
+// trackingSettings are the settings of the repositories that override the
+// configured defaults, keyed by lower cased full name.
+type trackingSettings struct {
+	mu     sync.RWMutex
+	byRepo map[string]*rmds.TrackedRepository
+}
+
+// refreshTrackingSettings reloads the settings of the repositories from the
+// commits manager, keeping the previous ones when it cannot be reached.
+func (sc *CommentMonitorService) refreshTrackingSettings() {
+	trackedRepositories, err := sc.ReposMetaDataServiceClient.GetTrackedRepositories()
+	if err != nil {
+		log.Println("CMOS: error getting the tracked repositories settings")
+		log.Println("CMOS: err:", err)
+		return
+	}
+
+	byRepo := make(map[string]*rmds.TrackedRepository, len(trackedRepositories))
+	for _, settings := range trackedRepositories {
+		byRepo[strings.ToLower(settings.GetRepository())] = settings
+	}
+	sc.tracking.mu.Lock()
+	sc.tracking.byRepo = byRepo
+	sc.tracking.mu.Unlock()
+}
+
+// settings returns how a repository is tracked.
+func (sc *CommentMonitorService) settings(repo string) repositorySettings {
+	settings := repositorySettings{
+		enabled:       true,
+		syncStartDate: sc.Config.StartDate,
+		branches:      sc.Config.GithubBranches,
+	}
+
+	sc.tracking.mu.RLock()
+	tracked, ok := sc.tracking.byRepo[strings.ToLower(repo)]
+	sc.tracking.mu.RUnlock()
+	if !ok {
+		return settings
+	}
+
+	settings.enabled = tracked.GetEnabled()
+	if tracked.GetSyncStartDate() != "" {
+		settings.syncStartDate = tracked.GetSyncStartDate()
+	}
+	if tracked.GetBranchesSet() {
+		settings.branches = tracked.GetBranches()
+	}
+	return settings
+}
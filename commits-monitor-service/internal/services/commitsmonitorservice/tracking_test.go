@@ -0,0 +1,52 @@
+package commitsmonitorservice
+
+import (
+	"commits-monitor-service/internal/constants/models"
+	rmds "commits-monitor-service/internal/http/grpc/protos/repos"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettingsBranches(t *testing.T) {
+	tests := []struct {
+		name    string
+		tracked *rmds.TrackedRepository
+		want    []string
+	}{
+		{
+			name: "configured branches without settings",
+			want: []string{"release/*"},
+		},
+		{
+			name:    "configured branches when the settings leave them out",
+			tracked: &rmds.TrackedRepository{Repository: "Acme/API", Enabled: true},
+			want:    []string{"release/*"},
+		},
+		{
+			name:    "branches of the settings",
+			tracked: &rmds.TrackedRepository{Repository: "Acme/API", Enabled: true, Branches: []string{"*"}, BranchesSet: true},
+			want:    []string{"*"},
+		},
+		{
+			name:    "default branch only",
+			tracked: &rmds.TrackedRepository{Repository: "Acme/API", Enabled: true, BranchesSet: true},
+			want:    nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sc := CommentMonitorService{
+				Config:   &models.Config{GithubBranches: []string{"release/*"}},
+				tracking: &trackingSettings{byRepo: map[string]*rmds.TrackedRepository{}},
+			}
+			if tt.tracked != nil {
+				sc.tracking.byRepo["acme/api"] = tt.tracked
+			}
+
+			settings := sc.settings("acme/api")
+			require.True(t, settings.enabled)
+			require.Equal(t, tt.want, settings.branches)
+		})
+	}
+}
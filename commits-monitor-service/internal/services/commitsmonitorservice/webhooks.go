@@ -0,0 +1,121 @@
+package commitsmonitorservice
+
+import (
+	"commits-monitor-service/internal/constants"
+	"commits-monitor-service/internal/constants/models"
+	"commits-monitor-service/internal/message-broker/rabbitmq"
+	"commits-monitor-service/internal/pkg/githubrestclient"
+	"encoding/json"
+	"errors"
+	"log"
+	"strings"
+	"time"
+)
+
+const (
+	branchRefPrefix = "refs/heads/"
+	tagRefPrefix    = "refs/tags/"
+)
+
+// HandlePush publishes the commits of a push delivery as a commits event, so
+// they are stored right away. They are never linked to the pushed branch:
+// push payloads carry no parents and at most 2048 commits, so linking them
+// would move the branch watermark past commits polling then never fetches.
+// Polling stores them again with their parents and links them. Pushed tags
+// refresh the releases of the repository. Pushes to paused repositories are
+// ignored.
+func (sc *CommentMonitorService) HandlePush(push models.PushEvent) error {
+	repo := push.Repository.FullName
+	if strings.HasPrefix(push.Ref, tagRefPrefix) {
+		go sc.fetchAndSaveReleases(repo)
+		return nil
+	}
+	branch, ok := strings.CutPrefix(push.Ref, branchRefPrefix)
+	if !ok || push.Deleted || len(push.Commits) == 0 {
+		return nil
+	}
+	settings := sc.settings(repo)
+	if !settings.enabled || branch != push.Repository.DefaultBranch && !matchesAny(settings.branches, branch) {
+		return nil
+	}
+
+	commits := make([]models.Commit, len(push.Commits))
+	for i, pushed := range push.Commits {
+		commits[i] = models.Commit{
+			Sha:       pushed.ID,
+			URL:       pushed.URL,
+			HTMLURL:   pushed.URL,
+			Message:   pushed.Message,
+			Author:    models.Signature{Name: pushed.Author.Name, Email: pushed.Author.Email, Date: pushed.Timestamp},
+			Committer: models.Signature{Name: pushed.Committer.Name, Email: pushed.Committer.Email, Date: pushed.Timestamp},
+		}
+	}
+
+	if err := sc.pushToQueue(repo, "", time.Now().UTC(), commits); err != nil {
+		return err
+	}
+	log.Printf("CMOS: repo <%s> %d pushed commits received\n", repo, len(commits))
+	return nil
+}
+
+// HandleRepository publishes a repository delivery as a repo event. Deleted
+// repositories, and the old name of renamed ones, are recorded as not found,
+// so they are no longer polled.
+func (sc *CommentMonitorService) HandleRepository(repositoryEvent models.RepositoryEvent) error {
+	repo := repositoryEvent.Repository.FullName
+	switch repositoryEvent.Action {
+	case "deleted":
+		sc.recordOutcome(CommitsFetchOutcome{Repository: repo, Outcome: constants.FETCH_OUTCOME_NOT_FOUND, Message: "deleted"})
+		return nil
+	case "renamed":
+		owner, _, _ := strings.Cut(repo, "/")
+		oldName := owner + "/" + repositoryEvent.Changes.Repository.Name.From
+		sc.recordOutcome(CommitsFetchOutcome{Repository: oldName, Outcome: constants.FETCH_OUTCOME_NOT_FOUND, Message: "renamed to " + repo})
+	}
+
+	emitter, err := event.NewEventEmitter(sc.Rabbit)
+	if err != nil {
+		return err
+	}
+	j, err := json.MarshalIndent(&event.Payload{
+		Name: "repo",
+		Data: repositoryEvent.Repository,
+	}, "", "\t")
+	if err != nil {
+		return err
+	}
+	return emitter.Push(string(j), constants.REPOS_EVENT)
+}
+
+// HandleCreate syncs a created branch that is tracked, or the releases of
+// the repository when a tag was created, in the background. While rate
+// limited the branch is left to polling; branches of paused repositories
+// are ignored.
+func (sc *CommentMonitorService) HandleCreate(ref models.RefEvent) error {
+	repo := ref.Repository.FullName
+	switch ref.RefType {
+	case "tag":
+		go sc.fetchAndSaveReleases(repo)
+	case "branch":
+		settings := sc.settings(repo)
+		if !settings.enabled || ref.Ref != ref.Repository.DefaultBranch && !matchesAny(settings.branches, ref.Ref) {
+			return nil
+		}
+		if time.Now().Before(sc.backoff.get()) {
+			return nil
+		}
+		go func() {
+			_, _, err := sc.fetchAndSaveCommitsForBranch(repo, models.Branch{Name: ref.Ref})
+			if err != nil && !errors.Is(err, githubrestclient.ErrNotFound) {
+				sc.handleFetchError(repo, err)
+			}
+		}()
+	}
+	return nil
+}
+
+// HandleDelete logs a deleted branch or tag. What was stored of it is kept.
+func (sc *CommentMonitorService) HandleDelete(ref models.RefEvent) error {
+	log.Printf("CMOS: %s %s of <%s> deleted\n", ref.RefType, ref.Ref, ref.Repository.FullName)
+	return nil
+}
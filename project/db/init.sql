@@ -1,7 +1,12 @@
 CREATE TABLE repositories
 (
     id BIGSERIAL PRIMARY KEY,
-    name VARCHAR(255) UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    owner VARCHAR(255) NOT NULL,
+    full_name VARCHAR(255) UNIQUE NOT NULL,
+    owner_type VARCHAR(50) NOT NULL DEFAULT 'User',
+    provider VARCHAR(50) NOT NULL DEFAULT 'github',
+    sync_status VARCHAR(50) NOT NULL DEFAULT 'active',
     description TEXT,
     url VARCHAR(255) NOT NULL,
     language VARCHAR(255),
@@ -10,13 +15,14 @@ CREATE TABLE repositories
     open_issues_count INT NOT NULL,
     watchers_count INT NOT NULL,
     created_at TIMESTAMP NOT NULL,
-    updated_at TIMESTAMP NOT NULL
+    updated_at TIMESTAMP NOT NULL,
+    pushed_at TIMESTAMP NOT NULL
 );
 
 CREATE TABLE commits
 (
     id BIGSERIAL PRIMARY KEY,
-    sha VARCHAR(255) UNIQUE,
+    sha VARCHAR(255) NOT NULL,
     url VARCHAR(255) NOT NULL,
     message TEXT NOT NULL,
     author_name VARCHAR(255) NOT NULL,
@@ -24,12 +30,188 @@ CREATE TABLE commits
     created_at TIMESTAMP NOT NULL,
     updated_at TIMESTAMP NOT NULL,
     repository_name VARCHAR(255) NOT NULL,
-    FOREIGN KEY (repository_name) REFERENCES repositories(name) ON DELETE CASCADE
+    bot BOOLEAN NOT NULL DEFAULT FALSE,
+    UNIQUE (repository_name, sha),
+    FOREIGN KEY (repository_name) REFERENCES repositories(full_name) ON DELETE CASCADE
+);
+
+CREATE INDEX commits_repository_name_author_date_idx ON commits (repository_name, author_date DESC);
+
+CREATE TABLE commit_stats
+(
+    repository_name VARCHAR(255) NOT NULL,
+    sha VARCHAR(255) NOT NULL,
+    additions INT NOT NULL,
+    deletions INT NOT NULL,
+    total INT NOT NULL,
+    PRIMARY KEY (repository_name, sha),
+    FOREIGN KEY (repository_name, sha) REFERENCES commits(repository_name, sha) ON DELETE CASCADE
+);
+
+CREATE TABLE commit_files
+(
+    id BIGSERIAL PRIMARY KEY,
+    repository_name VARCHAR(255) NOT NULL,
+    sha VARCHAR(255) NOT NULL,
+    path TEXT NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    additions INT NOT NULL,
+    deletions INT NOT NULL,
+    FOREIGN KEY (repository_name, sha) REFERENCES commits(repository_name, sha) ON DELETE CASCADE
+);
+
+CREATE TABLE commit_details_failures
+(
+    repository_name VARCHAR(255) NOT NULL,
+    sha VARCHAR(255) NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    message TEXT NOT NULL DEFAULT '',
+    failed_at TIMESTAMPTZ NOT NULL,
+    PRIMARY KEY (repository_name, sha),
+    FOREIGN KEY (repository_name, sha) REFERENCES commits(repository_name, sha) ON DELETE CASCADE
+);
+
+CREATE INDEX commit_files_sha_idx ON commit_files (repository_name, sha);
+
+CREATE TABLE commit_branches
+(
+    repository_name VARCHAR(255) NOT NULL,
+    sha VARCHAR(255) NOT NULL,
+    branch VARCHAR(255) NOT NULL,
+    PRIMARY KEY (repository_name, sha, branch),
+    FOREIGN KEY (repository_name, sha) REFERENCES commits(repository_name, sha) ON DELETE CASCADE
 );
 
+CREATE INDEX commit_branches_branch_idx ON commit_branches (repository_name, branch);
+
+CREATE TABLE commit_parents
+(
+    repository_name VARCHAR(255) NOT NULL,
+    sha VARCHAR(255) NOT NULL,
+    parent_sha VARCHAR(255) NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (repository_name, sha, parent_sha),
+    FOREIGN KEY (repository_name, sha) REFERENCES commits(repository_name, sha) ON DELETE CASCADE
+);
+
+CREATE TABLE tags
+(
+    id BIGSERIAL PRIMARY KEY,
+    repository_name VARCHAR(255) NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    sha VARCHAR(255) NOT NULL,
+    UNIQUE (repository_name, name),
+    FOREIGN KEY (repository_name) REFERENCES repositories(full_name) ON DELETE CASCADE
+);
+
+CREATE TABLE releases
+(
+    id BIGSERIAL PRIMARY KEY,
+    repository_name VARCHAR(255) NOT NULL,
+    tag_name VARCHAR(255) NOT NULL,
+    name TEXT NOT NULL,
+    target_sha VARCHAR(255) NOT NULL DEFAULT '',
+    draft BOOLEAN NOT NULL DEFAULT FALSE,
+    prerelease BOOLEAN NOT NULL DEFAULT FALSE,
+    author VARCHAR(255) NOT NULL,
+    url TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    published_at TIMESTAMPTZ,
+    UNIQUE (repository_name, tag_name),
+    FOREIGN KEY (repository_name) REFERENCES repositories(full_name) ON DELETE CASCADE
+);
+
+CREATE TABLE pull_requests
+(
+    id BIGSERIAL PRIMARY KEY,
+    repository_name VARCHAR(255) NOT NULL,
+    number INT NOT NULL,
+    title TEXT NOT NULL,
+    author VARCHAR(255) NOT NULL,
+    state VARCHAR(50) NOT NULL,
+    head_ref VARCHAR(255) NOT NULL,
+    base_ref VARCHAR(255) NOT NULL,
+    url TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    merged_at TIMESTAMPTZ,
+    closed_at TIMESTAMPTZ,
+    UNIQUE (repository_name, number),
+    FOREIGN KEY (repository_name) REFERENCES repositories(full_name) ON DELETE CASCADE
+);
+
+CREATE INDEX pull_requests_updated_at_idx ON pull_requests (repository_name, updated_at);
+
+CREATE TABLE pull_request_commits
+(
+    pull_request_id BIGINT NOT NULL,
+    sha VARCHAR(255) NOT NULL,
+    PRIMARY KEY (pull_request_id, sha),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests(id) ON DELETE CASCADE
+);
+
+CREATE TABLE issues
+(
+    id BIGSERIAL PRIMARY KEY,
+    repository_name VARCHAR(255) NOT NULL,
+    number INT NOT NULL,
+    title TEXT NOT NULL,
+    author VARCHAR(255) NOT NULL,
+    state VARCHAR(50) NOT NULL,
+    comments INT NOT NULL DEFAULT 0,
+    url TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    closed_at TIMESTAMPTZ,
+    UNIQUE (repository_name, number),
+    FOREIGN KEY (repository_name) REFERENCES repositories(full_name) ON DELETE CASCADE
+);
+
+CREATE INDEX issues_updated_at_idx ON issues (repository_name, updated_at);
+
+CREATE TABLE issue_labels
+(
+    issue_id BIGINT NOT NULL,
+    name VARCHAR(255) NOT NULL,
+    PRIMARY KEY (issue_id, name),
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+CREATE TABLE issue_assignees
+(
+    issue_id BIGINT NOT NULL,
+    login VARCHAR(255) NOT NULL,
+    PRIMARY KEY (issue_id, login),
+    FOREIGN KEY (issue_id) REFERENCES issues(id) ON DELETE CASCADE
+);
+
+CREATE TABLE workflow_runs
+(
+    id BIGINT PRIMARY KEY,
+    repository_name VARCHAR(255) NOT NULL,
+    workflow_id BIGINT NOT NULL,
+    workflow_name VARCHAR(255) NOT NULL,
+    head_sha VARCHAR(255) NOT NULL,
+    head_branch VARCHAR(255) NOT NULL,
+    event VARCHAR(50) NOT NULL,
+    status VARCHAR(50) NOT NULL,
+    conclusion VARCHAR(50) NOT NULL,
+    run_attempt INT NOT NULL DEFAULT 1,
+    url TEXT NOT NULL,
+    duration_seconds INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL,
+    run_started_at TIMESTAMPTZ NOT NULL,
+    FOREIGN KEY (repository_name) REFERENCES repositories(full_name) ON DELETE CASCADE
+);
+
+CREATE INDEX workflow_runs_head_sha_idx ON workflow_runs (repository_name, head_sha);
+CREATE INDEX workflow_runs_created_at_idx ON workflow_runs (repository_name, created_at);
+
 CREATE TABLE repos_fetch_history
 (
     id BIGSERIAL PRIMARY KEY,
+    owner VARCHAR(255) NOT NULL,
     total INT NOT NULL,
     last_page INT NOT NULL,
     fetched_at TIMESTAMPTZ NOT NULL
@@ -40,8 +222,71 @@ CREATE TABLE commits_fetch_history
     id BIGSERIAL PRIMARY KEY,
     repository_name VARCHAR(255) NOT NULL,
     total INT NOT NULL,
-    last_page INT NOT NULL,
     fetched_at TIMESTAMPTZ NOT NULL,
-    FOREIGN KEY (repository_name) REFERENCES repositories(name)
+    FOREIGN KEY (repository_name) REFERENCES repositories(full_name)
 );
 
+CREATE TABLE commits_fetch_outcomes
+(
+    id BIGSERIAL PRIMARY KEY,
+    repository_name VARCHAR(255) NOT NULL,
+    outcome VARCHAR(50) NOT NULL,
+    status_code INT NOT NULL DEFAULT 0,
+    message TEXT NOT NULL DEFAULT '',
+    total INT NOT NULL DEFAULT 0,
+    fetched_at TIMESTAMPTZ NOT NULL,
+    FOREIGN KEY (repository_name) REFERENCES repositories(full_name) ON DELETE CASCADE
+);
+
+CREATE INDEX commits_fetch_outcomes_repository_name_fetched_at_idx ON commits_fetch_outcomes (repository_name, fetched_at DESC);
+
+CREATE TABLE archive_imports
+(
+    file_name VARCHAR(255) PRIMARY KEY,
+    events INT NOT NULL,
+    repositories INT NOT NULL,
+    commits INT NOT NULL,
+    imported_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE TABLE poll_schedules
+(
+    repository_name VARCHAR(255) PRIMARY KEY,
+    interval_seconds BIGINT NOT NULL DEFAULT 0,
+    empty_fetches INT NOT NULL DEFAULT 0,
+    next_poll_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL,
+    FOREIGN KEY (repository_name) REFERENCES repositories(full_name) ON DELETE CASCADE
+);
+
+CREATE TABLE backfill_jobs
+(
+    id BIGSERIAL PRIMARY KEY,
+    repository_name VARCHAR(255) NOT NULL DEFAULT '',
+    start_date TIMESTAMPTZ NOT NULL,
+    end_date TIMESTAMPTZ NOT NULL,
+    priority INT NOT NULL DEFAULT 0,
+    status VARCHAR(50) NOT NULL,
+    repositories_total INT NOT NULL DEFAULT 0,
+    repositories_done INT NOT NULL DEFAULT 0,
+    commits INT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL,
+    started_at TIMESTAMPTZ,
+    finished_at TIMESTAMPTZ,
+    updated_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX backfill_jobs_status_priority_idx ON backfill_jobs (status, priority DESC, created_at);
+
+CREATE TABLE tracked_repositories
+(
+    repository_name VARCHAR(255) PRIMARY KEY,
+    enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    sync_start_date TIMESTAMPTZ,
+    branches TEXT,
+    poll_interval_seconds BIGINT NOT NULL DEFAULT 0,
+    exclude_bots BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL
+);
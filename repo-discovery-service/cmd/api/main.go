@@ -4,7 +4,10 @@ import (
 	"repos-discovery-service/internal/constants/models"
 	"repos-discovery-service/internal/http/grpc/client/repos"
 	"repos-discovery-service/internal/pkg/githubrestclient"
+	"repos-discovery-service/internal/pkg/gitlabclient"
+	"repos-discovery-service/internal/pkg/localgitclient"
 
+	"encoding/json"
 	"fmt"
 	"math"
 	"time"
@@ -13,6 +16,7 @@ import (
 
 	"log"
 	"os"
+	"strings"
 
 	amqp "github.com/rabbitmq/amqp091-go"
 )
@@ -29,15 +33,77 @@ func main() {
 	}
 	defer rabbitConn.Close()
 
-	githubRestClient := githubrestclient.NewGithubRestClient(&models.Config{
-		GithubToken:    os.Getenv("GITHUB_TOKEN"),
-		GithubUsername: os.Getenv("GITHUB_USERNAME"),
-	})
+	servers, err := parseServers(os.Getenv("GITHUB_SERVERS"))
+	if err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
+
+	config := &models.Config{
+		GithubAPIURL:            os.Getenv("GITHUB_API_URL"),
+		GithubCACert:            os.Getenv("GITHUB_CA_CERT"),
+		GithubServers:           servers,
+		GithubToken:             os.Getenv("GITHUB_TOKEN"),
+		GithubUsername:          os.Getenv("GITHUB_USERNAME"),
+		GithubOwnerType:         os.Getenv("GITHUB_OWNER_TYPE"),
+		GithubOwners:            parseOwners(os.Getenv("GITHUB_OWNERS")),
+		GithubTokens:            splitList(os.Getenv("GITHUB_TOKENS")),
+		GithubAppID:             os.Getenv("GITHUB_APP_ID"),
+		GithubAppInstallationID: os.Getenv("GITHUB_APP_INSTALLATION_ID"),
+		GithubAppPrivateKey:     os.Getenv("GITHUB_APP_PRIVATE_KEY"),
+		GitlabAPIURL:            os.Getenv("GITLAB_API_URL"),
+		GitlabToken:             os.Getenv("GITLAB_TOKEN"),
+		GitlabCACert:            os.Getenv("GITLAB_CA_CERT"),
+		GitlabOwners:            parseOwners(os.Getenv("GITLAB_OWNERS")),
+		LocalReposDir:           os.Getenv("LOCAL_REPOS_DIR"),
+	}
+	githubRestClient, err := githubrestclient.NewGithubRestClient(config)
+	if err != nil {
+		log.Println(err)
+		os.Exit(1)
+	}
 
 	reposMetaDataServiceClient := repos.NewRepositoriesServiceClient(commitMangerUrl)
-	reposdiscoveryservice := reposdiscoveryservice.NewReposDiscoveryService(githubRestClient,
+	reposdiscoveryservice := reposdiscoveryservice.NewReposDiscoveryService(config, githubRestClient,
 		*reposMetaDataServiceClient,
 		rabbitConn)
+	for name, server := range config.GithubServers {
+		client, err := githubrestclient.NewGithubRestClient(config.WithServer(server))
+		if err != nil {
+			log.Printf("RDS: github server %s: %v\n", name, err)
+			os.Exit(1)
+		}
+		reposdiscoveryservice.AddServer(server.Owners, client)
+	}
+	if len(config.GitlabOwners) > 0 {
+		gitlabClient, err := gitlabclient.NewGitlabClient(config)
+		if err != nil {
+			log.Println("RDS: gitlab:", err)
+			os.Exit(1)
+		}
+		var owners []string
+		for _, owner := range config.GitlabOwners {
+			owners = append(owners, owner.Login)
+		}
+		reposdiscoveryservice.AddServer(owners, gitlabClient)
+	}
+	if config.LocalReposDir != "" {
+		localClient, err := localgitclient.NewLocalGitClient(config)
+		if err != nil {
+			log.Println("RDS: local repositories:", err)
+			os.Exit(1)
+		}
+		config.LocalOwners, err = localClient.Owners()
+		if err != nil {
+			log.Println("RDS: local repositories:", err)
+			os.Exit(1)
+		}
+		var owners []string
+		for _, owner := range config.LocalOwners {
+			owners = append(owners, owner.Login)
+		}
+		reposdiscoveryservice.AddServer(owners, localClient)
+	}
 
 	wait := make(chan bool)
 
@@ -56,6 +122,40 @@ func main() {
 
 }
 
+// parseOwners reads a comma separated list of owners, each given as login or
+// login:type with type user or org.
+func parseOwners(value string) []models.Owner {
+	var owners []models.Owner
+	for _, item := range splitList(value) {
+		login, ownerType, _ := strings.Cut(item, ":")
+		owners = append(owners, models.Owner{Login: login, Type: ownerType})
+	}
+	return owners
+}
+
+// parseServers reads the JSON object of GitHub servers keyed by name.
+func parseServers(value string) (map[string]models.GithubServer, error) {
+	if value == "" {
+		return nil, nil
+	}
+	var servers map[string]models.GithubServer
+	if err := json.Unmarshal([]byte(value), &servers); err != nil {
+		return nil, fmt.Errorf("RDS: cannot parse GITHUB_SERVERS: %w", err)
+	}
+	return servers, nil
+}
+
+// splitList splits a comma separated environment variable.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
 func connect() (*amqp.Connection, error) {
 	var counts int64
 	var backOff = 1 * time.Second
@@ -5,3 +5,16 @@ const ISO_8601_TIME_LAYOUT = "2006-01-02T15:04:05Z"
 const REPOS_EVENT = "github.REPOS"
 
 const GITHUB_API_TOPIC = "github_api_topic"
+
+// Owner types as reported by GitHub in the type field of an account.
+const (
+	OWNER_TYPE_USER         = "User"
+	OWNER_TYPE_ORGANIZATION = "Organization"
+)
+
+// Providers hosting a repository
+const (
+	PROVIDER_GITHUB = "github"
+	PROVIDER_GITLAB = "gitlab"
+	PROVIDER_LOCAL  = "local"
+)
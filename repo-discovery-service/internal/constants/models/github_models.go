@@ -109,6 +109,9 @@ type RepositoryResponse struct {
 		Triage   bool `json:"triage"`
 		Pull     bool `json:"pull"`
 	} `json:"permissions"`
+	// Provider is the service hosting the repository. It is not sent by
+	// GitHub, the discovery service sets it.
+	Provider string `json:"provider,omitempty"`
 }
 
 type CommitResponse struct {
@@ -4,5 +4,99 @@ type Config struct {
 	DSN            string `json:"dsn"`
 	GithubToken    string `json:"github_token"`
 	GithubUsername string `json:"github_username"`
+
+	// GithubOwnerType is "user" or "org". When empty the type of
+	// GithubUsername is looked up on GitHub.
+	GithubOwnerType string `json:"github_owner_type"`
+
+	// GithubOwners are the accounts whose repositories are tracked. When
+	// empty, GithubUsername is the only owner.
+	GithubOwners []Owner `json:"github_owners"`
+
+	// GithubTokens are additional personal access tokens requests are
+	// rotated over.
+	GithubTokens []string `json:"-"`
+
+	// GitHub App authentication, rotated over together with the personal
+	// access tokens when GithubAppID is set. GithubAppPrivateKey holds
+	// either the PEM encoded key or the path of the key file.
+	GithubAppID             string `json:"github_app_id"`
+	GithubAppInstallationID string `json:"github_app_installation_id"`
+	GithubAppPrivateKey     string `json:"-"`
+
+	// GithubAPIURL is the root of the REST API, https://api.github.com
+	// unless a GitHub Enterprise Server such as https://ghe.corp/api/v3 is
+	// used.
+	GithubAPIURL string `json:"github_api_url"`
+
+	// GithubCACert holds a PEM encoded CA bundle, or the path of the bundle
+	// file, trusted in addition to the system roots.
+	GithubCACert string `json:"github_ca_cert"`
+
+	// GithubServers are further GitHub instances keyed by name. The
+	// repositories of the owners listed by a server are discovered on it.
+	GithubServers map[string]GithubServer `json:"github_servers"`
+
+	// GitLab instance the repositories of GitlabOwners are discovered on.
+	// GitlabAPIURL is the root of its REST API, https://gitlab.com/api/v4
+	// when empty, and GitlabCACert a CA bundle trusted like GithubCACert.
+	GitlabAPIURL string  `json:"gitlab_api_url"`
+	GitlabToken  string  `json:"-"`
+	GitlabCACert string  `json:"gitlab_ca_cert"`
+	GitlabOwners []Owner `json:"gitlab_owners"`
+
+	// LocalReposDir is a directory of git repositories on disk laid out as
+	// <owner>/<name>, bare (<name>.git) or working copies. Its owners are
+	// listed in LocalOwners when the service starts.
+	LocalReposDir string  `json:"local_repos_dir"`
+	LocalOwners   []Owner `json:"local_owners"`
+}
+
+// GithubServer is a GitHub instance serving the repositories of some owners.
+// Tokens replace the default tokens and GitHub App when set; the other
+// settings fall back to the defaults when empty.
+type GithubServer struct {
+	Owners []string `json:"owners"`
+	APIURL string   `json:"api_url"`
+	CACert string   `json:"ca_cert"`
+	Token  string   `json:"token"`
+	Tokens []string `json:"tokens"`
+}
+
+// WithServer returns a copy of the configuration talking to server.
+func (c *Config) WithServer(server GithubServer) *Config {
+	config := *c
+	config.GithubServers = nil
+	if server.APIURL != "" {
+		config.GithubAPIURL = server.APIURL
+	}
+	if server.CACert != "" {
+		config.GithubCACert = server.CACert
+	}
+	if server.Token != "" || len(server.Tokens) > 0 {
+		config.GithubToken = server.Token
+		config.GithubTokens = server.Tokens
+		config.GithubAppID = ""
+		config.GithubAppInstallationID = ""
+		config.GithubAppPrivateKey = ""
+	}
+	return &config
+}
+
+// Owner is a GitHub user or organization whose repositories are tracked.
+type Owner struct {
+	Login string `json:"login"`
+	// Type is "user" or "org". When empty it is looked up on GitHub.
+	Type string `json:"type"`
 }
 
+// Owners returns the configured owners.
+func (c *Config) Owners() []Owner {
+	if len(c.GithubOwners) > 0 {
+		return c.GithubOwners
+	}
+	if c.GithubUsername == "" {
+		return nil
+	}
+	return []Owner{{Login: c.GithubUsername, Type: c.GithubOwnerType}}
+}
@@ -38,7 +38,7 @@ func (rmdsc RepositoriesServiceClient) GetRepositoryNames() ([]string, error) {
 	return response.Repositories, nil
 }
 
-func (rmdsc RepositoriesServiceClient) GetReposFetchHistory() (*rs.GetReposFetchHistoryResponse, error) {
+func (rmdsc RepositoriesServiceClient) GetReposFetchHistory(owner string) (*rs.GetReposFetchHistoryResponse, error) {
 	conn, err := grpc.NewClient(rmdsc.ServiceUrl, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
 		return &rs.GetReposFetchHistoryResponse{}, err
@@ -49,7 +49,7 @@ func (rmdsc RepositoriesServiceClient) GetReposFetchHistory() (*rs.GetReposFetch
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
 	defer cancel()
 
-	response, err := c.GetReposFetchHistory(ctx, &rs.GetReposFetchHistoryRequest{})
+	response, err := c.GetReposFetchHistory(ctx, &rs.GetReposFetchHistoryRequest{Owner: owner})
 	if err != nil {
 		return &rs.GetReposFetchHistoryResponse{}, err
 	}
@@ -58,3 +58,23 @@ func (rmdsc RepositoriesServiceClient) GetReposFetchHistory() (*rs.GetReposFetch
 		LastPage:      response.LastPage,
 	}, nil
 }
+
+// GetTrackedRepositories returns the settings of the repositories that
+// override the configured defaults.
+func (rmdsc RepositoriesServiceClient) GetTrackedRepositories() ([]*rs.TrackedRepository, error) {
+	conn, err := grpc.NewClient(rmdsc.ServiceUrl, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	c := rs.NewRepositoriesServiceClient(conn)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*10)
+	defer cancel()
+
+	response, err := c.GetTrackedRepositories(ctx, &rs.GetTrackedRepositoriesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return response.Repositories, nil
+}
@@ -0,0 +1,197 @@
+package githubrestclient
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"repos-discovery-service/internal/constants/models"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// appJWTLifetime is below the 10 minutes GitHub accepts for app JWTs.
+	appJWTLifetime = 9 * time.Minute
+
+	// appJWTClockSkew backdates the JWT to tolerate clock drift.
+	appJWTClockSkew = 60 * time.Second
+
+	// installationTokenRefreshMargin is how long before its expiry an
+	// installation token is replaced.
+	installationTokenRefreshMargin = 5 * time.Minute
+)
+
+// tokenSource provides the token sent in the Authorization header.
+type tokenSource interface {
+	token() (string, error)
+}
+
+// staticToken is a personal access token.
+type staticToken string
+
+func (t staticToken) token() (string, error) {
+	return string(t), nil
+}
+
+// appTokenSource authenticates as a GitHub App installation. It signs a JWT
+// with the app's private key, exchanges it for an installation access token
+// and refreshes that token before it expires.
+type appTokenSource struct {
+	appID          string
+	installationID string
+	key            *rsa.PrivateKey
+	baseURL        string
+	httpClient     *http.Client
+
+	mu        sync.Mutex
+	current   string
+	expiresAt time.Time
+}
+
+func newAppTokenSource(config *models.Config, baseURL string, httpClient *http.Client) (*appTokenSource, error) {
+	if config.GithubAppInstallationID == "" {
+		return nil, errors.New("RDS: github app installation id is not configured")
+	}
+	key, err := loadPrivateKey(config.GithubAppPrivateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &appTokenSource{
+		appID:          config.GithubAppID,
+		installationID: config.GithubAppInstallationID,
+		key:            key,
+		baseURL:        baseURL,
+		httpClient:     httpClient,
+	}, nil
+}
+
+func (s *appTokenSource) token() (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.current != "" && time.Until(s.expiresAt) > installationTokenRefreshMargin {
+		return s.current, nil
+	}
+
+	token, expiresAt, err := s.installationToken()
+	if err != nil {
+		return "", err
+	}
+	s.current = token
+	s.expiresAt = expiresAt
+	return s.current, nil
+}
+
+// installationToken exchanges an app JWT for an installation access token.
+func (s *appTokenSource) installationToken() (string, time.Time, error) {
+	jwt, err := s.jwt(time.Now())
+	if err != nil {
+		return "", time.Time{}, err
+	}
+
+	tokenUrl := fmt.Sprintf("%s/app/installations/%s/access_tokens", s.baseURL, s.installationID)
+	request, err := http.NewRequest(http.MethodPost, tokenUrl, nil)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	request.Header.Add("Accept", "application/vnd.github+json")
+	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", jwt))
+	request.Header.Add("X-GitHub-Api-Version", "2022-11-28")
+
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	defer response.Body.Close()
+
+	bodyBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return "", time.Time{}, err
+	}
+	if response.StatusCode != http.StatusCreated {
+		return "", time.Time{}, fmt.Errorf("RDS: creating installation token failed with status %d: %s", response.StatusCode, bodyBytes)
+	}
+
+	var installationToken struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.Unmarshal(bodyBytes, &installationToken); err != nil {
+		return "", time.Time{}, err
+	}
+	if installationToken.Token == "" {
+		return "", time.Time{}, errors.New("RDS: installation token response has no token")
+	}
+	return installationToken.Token, installationToken.ExpiresAt, nil
+}
+
+// jwt returns an RS256 signed JWT identifying the app.
+func (s *appTokenSource) jwt(now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-appJWTClockSkew).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": s.appID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", err
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// loadPrivateKey parses a PEM encoded RSA key given either inline or as the
+// path of the file GitHub generated for the app.
+func loadPrivateKey(value string) (*rsa.PrivateKey, error) {
+	if value == "" {
+		return nil, errors.New("RDS: github app private key is not configured")
+	}
+
+	data := []byte(value)
+	if !strings.Contains(value, "-----BEGIN") {
+		var err error
+		data, err = os.ReadFile(value)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("RDS: github app private key is not PEM encoded")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("RDS: github app private key is not an RSA key")
+	}
+	return key, nil
+}
@@ -7,22 +7,58 @@ import (
 	"log"
 	"net/http"
 	"net/url"
+	"repos-discovery-service/internal/constants"
 	"repos-discovery-service/internal/constants/models"
+	"strings"
 )
 
 type GithubRestClient struct {
-	Config *models.Config
+	Config     *models.Config
+	baseURL    string
+	httpClient *http.Client
+	pool       *tokenPool
+	cache      *responseCache
 }
 
-func NewGithubRestClient(Config *models.Config) GithubRestClient {
-	return GithubRestClient{Config: Config}
+func NewGithubRestClient(Config *models.Config) (GithubRestClient, error) {
+	httpClient, err := newHTTPClient(Config)
+	if err != nil {
+		return GithubRestClient{}, err
+	}
+
+	baseURL := apiURL(Config)
+	pool, err := newTokenPool(Config, baseURL, httpClient)
+	if err != nil {
+		return GithubRestClient{}, err
+	}
+
+	return GithubRestClient{
+		Config:     Config,
+		baseURL:    baseURL,
+		httpClient: httpClient,
+		pool:       pool,
+		cache:      newResponseCache(),
+	}, nil
 }
 
-const baseURL = "https://api.github.com"
+// Name returns the provider the discovered repositories are tagged with.
+func (gp GithubRestClient) Name() string {
+	return constants.PROVIDER_GITHUB
+}
+
+// RateLimit returns the request budget left on the configured tokens.
+func (gp GithubRestClient) RateLimit() RateLimit {
+	return gp.pool.rateLimit()
+}
+
+// TokenUsage returns the budget and usage of every configured token.
+func (gp GithubRestClient) TokenUsage() []TokenUsage {
+	return gp.pool.usage()
+}
 
 func buildURI(base string, path string, queryParams map[string]string) string {
 	u, _ := url.Parse(base)
-	u.Path = path
+	u.Path = strings.TrimSuffix(u.Path, "/") + path
 	q := u.Query()
 	for key, value := range queryParams {
 		q.Set(key, value)
@@ -31,94 +67,188 @@ func buildURI(base string, path string, queryParams map[string]string) string {
 	return u.String()
 }
 
-func (gp GithubRestClient) FetchRepositories(perPage, page int) ([]models.RepositoryResponse, error) {
-	path := fmt.Sprintf("/users/%s/repos", gp.Config.GithubUsername)
+// RepositoriesPage is one page of the repositories listing of an owner.
+type RepositoriesPage struct {
+	Repositories []models.RepositoryResponse
+	// NextPage is the page linked as next by GitHub, 0 on the last page.
+	NextPage int
+	// LastPage is the page linked as last by GitHub, 0 on the last page.
+	LastPage int
+	// NotModified is set when GitHub answered 304 and Repositories were
+	// served from the cache.
+	NotModified bool
+}
+
+// RepositoryMetadata is the metadata of a single repository.
+type RepositoryMetadata struct {
+	Repository models.RepositoryResponse
+	// NotModified is set when the repository did not change since the
+	// previous fetch.
+	NotModified bool
+}
+
+// FetchOwnerType returns the type of a GitHub account, either
+// constants.OWNER_TYPE_USER or constants.OWNER_TYPE_ORGANIZATION.
+func (gp GithubRestClient) FetchOwnerType(owner string) (string, error) {
+	path := fmt.Sprintf("/users/%s", owner)
+
+	response, err := gp.get(buildURI(gp.baseURL, path, nil))
+	if err != nil {
+		log.Println("RDS: ", err)
+		return "", err
+	}
+
+	if response.statusCode != http.StatusOK {
+		log.Println("RDS: fetch owner unexpected status code: ", response.statusCode)
+		return "", fmt.Errorf("RDS: unexpected status code: %d", response.statusCode)
+	}
+
+	var account struct {
+		Type string `json:"type"`
+	}
+	err = json.Unmarshal(response.body, &account)
+	if err != nil {
+		log.Println("RDS: error unmarshalling response body: ", err)
+		return "", err
+	}
+
+	return account.Type, nil
+}
+
+// FetchRepositories fetches a page of the repositories of an owner, newest
+// first. Organizations are listed through /orgs so internal and private
+// repositories visible to the token are included.
+func (gp GithubRestClient) FetchRepositories(owner, ownerType string, perPage, page int) (RepositoriesPage, error) {
+	path := fmt.Sprintf("/users/%s/repos", owner)
 	queryParams := map[string]string{
 		"sort":      "created",
 		"direction": "desc",
 		"per_page":  fmt.Sprintf("%d", perPage),
 		"page":      fmt.Sprintf("%d", page),
 	}
+	if ownerType == constants.OWNER_TYPE_ORGANIZATION {
+		path = fmt.Sprintf("/orgs/%s/repos", owner)
+		queryParams["type"] = "all"
+	}
 
-	fetchRepoUrl := buildURI(baseURL, path, queryParams)
+	fetchRepoUrl := buildURI(gp.baseURL, path, queryParams)
 
-	request, err := http.NewRequest(http.MethodGet, fetchRepoUrl, nil)
+	response, err := gp.get(fetchRepoUrl)
 	if err != nil {
 		log.Println("RDS: ", err)
-		return nil, err
+		return RepositoriesPage{}, err
 	}
 
-	request.Header.Add("Accept", "application/vnd.github+json")
-	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", gp.Config.GithubToken))
-	request.Header.Add("X-GitHub-Api-Version", "2022-11-28")
+	if response.statusCode != http.StatusOK {
+		log.Println("RDS: unexpected status code: ", response.statusCode)
+		return RepositoriesPage{}, fmt.Errorf("RDS: unexpected status code: %d", response.statusCode)
+	}
 
-	response, err := http.DefaultClient.Do(request)
+	var repositories []models.RepositoryResponse
+	err = json.Unmarshal(response.body, &repositories)
 	if err != nil {
-		log.Println("RDS: ", err)
-		return nil, err
+		log.Println("RDS: error unmarshalling response body: ", err)
+		return RepositoriesPage{}, err
 	}
-	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusOK {
-		log.Println("RDS: unexpected status code: ", response.StatusCode)
-		return nil, fmt.Errorf("RDS: unexpected status code: %d", response.StatusCode)
-	}
+	pages := paginationOf(response.link)
+	return RepositoriesPage{
+		Repositories: repositories,
+		NextPage:     pages.next,
+		LastPage:     pages.last,
+		NotModified:  response.notModified,
+	}, nil
+}
 
-	bodyBytes, err := io.ReadAll(response.Body)
+// FetchRepositoryMetadata fetches a repository by its full name (owner/name).
+func (gp GithubRestClient) FetchRepositoryMetadata(fullName string) (RepositoryMetadata, error) {
+	path := fmt.Sprintf("/repos/%s", fullName)
+
+	fetchRepoUrl := buildURI(gp.baseURL, path, nil)
+
+	response, err := gp.get(fetchRepoUrl)
 	if err != nil {
-		log.Println("RDS: error reading response body: ", err)
-		return nil, err
+		log.Println("RDS: ", err)
+		return RepositoryMetadata{}, err
 	}
 
-	var repositories []models.RepositoryResponse
-	err = json.Unmarshal(bodyBytes, &repositories)
+	if response.statusCode != http.StatusOK {
+		log.Println("RDS: fetch metaData unexpected status code: ", response.statusCode)
+		return RepositoryMetadata{}, fmt.Errorf("RDS: unexpected status code: %d", response.statusCode)
+	}
+
+	var repository models.RepositoryResponse
+	err = json.Unmarshal(response.body, &repository)
 	if err != nil {
 		log.Println("RDS: error unmarshalling response body: ", err)
-		return nil, err
+		return RepositoryMetadata{}, err
 	}
 
-	return repositories, nil
+	return RepositoryMetadata{Repository: repository, NotModified: response.notModified}, nil
 }
 
-func (gp GithubRestClient) FetchRepositoryMetadata(repoName string) (models.RepositoryResponse, error) {
-	path := fmt.Sprintf("/repos/%s/%s", gp.Config.GithubUsername, repoName)
-
-	fetchRepoUrl := buildURI(baseURL, path, nil)
+// apiResponse is a GitHub response with its body already read.
+type apiResponse struct {
+	statusCode  int
+	link        string
+	body        []byte
+	notModified bool
+}
 
-	request, err := http.NewRequest(http.MethodGet, fetchRepoUrl, nil)
+// get sends a conditional GET request. When GitHub answers 304 the body of
+// the cached response is returned and notModified is set.
+func (gp GithubRestClient) get(requestUrl string) (apiResponse, error) {
+	request, err := http.NewRequest(http.MethodGet, requestUrl, nil)
 	if err != nil {
-		log.Println("RDS: ", err)
-		return models.RepositoryResponse{}, err
+		return apiResponse{}, err
 	}
 
 	request.Header.Add("Accept", "application/vnd.github+json")
-	request.Header.Add("Authorization", fmt.Sprintf("Bearer %s", gp.Config.GithubToken))
 	request.Header.Add("X-GitHub-Api-Version", "2022-11-28")
 
-	response, err := http.DefaultClient.Do(request)
+	cached, isCached := gp.cache.get(requestUrl)
+	if isCached {
+		if cached.etag != "" {
+			request.Header.Add("If-None-Match", cached.etag)
+		}
+		if cached.lastModified != "" {
+			request.Header.Add("If-Modified-Since", cached.lastModified)
+		}
+	}
+
+	response, err := gp.pool.do(request)
 	if err != nil {
-		log.Println("RDS: ", err)
-		return models.RepositoryResponse{}, err
+		return apiResponse{}, err
 	}
 	defer response.Body.Close()
 
-	if response.StatusCode != http.StatusOK {
-		log.Println("RDS: fetch metaData unexpected status code: ", response.StatusCode)
-		return models.RepositoryResponse{}, fmt.Errorf("RDS: unexpected status code: %d", response.StatusCode)
+	if response.StatusCode == http.StatusNotModified && isCached {
+		return apiResponse{
+			statusCode:  http.StatusOK,
+			link:        cached.link,
+			body:        cached.body,
+			notModified: true,
+		}, nil
 	}
 
 	bodyBytes, err := io.ReadAll(response.Body)
 	if err != nil {
 		log.Println("RDS: error reading response body: ", err)
-		return models.RepositoryResponse{}, err
+		return apiResponse{}, err
 	}
 
-	var repository models.RepositoryResponse
-	err = json.Unmarshal(bodyBytes, &repository)
-	if err != nil {
-		log.Println("RDS: error unmarshalling response body: ", err)
-		return models.RepositoryResponse{}, err
+	if response.StatusCode == http.StatusOK {
+		gp.cache.set(requestUrl, cachedResponse{
+			etag:         response.Header.Get("ETag"),
+			lastModified: response.Header.Get("Last-Modified"),
+			link:         response.Header.Get("Link"),
+			body:         bodyBytes,
+		})
 	}
 
-	return repository, nil
+	return apiResponse{
+		statusCode: response.StatusCode,
+		link:       response.Header.Get("Link"),
+		body:       bodyBytes,
+	}, nil
 }
@@ -0,0 +1,32 @@
+package githubrestclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLinkHeader(t *testing.T) {
+	header := `<https://api.github.com/repositories/1/commits?page=2&per_page=10>; rel="next", ` +
+		`<https://api.github.com/repositories/1/commits?page=34&per_page=10>; rel="last"`
+
+	links := parseLinkHeader(header)
+	require.Len(t, links, 2)
+	require.Equal(t, "https://api.github.com/repositories/1/commits?page=2&per_page=10", links["next"])
+
+	pages := paginationOf(header)
+	require.Equal(t, 2, pages.next)
+	require.Equal(t, 34, pages.last)
+}
+
+func TestPaginationOfLastPage(t *testing.T) {
+	header := `<https://api.github.com/repositories/1/commits?page=33&per_page=10>; rel="prev", ` +
+		`<https://api.github.com/repositories/1/commits?page=1&per_page=10>; rel="first"`
+
+	pages := paginationOf(header)
+	require.Zero(t, pages.next)
+	require.Zero(t, pages.last)
+
+	require.Equal(t, pagination{}, paginationOf(""))
+	require.Equal(t, pagination{}, paginationOf("garbage; rel=next"))
+}
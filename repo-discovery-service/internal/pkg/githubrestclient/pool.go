@@ -0,0 +1,209 @@
+package githubrestclient
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"repos-discovery-service/internal/constants/models"
+	"sync"
+	"time"
+)
+
+// unauthorizedParkDuration is how long a token answered with 401 is left
+// out of rotation before it is tried again.
+const unauthorizedParkDuration = 1 * time.Hour
+
+// errNoUsableToken is returned when every token of the pool was rejected.
+var errNoUsableToken = errors.New("RDS: every GitHub token was rejected as unauthorized")
+
+// TokenUsage describes how a token of the pool has been used.
+type TokenUsage struct {
+	Token       string
+	RateLimit   RateLimit
+	Requests    int
+	ParkedUntil time.Time
+	// Unauthorized is set while the token is parked after a 401.
+	Unauthorized bool
+}
+
+// credential is a token of the pool together with its own budget.
+type credential struct {
+	name    string
+	tokens  tokenSource
+	limiter *rateLimiter
+
+	mu                sync.Mutex
+	requests          int
+	unauthorizedUntil time.Time
+}
+
+func newCredential(name string, tokens tokenSource) *credential {
+	return &credential{name: name, tokens: tokens, limiter: newRateLimiter()}
+}
+
+// record updates the budget and counters of the credential from a response.
+func (c *credential) record(response *http.Response) {
+	c.limiter.update(response.Header)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.requests++
+	if response.StatusCode == http.StatusUnauthorized {
+		c.unauthorizedUntil = time.Now().Add(unauthorizedParkDuration)
+	}
+}
+
+func (c *credential) unauthorized(now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return now.Before(c.unauthorizedUntil)
+}
+
+// remaining is the budget left on the credential. Tokens that were not used
+// yet are assumed to have their full budget.
+func (c *credential) remaining() int {
+	budget := c.limiter.status()
+	if !budget.Known() || !time.Now().Before(budget.Reset) {
+		return math.MaxInt
+	}
+	return budget.Remaining
+}
+
+func (c *credential) usage(now time.Time) TokenUsage {
+	parkedUntil := now.Add(c.limiter.delay(now))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	unauthorized := now.Before(c.unauthorizedUntil)
+	if unauthorized {
+		parkedUntil = c.unauthorizedUntil
+	}
+	if !parkedUntil.After(now) {
+		parkedUntil = time.Time{}
+	}
+	return TokenUsage{
+		Token:        c.name,
+		RateLimit:    c.limiter.status(),
+		Requests:     c.requests,
+		ParkedUntil:  parkedUntil,
+		Unauthorized: unauthorized,
+	}
+}
+
+// tokenPool rotates requests over several GitHub credentials. Requests go
+// to the token with the most remaining budget; tokens that hit their limit
+// are parked until the reset and tokens answered with 401 are parked for
+// unauthorizedParkDuration.
+type tokenPool struct {
+	httpClient  *http.Client
+	credentials []*credential
+}
+
+// newTokenPool builds the pool from the configured personal access tokens
+// and, when configured, the GitHub App installation.
+func newTokenPool(config *models.Config, baseURL string, httpClient *http.Client) (*tokenPool, error) {
+	pool := &tokenPool{httpClient: httpClient}
+
+	seen := make(map[string]bool)
+	for _, token := range append([]string{config.GithubToken}, config.GithubTokens...) {
+		if token == "" || seen[token] {
+			continue
+		}
+		seen[token] = true
+		pool.credentials = append(pool.credentials, newCredential(maskToken(token), staticToken(token)))
+	}
+
+	if config.GithubAppID != "" {
+		source, err := newAppTokenSource(config, baseURL, httpClient)
+		if err != nil {
+			return nil, err
+		}
+		pool.credentials = append(pool.credentials, newCredential("app-"+config.GithubAppID, source))
+	}
+
+	if len(pool.credentials) == 0 {
+		pool.credentials = append(pool.credentials, newCredential("anonymous", staticToken("")))
+	}
+
+	return pool, nil
+}
+
+// acquire returns the credential to send the next request with, waiting
+// while every token is parked or short of budget.
+func (p *tokenPool) acquire() (*credential, error) {
+	for {
+		now := time.Now()
+
+		var best *credential
+		var bestDelay time.Duration
+		for _, c := range p.credentials {
+			if c.unauthorized(now) {
+				continue
+			}
+			d := c.limiter.delay(now)
+			if best == nil || d < bestDelay || (d == bestDelay && c.remaining() > best.remaining()) {
+				best, bestDelay = c, d
+			}
+		}
+
+		if best == nil {
+			return nil, errNoUsableToken
+		}
+		if bestDelay <= 0 {
+			return best, nil
+		}
+
+		if bestDelay > time.Second {
+			log.Printf("RDS: every GitHub token is short of budget, waiting %s\n", bestDelay.Round(time.Second))
+		}
+		time.Sleep(bestDelay)
+	}
+}
+
+// rateLimit sums up the budget of the usable tokens. Reset is the earliest
+// reset among them, or zero while a token has no known budget yet.
+func (p *tokenPool) rateLimit() RateLimit {
+	now := time.Now()
+
+	var total RateLimit
+	var unknown bool
+	for _, c := range p.credentials {
+		if c.unauthorized(now) {
+			continue
+		}
+		budget := c.limiter.status()
+		if !budget.Known() || !now.Before(budget.Reset) {
+			unknown = true
+			continue
+		}
+		total.Limit += budget.Limit
+		total.Remaining += budget.Remaining
+		total.Used += budget.Used
+		if total.Reset.IsZero() || budget.Reset.Before(total.Reset) {
+			total.Reset = budget.Reset
+		}
+	}
+	if unknown {
+		total.Reset = time.Time{}
+	}
+	return total
+}
+
+func (p *tokenPool) usage() []TokenUsage {
+	now := time.Now()
+	usage := make([]TokenUsage, 0, len(p.credentials))
+	for _, c := range p.credentials {
+		usage = append(usage, c.usage(now))
+	}
+	return usage
+}
+
+// maskToken keeps just enough of a token to tell tokens apart in logs.
+func maskToken(token string) string {
+	if len(token) <= 12 {
+		return "****"
+	}
+	return fmt.Sprintf("%s…%s", token[:4], token[len(token)-4:])
+}
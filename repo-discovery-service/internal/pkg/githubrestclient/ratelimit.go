@@ -0,0 +1,257 @@
+package githubrestclient
+
+import (
+	"bytes"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// maxRetries is the number of times a request is retried after a
+	// rate limit or a transient server error before giving up.
+	maxRetries = 5
+
+	// reserveRatio is the share of the hourly budget below which requests
+	// are spread evenly over the time left until the budget resets.
+	reserveRatio = 0.1
+
+	baseBackoff = 1 * time.Second
+	maxBackoff  = 1 * time.Minute
+
+	// secondaryRateLimitWait is how long GitHub asks clients to wait after a
+	// secondary rate limit response that carries no Retry-After header.
+	secondaryRateLimitWait = 1 * time.Minute
+)
+
+// RateLimit is the request budget reported by GitHub on the latest response.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Used      int
+	Reset     time.Time
+}
+
+// Known reports whether GitHub has sent rate limit headers yet.
+func (rl RateLimit) Known() bool {
+	return rl.Limit > 0
+}
+
+// Exhausted reports whether no requests are left until the budget resets.
+func (rl RateLimit) Exhausted() bool {
+	return rl.Known() && rl.Remaining <= 0 && time.Now().Before(rl.Reset)
+}
+
+// rateLimiter tracks the budget of a token and holds requests back when the
+// budget is low or GitHub asked us to wait.
+type rateLimiter struct {
+	mu         sync.Mutex
+	budget     RateLimit
+	pauseUntil time.Time
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{}
+}
+
+// status returns a snapshot of the current budget.
+func (rl *rateLimiter) status() RateLimit {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	return rl.budget
+}
+
+// delay returns how long the next request has to wait.
+func (rl *rateLimiter) delay(now time.Time) time.Duration {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	if now.Before(rl.pauseUntil) {
+		return rl.pauseUntil.Sub(now)
+	}
+
+	budget := rl.budget
+	if !budget.Known() || !now.Before(budget.Reset) {
+		return 0
+	}
+	if budget.Remaining <= 0 {
+		return budget.Reset.Sub(now)
+	}
+	if float64(budget.Remaining) < float64(budget.Limit)*reserveRatio {
+		return budget.Reset.Sub(now) / time.Duration(budget.Remaining)
+	}
+	return 0
+}
+
+// update records the budget reported by the X-RateLimit-* headers.
+func (rl *rateLimiter) update(header http.Header) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, _ := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	used, _ := strconv.Atoi(header.Get("X-RateLimit-Used"))
+	reset, _ := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	rl.budget = RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+		Used:      used,
+		Reset:     time.Unix(reset, 0),
+	}
+}
+
+// pause holds every request back until the given time.
+func (rl *rateLimiter) pause(until time.Time) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	if until.After(rl.pauseUntil) {
+		rl.pauseUntil = until
+	}
+}
+
+// retryDelay decides whether a response should be retried and how long to
+// wait before doing so. Rate limited responses also pause the limiter so
+// concurrent requests wait as well.
+func (rl *rateLimiter) retryDelay(response *http.Response, attempt int) (time.Duration, bool) {
+	switch {
+	case response.StatusCode == http.StatusForbidden || response.StatusCode == http.StatusTooManyRequests:
+		now := time.Now()
+		if retryAfter, ok := parseRetryAfter(response.Header.Get("Retry-After"), now); ok {
+			rl.pause(now.Add(retryAfter))
+			return retryAfter, true
+		}
+		if response.Header.Get("X-RateLimit-Remaining") == "0" {
+			reset, err := strconv.ParseInt(response.Header.Get("X-RateLimit-Reset"), 10, 64)
+			if err == nil {
+				until := time.Unix(reset, 0)
+				rl.pause(until)
+				return until.Sub(now), true
+			}
+		}
+		if isSecondaryRateLimit(response) {
+			rl.pause(now.Add(secondaryRateLimitWait))
+			return secondaryRateLimitWait, true
+		}
+		return 0, false
+	case response.StatusCode >= http.StatusInternalServerError:
+		return backoff(attempt), true
+	}
+	return 0, false
+}
+
+// parseRetryAfter reads a Retry-After header given either in seconds or as
+// an HTTP date.
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return date.Sub(now), true
+	}
+	return 0, false
+}
+
+// isSecondaryRateLimit peeks at a 403 body for GitHub's secondary rate
+// limit message. The body is restored so callers can still read it.
+func isSecondaryRateLimit(response *http.Response) bool {
+	if response.Body == nil {
+		return false
+	}
+	body, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	response.Body = io.NopCloser(bytes.NewReader(body))
+	if err != nil {
+		return false
+	}
+	return strings.Contains(strings.ToLower(string(body)), "secondary rate limit")
+}
+
+// backoff returns an exponential delay with full jitter for the attempt.
+func backoff(attempt int) time.Duration {
+	d := baseBackoff << attempt
+	if d <= 0 || d > maxBackoff {
+		d = maxBackoff
+	}
+	return time.Duration(rand.Int63n(int64(d))) + baseBackoff
+}
+
+// do sends the request with the pool's best token, waiting for rate limit
+// budget first and retrying rate limited and transient server errors.
+func (p *tokenPool) do(request *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		cred, err := p.acquire()
+		if err != nil {
+			return nil, err
+		}
+		if attempt > 0 && request.GetBody != nil {
+			if request.Body, err = request.GetBody(); err != nil {
+				return nil, err
+			}
+		}
+		token, err := cred.tokens.token()
+		if err != nil {
+			return nil, err
+		}
+		if token != "" {
+			request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
+		}
+
+		response, err := p.httpClient.Do(request)
+		if err != nil {
+			if attempt >= maxRetries || isCertificateError(err) {
+				return nil, err
+			}
+			d := backoff(attempt)
+			log.Printf("RDS: request to %s failed, retrying in %s: %v\n", request.URL.Path, d.Round(time.Millisecond), err)
+			time.Sleep(d)
+			continue
+		}
+
+		cred.record(response)
+
+		if response.StatusCode == http.StatusUnauthorized {
+			if attempt >= maxRetries || len(p.credentials) == 1 {
+				return response, nil
+			}
+			response.Body.Close()
+			log.Printf("RDS: token %s is unauthorized, parking it for %s\n", cred.name, unauthorizedParkDuration)
+			continue
+		}
+
+		d, retry := cred.limiter.retryDelay(response, attempt)
+		if !retry || attempt >= maxRetries {
+			return response, nil
+		}
+		response.Body.Close()
+
+		if response.StatusCode < http.StatusInternalServerError {
+			// the token is paused now, acquire picks another one or waits
+			log.Printf("RDS: token %s is rate limited for %s\n", cred.name, d.Round(time.Second))
+			continue
+		}
+
+		log.Printf("RDS: %s returned %d, retrying in %s\n", request.URL.Path, response.StatusCode, d.Round(time.Millisecond))
+		time.Sleep(d)
+	}
+}
+
+// isCertificateError reports whether the server certificate was rejected,
+// which retrying does not fix.
+func isCertificateError(err error) bool {
+	var verificationErr *tls.CertificateVerificationError
+	return errors.As(err, &verificationErr)
+}
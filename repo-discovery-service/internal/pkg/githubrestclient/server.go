@@ -0,0 +1,53 @@
+package githubrestclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"net/http"
+	"os"
+	"repos-discovery-service/internal/constants/models"
+	"strings"
+	"time"
+)
+
+const defaultBaseURL = "https://api.github.com"
+
+// apiURL returns the root of the REST API of the configured server.
+func apiURL(config *models.Config) string {
+	if config.GithubAPIURL == "" {
+		return defaultBaseURL
+	}
+	return strings.TrimSuffix(config.GithubAPIURL, "/")
+}
+
+// newHTTPClient returns the client used to talk to the configured server,
+// trusting its CA bundle when one is set.
+func newHTTPClient(config *models.Config) (*http.Client, error) {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	if config.GithubCACert == "" {
+		return httpClient, nil
+	}
+
+	data := []byte(config.GithubCACert)
+	if !strings.Contains(config.GithubCACert, "-----BEGIN") {
+		var err error
+		data, err = os.ReadFile(config.GithubCACert)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	if !roots.AppendCertsFromPEM(data) {
+		return nil, errors.New("RDS: github CA bundle contains no PEM encoded certificate")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: roots}
+	httpClient.Transport = transport
+	return httpClient, nil
+}
@@ -0,0 +1,357 @@
+package gitlabclient
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"repos-discovery-service/internal/constants"
+	"repos-discovery-service/internal/constants/models"
+	"repos-discovery-service/internal/pkg/githubrestclient"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const defaultBaseURL = "https://gitlab.com/api/v4"
+
+// maxRetries is the number of times a rate limited request is retried
+// before giving up.
+const maxRetries = 5
+
+// GitlabClient discovers repositories through the GitLab REST API v4. Groups
+// and users take the place of GitHub organizations and users, projects the
+// place of repositories.
+type GitlabClient struct {
+	Config     *models.Config
+	baseURL    string
+	httpClient *http.Client
+	usage      *tokenUsage
+}
+
+func NewGitlabClient(Config *models.Config) (GitlabClient, error) {
+	httpClient, err := newHTTPClient(Config.GitlabCACert)
+	if err != nil {
+		return GitlabClient{}, err
+	}
+
+	baseURL := defaultBaseURL
+	if Config.GitlabAPIURL != "" {
+		baseURL = strings.TrimSuffix(Config.GitlabAPIURL, "/")
+	}
+
+	return GitlabClient{
+		Config:     Config,
+		baseURL:    baseURL,
+		httpClient: httpClient,
+		usage:      &tokenUsage{},
+	}, nil
+}
+
+// Name returns the provider the discovered repositories are tagged with.
+func (gl GitlabClient) Name() string {
+	return constants.PROVIDER_GITLAB
+}
+
+// RateLimit returns the request budget reported by GitLab on the latest
+// response.
+func (gl GitlabClient) RateLimit() githubrestclient.RateLimit {
+	gl.usage.mu.Lock()
+	defer gl.usage.mu.Unlock()
+	return gl.usage.budget
+}
+
+// TokenUsage returns the budget and usage of the configured token.
+func (gl GitlabClient) TokenUsage() []githubrestclient.TokenUsage {
+	gl.usage.mu.Lock()
+	defer gl.usage.mu.Unlock()
+	return []githubrestclient.TokenUsage{{
+		Token:     "gitlab " + maskToken(gl.Config.GitlabToken),
+		RateLimit: gl.usage.budget,
+		Requests:  gl.usage.requests,
+	}}
+}
+
+// FetchOwnerType returns the type of a GitLab namespace, groups being
+// reported as constants.OWNER_TYPE_ORGANIZATION.
+func (gl GitlabClient) FetchOwnerType(owner string) (string, error) {
+	response, err := gl.get(gl.uri("/namespaces/"+url.PathEscape(owner), nil))
+	if err != nil {
+		log.Println("RDS: ", err)
+		return "", err
+	}
+
+	var namespace namespace
+	if err := json.Unmarshal(response.body, &namespace); err != nil {
+		log.Println("RDS: error unmarshalling response body: ", err)
+		return "", err
+	}
+	return namespace.ownerType(), nil
+}
+
+// FetchRepositories fetches a page of the projects of a group, subgroups
+// included, or of a user, newest first.
+func (gl GitlabClient) FetchRepositories(owner, ownerType string, perPage, page int) (githubrestclient.RepositoriesPage, error) {
+	path := fmt.Sprintf("/users/%s/projects", url.PathEscape(owner))
+	queryParams := map[string]string{
+		"order_by": "created_at",
+		"sort":     "desc",
+		"per_page": strconv.Itoa(perPage),
+		"page":     strconv.Itoa(page),
+	}
+	if ownerType == constants.OWNER_TYPE_ORGANIZATION {
+		path = fmt.Sprintf("/groups/%s/projects", url.PathEscape(owner))
+		queryParams["include_subgroups"] = "true"
+	}
+
+	response, err := gl.get(gl.uri(path, queryParams))
+	if err != nil {
+		log.Println("RDS: ", err)
+		return githubrestclient.RepositoriesPage{}, err
+	}
+
+	var projects []project
+	if err := json.Unmarshal(response.body, &projects); err != nil {
+		log.Println("RDS: error unmarshalling response body: ", err)
+		return githubrestclient.RepositoriesPage{}, err
+	}
+
+	repositories := make([]models.RepositoryResponse, 0, len(projects))
+	for _, project := range projects {
+		repositories = append(repositories, project.repository())
+	}
+
+	nextPage, _ := strconv.Atoi(response.header.Get("X-Next-Page"))
+	lastPage, _ := strconv.Atoi(response.header.Get("X-Total-Pages"))
+	if nextPage == 0 {
+		lastPage = 0
+	}
+	return githubrestclient.RepositoriesPage{
+		Repositories: repositories,
+		NextPage:     nextPage,
+		LastPage:     lastPage,
+	}, nil
+}
+
+// FetchRepositoryMetadata fetches a project by its full path.
+func (gl GitlabClient) FetchRepositoryMetadata(fullName string) (githubrestclient.RepositoryMetadata, error) {
+	response, err := gl.get(gl.uri("/projects/"+url.PathEscape(fullName), nil))
+	if err != nil {
+		log.Println("RDS: ", err)
+		return githubrestclient.RepositoryMetadata{}, err
+	}
+
+	var project project
+	if err := json.Unmarshal(response.body, &project); err != nil {
+		log.Println("RDS: error unmarshalling response body: ", err)
+		return githubrestclient.RepositoryMetadata{}, err
+	}
+	return githubrestclient.RepositoryMetadata{Repository: project.repository()}, nil
+}
+
+// namespace is a group or user as returned by GET /namespaces/:id.
+type namespace struct {
+	Kind     string `json:"kind"`
+	FullPath string `json:"full_path"`
+}
+
+func (n namespace) ownerType() string {
+	if n.Kind == "group" {
+		return constants.OWNER_TYPE_ORGANIZATION
+	}
+	return constants.OWNER_TYPE_USER
+}
+
+// project is a project as returned by GET /projects/:id.
+type project struct {
+	ID                int       `json:"id"`
+	Path              string    `json:"path"`
+	PathWithNamespace string    `json:"path_with_namespace"`
+	Description       *string   `json:"description"`
+	WebURL            string    `json:"web_url"`
+	DefaultBranch     string    `json:"default_branch"`
+	Visibility        string    `json:"visibility"`
+	Archived          bool      `json:"archived"`
+	Topics            []string  `json:"topics"`
+	ForksCount        int       `json:"forks_count"`
+	StarCount         int       `json:"star_count"`
+	OpenIssuesCount   int       `json:"open_issues_count"`
+	CreatedAt         time.Time `json:"created_at"`
+	LastActivityAt    time.Time `json:"last_activity_at"`
+	Namespace         namespace `json:"namespace"`
+	ForkedFromProject *struct {
+		ID int `json:"id"`
+	} `json:"forked_from_project"`
+}
+
+// repository maps a project to the shape of a GitHub repository.
+func (p project) repository() models.RepositoryResponse {
+	var repository models.RepositoryResponse
+	repository.ID = p.ID
+	repository.Name = p.Path
+	repository.FullName = p.PathWithNamespace
+	repository.Private = p.Visibility != "public"
+	repository.Owner.Login = p.Namespace.FullPath
+	repository.Owner.Type = p.Namespace.ownerType()
+	repository.HTMLURL = p.WebURL
+	if p.Description != nil {
+		repository.Description = *p.Description
+	}
+	repository.Fork = p.ForkedFromProject != nil
+	repository.CreatedAt = p.CreatedAt
+	repository.UpdatedAt = p.LastActivityAt
+	repository.PushedAt = p.LastActivityAt
+	repository.ForksCount = p.ForksCount
+	repository.Forks = p.ForksCount
+	repository.StargazersCount = p.StarCount
+	repository.OpenIssuesCount = p.OpenIssuesCount
+	repository.OpenIssues = p.OpenIssuesCount
+	repository.Archived = p.Archived
+	repository.Visibility = p.Visibility
+	repository.DefaultBranch = p.DefaultBranch
+	for _, topic := range p.Topics {
+		repository.Topics = append(repository.Topics, topic)
+	}
+	repository.Provider = constants.PROVIDER_GITLAB
+	return repository
+}
+
+func (gl GitlabClient) uri(path string, queryParams map[string]string) string {
+	query := url.Values{}
+	for key, value := range queryParams {
+		query.Set(key, value)
+	}
+	uri := gl.baseURL + path
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
+	}
+	return uri
+}
+
+// apiResponse is a successful GitLab response with its body already read.
+type apiResponse struct {
+	header http.Header
+	body   []byte
+}
+
+// get sends a GET request, waiting and retrying while GitLab rate limits
+// it. Any status but 200 is returned as an error.
+func (gl GitlabClient) get(requestUrl string) (apiResponse, error) {
+	for attempt := 0; ; attempt++ {
+		request, err := http.NewRequest(http.MethodGet, requestUrl, nil)
+		if err != nil {
+			return apiResponse{}, err
+		}
+		if gl.Config.GitlabToken != "" {
+			request.Header.Set("PRIVATE-TOKEN", gl.Config.GitlabToken)
+		}
+
+		response, err := gl.httpClient.Do(request)
+		if err != nil {
+			return apiResponse{}, err
+		}
+		gl.usage.record(response.Header)
+
+		body, err := io.ReadAll(response.Body)
+		response.Body.Close()
+		if err != nil {
+			log.Println("RDS: error reading response body: ", err)
+			return apiResponse{}, err
+		}
+
+		if response.StatusCode == http.StatusTooManyRequests && attempt < maxRetries {
+			wait := retryAfter(response.Header, time.Now())
+			log.Printf("RDS: gitlab rate limited %s, retrying in %s\n", request.URL.Path, wait.Round(time.Second))
+			time.Sleep(wait)
+			continue
+		}
+		if response.StatusCode != http.StatusOK {
+			return apiResponse{}, fmt.Errorf("RDS: gitlab responded with status %d", response.StatusCode)
+		}
+		return apiResponse{header: response.Header, body: body}, nil
+	}
+}
+
+// retryAfter reads how long a rate limited request has to wait from the
+// Retry-After or RateLimit-Reset header.
+func retryAfter(header http.Header, now time.Time) time.Duration {
+	if seconds, err := strconv.Atoi(header.Get("Retry-After")); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if reset, err := strconv.ParseInt(header.Get("RateLimit-Reset"), 10, 64); err == nil && time.Unix(reset, 0).After(now) {
+		return time.Unix(reset, 0).Sub(now)
+	}
+	return time.Minute
+}
+
+// tokenUsage tracks the budget GitLab reports in the RateLimit headers.
+type tokenUsage struct {
+	mu       sync.Mutex
+	budget   githubrestclient.RateLimit
+	requests int
+}
+
+func (u *tokenUsage) record(header http.Header) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+	u.requests++
+
+	limit, err := strconv.Atoi(header.Get("RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, _ := strconv.Atoi(header.Get("RateLimit-Remaining"))
+	reset, _ := strconv.ParseInt(header.Get("RateLimit-Reset"), 10, 64)
+	u.budget = githubrestclient.RateLimit{
+		Limit:     limit,
+		Remaining: remaining,
+		Used:      limit - remaining,
+		Reset:     time.Unix(reset, 0),
+	}
+}
+
+// maskToken keeps just enough of a token to tell tokens apart in logs.
+func maskToken(token string) string {
+	if len(token) <= 12 {
+		return "****"
+	}
+	return fmt.Sprintf("%s…%s", token[:4], token[len(token)-4:])
+}
+
+// newHTTPClient returns the client used to talk to GitLab, trusting the CA
+// bundle, given as PEM contents or file path, when one is set.
+func newHTTPClient(caCert string) (*http.Client, error) {
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	if caCert == "" {
+		return httpClient, nil
+	}
+
+	data := []byte(caCert)
+	if !strings.Contains(caCert, "-----BEGIN") {
+		var err error
+		data, err = os.ReadFile(caCert)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	roots, err := x509.SystemCertPool()
+	if err != nil {
+		roots = x509.NewCertPool()
+	}
+	if !roots.AppendCertsFromPEM(data) {
+		return nil, errors.New("RDS: gitlab CA bundle contains no PEM encoded certificate")
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = &tls.Config{RootCAs: roots}
+	httpClient.Transport = transport
+	return httpClient, nil
+}
@@ -0,0 +1,230 @@
+package localgitclient
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"repos-discovery-service/internal/constants"
+	"repos-discovery-service/internal/constants/models"
+	"repos-discovery-service/internal/pkg/githubrestclient"
+	"strings"
+	"time"
+)
+
+// ErrNotFound is returned for repositories and owners missing on disk.
+var ErrNotFound = errors.New("RDS: local repository not found")
+
+// LocalGitClient discovers git repositories in a directory laid out as
+// <owner>/<name>, for mirrors that cannot reach any API. A repository is a
+// working copy or a bare repository, <name>.git, and is read with the git
+// command.
+type LocalGitClient struct {
+	Config *models.Config
+	root   string
+}
+
+func NewLocalGitClient(Config *models.Config) (LocalGitClient, error) {
+	root, err := filepath.Abs(Config.LocalReposDir)
+	if err != nil {
+		return LocalGitClient{}, err
+	}
+	// git compares safe.directory with the resolved path of a repository
+	if resolved, err := filepath.EvalSymlinks(root); err == nil {
+		root = resolved
+	}
+	if _, err := exec.LookPath("git"); err != nil {
+		return LocalGitClient{}, fmt.Errorf("RDS: local repositories need the git command: %w", err)
+	}
+	return LocalGitClient{Config: Config, root: root}, nil
+}
+
+// Name returns the provider the discovered repositories are tagged with.
+func (lg LocalGitClient) Name() string {
+	return constants.PROVIDER_LOCAL
+}
+
+// Owners lists the owner directories of the repositories directory.
+func (lg LocalGitClient) Owners() ([]models.Owner, error) {
+	entries, err := os.ReadDir(lg.root)
+	if err != nil {
+		return nil, err
+	}
+
+	var owners []models.Owner
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			owners = append(owners, models.Owner{Login: entry.Name()})
+		}
+	}
+	return owners, nil
+}
+
+// RateLimit returns no budget, reading from disk is not limited.
+func (lg LocalGitClient) RateLimit() githubrestclient.RateLimit {
+	return githubrestclient.RateLimit{}
+}
+
+// TokenUsage returns nothing, no token is used.
+func (lg LocalGitClient) TokenUsage() []githubrestclient.TokenUsage {
+	return nil
+}
+
+// FetchOwnerType reports every owner directory as an organization.
+func (lg LocalGitClient) FetchOwnerType(owner string) (string, error) {
+	if _, err := lg.ownerDir(owner); err != nil {
+		return "", err
+	}
+	return constants.OWNER_TYPE_ORGANIZATION, nil
+}
+
+// FetchRepositories lists a page of the repositories of an owner directory
+// in name order.
+func (lg LocalGitClient) FetchRepositories(owner, ownerType string, perPage, page int) (githubrestclient.RepositoriesPage, error) {
+	dir, err := lg.ownerDir(owner)
+	if err != nil {
+		return githubrestclient.RepositoriesPage{}, err
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return githubrestclient.RepositoriesPage{}, err
+	}
+
+	var names []string
+	for _, entry := range entries {
+		if entry.IsDir() && isRepository(filepath.Join(dir, entry.Name())) {
+			names = append(names, entry.Name())
+		}
+	}
+
+	start := min((page-1)*perPage, len(names))
+	end := min(start+perPage, len(names))
+
+	repositories := make([]models.RepositoryResponse, 0, end-start)
+	for _, name := range names[start:end] {
+		repositories = append(repositories, lg.repository(owner, filepath.Join(dir, name)))
+	}
+
+	if end == len(names) {
+		return githubrestclient.RepositoriesPage{Repositories: repositories}, nil
+	}
+	return githubrestclient.RepositoriesPage{
+		Repositories: repositories,
+		NextPage:     page + 1,
+		LastPage:     (len(names) + perPage - 1) / perPage,
+	}, nil
+}
+
+// FetchRepositoryMetadata reads a repository given by its full name.
+func (lg LocalGitClient) FetchRepositoryMetadata(fullName string) (githubrestclient.RepositoryMetadata, error) {
+	owner, name, ok := strings.Cut(fullName, "/")
+	if !ok {
+		return githubrestclient.RepositoryMetadata{}, fmt.Errorf("%w: %s", ErrNotFound, fullName)
+	}
+	ownerDir, err := lg.ownerDir(owner)
+	if err != nil {
+		return githubrestclient.RepositoryMetadata{}, err
+	}
+	for _, dir := range []string{name, name + ".git"} {
+		if path := filepath.Join(ownerDir, dir); isPathElement(name) && isRepository(path) {
+			return githubrestclient.RepositoryMetadata{Repository: lg.repository(owner, path)}, nil
+		}
+	}
+	return githubrestclient.RepositoryMetadata{}, fmt.Errorf("%w: %s", ErrNotFound, fullName)
+}
+
+// repository reads a repository in the shape of a GitHub repository. It is
+// created with its oldest root commit and updated with its newest branch.
+func (lg LocalGitClient) repository(owner string, dir string) models.RepositoryResponse {
+	var repository models.RepositoryResponse
+	repository.Name = strings.TrimSuffix(filepath.Base(dir), ".git")
+	repository.FullName = owner + "/" + repository.Name
+	repository.Owner.Login = owner
+	repository.Owner.Type = constants.OWNER_TYPE_ORGANIZATION
+	repository.HTMLURL = "file://" + filepath.ToSlash(dir)
+	repository.URL = repository.HTMLURL
+	repository.Provider = constants.PROVIDER_LOCAL
+
+	if description, err := os.ReadFile(filepath.Join(gitDir(dir), "description")); err == nil &&
+		!bytes.HasPrefix(description, []byte("Unnamed repository")) {
+		repository.Description = strings.TrimSpace(string(description))
+	}
+	if output, err := git(dir, "symbolic-ref", "--short", "HEAD"); err == nil {
+		repository.DefaultBranch = strings.TrimSpace(output)
+	}
+
+	if info, err := os.Stat(dir); err == nil {
+		repository.CreatedAt = info.ModTime().UTC()
+		repository.UpdatedAt = repository.CreatedAt
+	}
+	if output, err := git(dir, "log", "--max-parents=0", "--format=%cI", "HEAD"); err == nil {
+		if date, ok := lastDate(output); ok {
+			repository.CreatedAt = date
+		}
+	}
+	if output, err := git(dir, "for-each-ref", "--sort=-committerdate", "--count=1",
+		"--format=%(committerdate:iso-strict)", "refs/heads"); err == nil {
+		if date, ok := lastDate(output); ok {
+			repository.UpdatedAt = date
+		}
+	}
+	repository.PushedAt = repository.UpdatedAt
+	return repository
+}
+
+// ownerDir returns the directory of an owner.
+func (lg LocalGitClient) ownerDir(owner string) (string, error) {
+	dir := filepath.Join(lg.root, owner)
+	if info, err := os.Stat(dir); !isPathElement(owner) || err != nil || !info.IsDir() {
+		return "", fmt.Errorf("%w: %s", ErrNotFound, owner)
+	}
+	return dir, nil
+}
+
+// isPathElement reports whether name is a single element of a path that
+// stays in its parent directory.
+func isPathElement(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`)
+}
+
+// isRepository reports whether dir is a working copy or a bare repository.
+func isRepository(dir string) bool {
+	_, err := os.Stat(filepath.Join(gitDir(dir), "HEAD"))
+	return err == nil
+}
+
+// gitDir returns the git directory of a working copy or bare repository.
+func gitDir(dir string) string {
+	if info, err := os.Stat(filepath.Join(dir, ".git")); err == nil && info.IsDir() {
+		return filepath.Join(dir, ".git")
+	}
+	return dir
+}
+
+// lastDate parses the last of the ISO 8601 dates printed one per line.
+func lastDate(output string) (time.Time, bool) {
+	lines := strings.Fields(output)
+	if len(lines) == 0 {
+		return time.Time{}, false
+	}
+	date, err := time.Parse(time.RFC3339, lines[len(lines)-1])
+	return date.UTC(), err == nil
+}
+
+// git runs a git command in the repository dir. Mirrors are often owned by
+// another user, so the repository is trusted, and only that one: dir is
+// always a repository of the repositories directory.
+func git(dir string, args ...string) (string, error) {
+	cmd := exec.Command("git", append([]string{"-c", "safe.directory=" + dir, "-C", dir}, args...)...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	output, err := cmd.Output()
+	if err != nil {
+		log.Printf("RDS: git %s in %s: %s\n", args[0], dir, strings.TrimSpace(stderr.String()))
+		return "", err
+	}
+	return string(output), nil
+}
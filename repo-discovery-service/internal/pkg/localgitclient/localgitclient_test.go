@@ -0,0 +1,146 @@
+package localgitclient
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"repos-discovery-service/internal/constants"
+	"repos-discovery-service/internal/constants/models"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// run runs a git command in dir with fixed identities and dates.
+func run(t *testing.T, dir string, date string, args ...string) string {
+	cmd := exec.Command("git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(),
+		"GIT_AUTHOR_NAME=Jane", "GIT_AUTHOR_EMAIL=jane@corp", "GIT_AUTHOR_DATE="+date,
+		"GIT_COMMITTER_NAME=John", "GIT_COMMITTER_EMAIL=john@corp", "GIT_COMMITTER_DATE="+date,
+		"GIT_CONFIG_NOSYSTEM=1", "HOME="+t.TempDir(),
+	)
+	output, err := cmd.CombinedOutput()
+	require.NoError(t, err, string(output))
+	return strings.TrimSpace(string(output))
+}
+
+// newTestRepositories lays out mirrors/app as a working copy with two
+// commits on main and a newer one on a second branch, mirrors/lib.git as a
+// bare clone of it, and mirrors/notes as a directory that is no repository.
+func newTestRepositories(t *testing.T) string {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+
+	root := t.TempDir()
+	dir := filepath.Join(root, "mirrors", "app")
+	require.NoError(t, os.MkdirAll(dir, 0o755))
+
+	run(t, dir, "2024-03-01T10:00:00Z", "init", "-q", "-b", "main")
+	run(t, dir, "2024-03-01T10:00:00Z", "commit", "-q", "--allow-empty", "-m", "Initial commit")
+	run(t, dir, "2024-03-02T10:00:00Z", "commit", "-q", "--allow-empty", "-m", "Second commit")
+	run(t, dir, "2024-03-02T10:00:00Z", "checkout", "-q", "-b", "feature")
+	run(t, dir, "2024-03-05T10:00:00Z", "commit", "-q", "--allow-empty", "-m", "Feature commit")
+	run(t, dir, "2024-03-05T10:00:00Z", "checkout", "-q", "main")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, ".git", "description"), []byte("The app\n"), 0o644))
+
+	run(t, root, "2024-03-05T10:00:00Z", "clone", "-q", "--bare", dir, filepath.Join(root, "mirrors", "lib.git"))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, "mirrors", "notes"), 0o755))
+	require.NoError(t, os.MkdirAll(filepath.Join(root, ".cache"), 0o755))
+	return root
+}
+
+func newTestClient(t *testing.T, root string) LocalGitClient {
+	client, err := NewLocalGitClient(&models.Config{LocalReposDir: root})
+	require.NoError(t, err)
+	return client
+}
+
+func TestOwners(t *testing.T) {
+	client := newTestClient(t, newTestRepositories(t))
+
+	owners, err := client.Owners()
+	require.NoError(t, err)
+	require.Equal(t, []models.Owner{{Login: "mirrors"}}, owners)
+
+	ownerType, err := client.FetchOwnerType("mirrors")
+	require.NoError(t, err)
+	require.Equal(t, constants.OWNER_TYPE_ORGANIZATION, ownerType)
+
+	_, err = client.FetchOwnerType("..")
+	require.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestFetchRepositories(t *testing.T) {
+	client := newTestClient(t, newTestRepositories(t))
+
+	first, err := client.FetchRepositories("mirrors", constants.OWNER_TYPE_ORGANIZATION, 1, 1)
+	require.NoError(t, err)
+	require.Len(t, first.Repositories, 1)
+	require.Equal(t, 2, first.NextPage)
+	require.Equal(t, 2, first.LastPage)
+
+	app := first.Repositories[0]
+	require.Equal(t, "mirrors/app", app.FullName)
+	require.Equal(t, "app", app.Name)
+	require.Equal(t, "mirrors", app.Owner.Login)
+	require.Equal(t, constants.PROVIDER_LOCAL, app.Provider)
+	require.Equal(t, "main", app.DefaultBranch)
+	require.Equal(t, "The app", app.Description)
+	// created with its root commit, updated with its newest branch
+	require.Equal(t, "2024-03-01T10:00:00Z", app.CreatedAt.Format("2006-01-02T15:04:05Z07:00"))
+	require.Equal(t, "2024-03-05T10:00:00Z", app.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"))
+	require.Equal(t, app.UpdatedAt, app.PushedAt)
+
+	second, err := client.FetchRepositories("mirrors", constants.OWNER_TYPE_ORGANIZATION, 1, 2)
+	require.NoError(t, err)
+	require.Len(t, second.Repositories, 1)
+	require.Zero(t, second.NextPage)
+	require.Equal(t, "mirrors/lib", second.Repositories[0].FullName)
+	require.Nil(t, second.Repositories[0].Description)
+
+	beyond, err := client.FetchRepositories("mirrors", constants.OWNER_TYPE_ORGANIZATION, 10, 3)
+	require.NoError(t, err)
+	require.Empty(t, beyond.Repositories)
+
+	_, err = client.FetchRepositories("nobody", constants.OWNER_TYPE_ORGANIZATION, 10, 1)
+	require.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestFetchRepositoryMetadata(t *testing.T) {
+	client := newTestClient(t, newTestRepositories(t))
+
+	metadata, err := client.FetchRepositoryMetadata("mirrors/lib")
+	require.NoError(t, err)
+	require.Equal(t, "mirrors/lib", metadata.Repository.FullName)
+	require.Equal(t, "main", metadata.Repository.DefaultBranch)
+
+	for _, fullName := range []string{"mirrors/notes", "mirrors/missing", "mirrors/../mirrors/app", "mirrors", "../mirrors/app"} {
+		_, err := client.FetchRepositoryMetadata(fullName)
+		require.True(t, errors.Is(err, ErrNotFound), fullName)
+	}
+}
+
+// TestForeignOwnedRepository reads a repository owned by another user,
+// which git refuses unless it is trusted.
+func TestForeignOwnedRepository(t *testing.T) {
+	if os.Getuid() != 0 {
+		t.Skip("changing the owner of a repository needs root")
+	}
+	root := newTestRepositories(t)
+	require.NoError(t, filepath.Walk(filepath.Join(root, "mirrors"), func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		return os.Lchown(path, 65534, 65534)
+	}))
+	client := newTestClient(t, root)
+
+	for _, fullName := range []string{"mirrors/app", "mirrors/lib"} {
+		metadata, err := client.FetchRepositoryMetadata(fullName)
+		require.NoError(t, err)
+		require.Equal(t, "main", metadata.Repository.DefaultBranch, fullName)
+	}
+}
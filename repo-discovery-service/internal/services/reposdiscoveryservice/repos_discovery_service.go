@@ -2,12 +2,15 @@ package reposdiscoveryservice
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"repos-discovery-service/internal/constants"
 	"repos-discovery-service/internal/constants/models"
 	rmdsc "repos-discovery-service/internal/http/grpc/client/repos"
 	"repos-discovery-service/internal/message-broker/rabbitmq"
 	"repos-discovery-service/internal/pkg/githubrestclient"
+	"strings"
+	"sync"
 
 	"time"
 
@@ -16,21 +19,86 @@ import (
 
 const perPage = 10
 
+// Provider is a code hosting service repositories are discovered on, a
+// GitHub server, a GitLab instance or a directory of git repositories.
+// Repositories are listed in the shape of GitHub responses whatever the
+// provider.
+type Provider interface {
+	// Name is the provider the discovered repositories are tagged with.
+	Name() string
+	FetchOwnerType(owner string) (string, error)
+	FetchRepositories(owner, ownerType string, perPage, page int) (githubrestclient.RepositoriesPage, error)
+	FetchRepositoryMetadata(fullName string) (githubrestclient.RepositoryMetadata, error)
+	RateLimit() githubrestclient.RateLimit
+	TokenUsage() []githubrestclient.TokenUsage
+}
+
 type ReposDiscoveryService struct {
-	GithubRestClient           githubrestclient.GithubRestClient
+	Config                     *models.Config
+	Provider                   Provider
 	ReposMetaDataServiceClient rmdsc.RepositoriesServiceClient
 	Rabbit                     *amqp.Connection
+
+	// serverClients are the providers configured for some owners, keyed by
+	// lower cased owner login.
+	serverClients map[string]Provider
+	servers       []Provider
+	paused        *pausedSet
+}
+
+// pausedSet holds the lower cased full names of the repositories paused in
+// the commits manager, as listed last.
+type pausedSet struct {
+	mu     sync.Mutex
+	byRepo map[string]bool
 }
 
 func NewReposDiscoveryService(
-	githubRestClient githubrestclient.GithubRestClient,
+	config *models.Config,
+	provider Provider,
 	reposMetaDataServiceClient rmdsc.RepositoriesServiceClient,
 	rabbit *amqp.Connection,
 ) ReposDiscoveryService {
 	return ReposDiscoveryService{
-		GithubRestClient:           githubRestClient,
+		Config:                     config,
+		Provider:                   provider,
 		ReposMetaDataServiceClient: reposMetaDataServiceClient,
 		Rabbit:                     rabbit,
+		serverClients:              map[string]Provider{},
+		paused:                     &pausedSet{byRepo: map[string]bool{}},
+	}
+}
+
+// AddServer discovers the repositories of owners through provider.
+func (sc *ReposDiscoveryService) AddServer(owners []string, provider Provider) {
+	sc.servers = append(sc.servers, provider)
+	for _, owner := range owners {
+		sc.serverClients[strings.ToLower(owner)] = provider
+	}
+}
+
+// client returns the provider serving owner.
+func (sc *ReposDiscoveryService) client(owner string) Provider {
+	if client, ok := sc.serverClients[strings.ToLower(owner)]; ok {
+		return client
+	}
+	return sc.Provider
+}
+
+// repositoryClient returns the provider of the owner of a repository. GitLab
+// projects may sit in subgroups, the longest namespace configured as owner
+// wins.
+func (sc *ReposDiscoveryService) repositoryClient(fullName string) Provider {
+	namespace := strings.ToLower(fullName)
+	for {
+		i := strings.LastIndex(namespace, "/")
+		if i < 0 {
+			return sc.Provider
+		}
+		namespace = namespace[:i]
+		if client, ok := sc.serverClients[namespace]; ok {
+			return client
+		}
 	}
 }
 
@@ -55,59 +123,187 @@ func (sc *ReposDiscoveryService) ScheduleFetchingRepositoryMetadata(interval tim
 }
 
 func (sc *ReposDiscoveryService) discoverAndSaveNewRepositories() {
-	repoFetchHistory, err := sc.ReposMetaDataServiceClient.GetReposFetchHistory()
+	sc.waitForRateLimit()
+
+	paused := sc.pausedRepositories()
+	for _, owner := range sc.owners() {
+		sc.discoverAndSaveNewRepositoriesOfOwner(owner, paused)
+	}
+	sc.logRateLimit()
+}
+
+// owners returns the local, GitLab and GitHub owners whose repositories are
+// discovered. An owner listed twice, mirrored on disk for instance, is
+// discovered once, as the owner of the provider its repositories are served
+// from: local repositories over GitLab over GitHub, like the commits monitor
+// routes them.
+func (sc *ReposDiscoveryService) owners() []models.Owner {
+	var owners []models.Owner
+	seen := map[string]bool{}
+	for _, list := range [][]models.Owner{sc.Config.LocalOwners, sc.Config.GitlabOwners, sc.Config.Owners()} {
+		for _, owner := range list {
+			if login := strings.ToLower(owner.Login); !seen[login] {
+				seen[login] = true
+				owners = append(owners, owner)
+			}
+		}
+	}
+	return owners
+}
+
+// discoverAndSaveNewRepositoriesOfOwner pushes the repositories of an owner
+// page by page, leaving out the paused ones.
+func (sc *ReposDiscoveryService) discoverAndSaveNewRepositoriesOfOwner(owner models.Owner, paused map[string]bool) {
+	repoFetchHistory, err := sc.ReposMetaDataServiceClient.GetReposFetchHistory(owner.Login)
 	if err != nil {
-		log.Println("RDS: Error getting all repositories last fetch time")
+		log.Println("RDS: Error getting all repositories last fetch time of ", owner.Login)
 		log.Println("RDS: ERR:", err)
 	}
 
+	ownerType, err := sc.ownerType(owner)
+	if err != nil {
+		log.Println("RDS: error getting the owner type of ", owner.Login)
+		log.Println("RDS: err:", err)
+		return
+	}
+
 	page := int(repoFetchHistory.LastPage + 1)
 	var totalRepositories int
-	log.Printf("RDS: discovering new repositoy started from page ->: %d /n", page)
+	log.Printf("RDS: discovering new repositories of <%s> started from page ->: %d\n", owner.Login, page)
 
-	for {
-		repositories, err := sc.GithubRestClient.FetchRepositories(perPage, page)
+	for page != 0 {
+		repositoriesPage, err := sc.client(owner.Login).FetchRepositories(owner.Login, ownerType, perPage, page)
 		if err != nil {
-			log.Println("RDS: error fetching repositories ")
+			log.Println("RDS: error fetching repositories of ", owner.Login)
 			log.Println("RDS: err:", err)
 			return
 		}
 
-		if len(repositories) == 0 {
-			break
-		}
+		repositories := repositoriesPage.Repositories
+		switch {
+		case len(repositories) == 0:
+		case repositoriesPage.NotModified:
+			log.Printf("RDS: repositories page %d of <%s> not modified\n", page, owner.Login)
+		default:
+			log.Printf("RDS: pulled %d repositories of <%s> page %d/%d\n", len(repositories), owner.Login, page, max(repositoriesPage.LastPage, page))
 
-		log.Printf("RDS: pulled %d repositories \n", len(repositories))
-		
-		fetchTime := repositories[len(repositories)-1].CreatedAt
-		sc.pushNewRepositoriesToQueue(fetchTime, page, repositories)
+			fetchTime := repositories[len(repositories)-1].CreatedAt
+			tracked := make([]models.RepositoryResponse, 0, len(repositories))
+			for _, repository := range repositories {
+				if paused[strings.ToLower(repository.FullName)] {
+					continue
+				}
+				repository.Provider = sc.client(owner.Login).Name()
+				tracked = append(tracked, repository)
+			}
+			sc.pushNewRepositoriesToQueue(owner.Login, fetchTime, page, tracked)
 
-		totalRepositories += len(repositories)
-		page++
+			totalRepositories += len(repositories)
+		}
+
+		page = repositoriesPage.NextPage
 	}
 
-	log.Println("RDS: total fetched repos: ", totalRepositories)
+	log.Printf("RDS: total fetched repos of <%s>: %d\n", owner.Login, totalRepositories)
+}
+
+// ownerType returns the configured type of the owner, or looks it up on
+// its provider when none is configured.
+func (sc *ReposDiscoveryService) ownerType(owner models.Owner) (string, error) {
+	switch strings.ToLower(owner.Type) {
+	case "":
+		return sc.client(owner.Login).FetchOwnerType(owner.Login)
+	case "user":
+		return constants.OWNER_TYPE_USER, nil
+	case "org", "organization", "group":
+		return constants.OWNER_TYPE_ORGANIZATION, nil
+	}
+	return "", fmt.Errorf("RDS: unknown owner type %q of %s, expected user or org", owner.Type, owner.Login)
 }
 
 func (sc *ReposDiscoveryService) fetchRepositoriesMetadata() {
+	sc.waitForRateLimit()
+
 	repositories, err := sc.ReposMetaDataServiceClient.GetRepositoryNames()
 	if err != nil {
 		log.Println("RDS: error getting repository names")
 		log.Println("RDS: err: ", err)
 	}
 
-	for _, repoName := range repositories {
-		repository, err := sc.GithubRestClient.FetchRepositoryMetadata(repoName)
+	paused := sc.pausedRepositories()
+	for _, fullName := range repositories {
+		if paused[strings.ToLower(fullName)] {
+			continue
+		}
+		client := sc.repositoryClient(fullName)
+		metadata, err := client.FetchRepositoryMetadata(fullName)
 		if err != nil {
 			log.Println("RDS: error getting repository meta data")
 			log.Println("RDS: err:", err)
+			continue
+		}
+		if metadata.NotModified {
+			continue
+		}
+		metadata.Repository.Provider = client.Name()
+		sc.pushRepositoryMetaDataToQueue(metadata.Repository)
+	}
+	sc.logRateLimit()
+}
+
+// pausedRepositories returns the lower cased full names of the repositories
+// paused in the commits manager, keeping the ones listed last when it cannot
+// be reached, as the commits monitor keeps its settings.
+func (sc *ReposDiscoveryService) pausedRepositories() map[string]bool {
+	trackedRepositories, err := sc.ReposMetaDataServiceClient.GetTrackedRepositories()
+	if err != nil {
+		log.Println("RDS: error getting the tracked repositories settings")
+		log.Println("RDS: err:", err)
+		sc.paused.mu.Lock()
+		defer sc.paused.mu.Unlock()
+		return sc.paused.byRepo
+	}
+
+	paused := map[string]bool{}
+	for _, settings := range trackedRepositories {
+		if !settings.GetEnabled() {
+			paused[strings.ToLower(settings.GetRepository())] = true
 		}
-		sc.pushRepositoryMetaDataToQueue(repository)
+	}
+	sc.paused.mu.Lock()
+	sc.paused.byRepo = paused
+	sc.paused.mu.Unlock()
+	return paused
+}
+
+// waitForRateLimit defers a cycle until the budget of the default provider
+// resets when the previous cycle used it up.
+func (sc *ReposDiscoveryService) waitForRateLimit() {
+	budget := sc.Provider.RateLimit()
+	if !budget.Exhausted() {
+		return
+	}
+	wait := time.Until(budget.Reset)
+	log.Printf("RDS: rate limit exhausted, deferring by %s\n", wait.Round(time.Second))
+	time.Sleep(wait)
+}
+
+func (sc *ReposDiscoveryService) logRateLimit() {
+	budget := sc.Provider.RateLimit()
+	log.Printf("RDS: rate limit %d/%d remaining, resets at %s\n",
+		budget.Remaining, budget.Limit, budget.Reset.Format(time.RFC3339))
+	usage := sc.Provider.TokenUsage()
+	for _, client := range sc.servers {
+		usage = append(usage, client.TokenUsage()...)
+	}
+	for _, usage := range usage {
+		log.Printf("RDS: token %s sent %d requests, %d/%d remaining\n",
+			usage.Token, usage.Requests, usage.RateLimit.Remaining, usage.RateLimit.Limit)
 	}
 }
 
 // pushNewRepositoriesToQueue pushes a message into RabbitMQ
-func (sc *ReposDiscoveryService) pushNewRepositoriesToQueue(fetchTime time.Time, lastPage int, repos []models.RepositoryResponse) error {
+func (sc *ReposDiscoveryService) pushNewRepositoriesToQueue(owner string, fetchTime time.Time, lastPage int, repos []models.RepositoryResponse) error {
 	emitter, err := event.NewEventEmitter(sc.Rabbit)
 	if err != nil {
 		return err
@@ -116,6 +312,7 @@ func (sc *ReposDiscoveryService) pushNewRepositoriesToQueue(fetchTime time.Time,
 	j, err := json.MarshalIndent(&event.Payload{
 		Name: "repos",
 		Data: ReposMetaData{
+			Owner:     owner,
 			FetchTime: fetchTime,
 			Repos:     repos,
 			LastPage:  lastPage,
@@ -155,6 +352,7 @@ func (sc *ReposDiscoveryService) pushRepositoryMetaDataToQueue(repo models.Repos
 }
 
 type ReposMetaData struct {
+	Owner     string
 	LastPage  int
 	FetchTime time.Time
 	Repos     []models.RepositoryResponse